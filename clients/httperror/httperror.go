@@ -0,0 +1,65 @@
+// Package httperror decodes the error responses returned by the eigenda-proxy, and is shared by the proxy clients.
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// 503 error type informing rollup to failover to other DA location
+	ErrServiceUnavailable = fmt.Errorf("eigenda service is temporarily unavailable")
+)
+
+// CertVerificationFailed is the JSON body of the 418 responses returned by the proxy for certs which fail
+// verification. Rollup derivation pipelines should drop such certs.
+type CertVerificationFailed struct {
+	// StatusCode is the VerificationStatusCode returned by the cert verifier, and not an HTTP status code
+	StatusCode uint8  `json:"StatusCode"`
+	Msg        string `json:"Msg"`
+	RequestID  string `json:"RequestID,omitempty"`
+}
+
+// ResponseError is returned by the clients when the proxy responds with an unexpected status code.
+type ResponseError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Body is the raw body of the response. It is plain text, except for 418 responses.
+	Body []byte
+	// CertVerificationFailed is the decoded body of 418 responses, and is nil for other responses
+	// (or 418 responses whose body isn't the expected JSON).
+	CertVerificationFailed *CertVerificationFailed
+
+	msg string
+}
+
+// NewResponseError returns the error for a response with the given status code and body. The error message is msg.
+func NewResponseError(msg string, statusCode int, body []byte) *ResponseError {
+	respErr := &ResponseError{
+		StatusCode: statusCode,
+		Body:       body,
+		msg:        msg,
+	}
+	if statusCode == http.StatusTeapot {
+		var certErr CertVerificationFailed
+		if err := json.Unmarshal(body, &certErr); err == nil {
+			respErr.CertVerificationFailed = &certErr
+		}
+	}
+	return respErr
+}
+
+func (e *ResponseError) Error() string {
+	return e.msg
+}
+
+// AsCertVerificationFailed returns the decoded 418 body of err, if err is (or wraps) a ResponseError of a 418 response.
+func AsCertVerificationFailed(err error) (*CertVerificationFailed, bool) {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.CertVerificationFailed == nil {
+		return nil, false
+	}
+	return respErr.CertVerificationFailed, true
+}
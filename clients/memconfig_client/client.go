@@ -29,6 +29,9 @@ type MemConfig struct {
 	PutLatency              time.Duration
 	GetLatency              time.Duration
 	PutReturnsFailoverError bool
+	MaxTotalBytes           uint64
+	MaxEntries              uint64
+	EvictionPolicy          string
 }
 
 // MarshalJSON implements custom JSON marshaling for Config.
@@ -41,6 +44,9 @@ func (c MemConfig) MarshalJSON() ([]byte, error) {
 		PutLatency:              c.PutLatency.String(),
 		GetLatency:              c.GetLatency.String(),
 		PutReturnsFailoverError: c.PutReturnsFailoverError,
+		MaxTotalBytes:           c.MaxTotalBytes,
+		MaxEntries:              c.MaxEntries,
+		EvictionPolicy:          c.EvictionPolicy,
 	})
 }
 
@@ -52,6 +58,10 @@ type intermediaryCfg struct {
 	PutLatency              string
 	GetLatency              string
 	PutReturnsFailoverError bool
+	MaxTotalBytes           uint64
+	MaxEntries              uint64
+	// omitted when empty, since the server rejects unknown eviction policies
+	EvictionPolicy string `json:",omitempty"`
 }
 
 // IntoMemConfig ... converts an intermediary config into a memconfig
@@ -78,6 +88,9 @@ func (cfg *intermediaryCfg) IntoMemConfig() (*MemConfig, error) {
 		PutLatency:              putLatency,
 		GetLatency:              getLatency,
 		PutReturnsFailoverError: cfg.PutReturnsFailoverError,
+		MaxTotalBytes:           cfg.MaxTotalBytes,
+		MaxEntries:              cfg.MaxEntries,
+		EvictionPolicy:          cfg.EvictionPolicy,
 	}, nil
 }
 
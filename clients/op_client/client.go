@@ -0,0 +1,137 @@
+// Package op_client is a client for the eigenda-proxy's [optimism commitment mode] routes.
+//
+// It sends payloads to the proxy and receives altda commitments, which are the serialized DA certs prefixed with the
+// [op generic commitment] header bytes. OP stack rollups should use op's [DAClient], which speaks the same protocol
+// and is integrated in the batcher and derivation pipeline. This client is meant for tooling and tests, which
+// don't want to depend on the optimism monorepo.
+//
+// [optimism commitment mode]: https://github.com/Layr-Labs/eigenda-proxy#optimism-commitment-mode
+// [op generic commitment]: https://specs.optimism.io/experimental/alt-da.html#example-commitments
+// [DAClient]: https://pkg.go.dev/github.com/ethereum-optimism/optimism/op-alt-da#DAClient
+package op_client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/clients/httperror"
+)
+
+var (
+	// 503 error type informing rollup to failover to other DA location
+	ErrServiceUnavailable = httperror.ErrServiceUnavailable
+)
+
+type Config struct {
+	URL string // EigenDA proxy REST API URL
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOption func(c *Client)
+
+// WithHTTPClient ... Embeds custom http client type
+func WithHTTPClient(client HTTPClient) ClientOption {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// Client implements an optimism commitment mode client for the eigenda-proxy.
+type Client struct {
+	cfg        *Config
+	httpClient HTTPClient
+}
+
+// New ... constructor
+func New(cfg *Config, opts ...ClientOption) *Client {
+	client := &Client{
+		cfg,
+		http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// GetData fetches the payload associated with an altda commitment, which is either an op generic commitment
+// (as returned by SetData, prefixed with 0x01) or an op keccak256 commitment (prefixed with 0x00).
+func (c *Client) GetData(ctx context.Context, comm []byte) ([]byte, error) {
+	url := fmt.Sprintf("%s/get/0x%x", c.cfg.URL, comm)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct http request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// errors of 418 responses hold the decoded reason of the cert verification failure, see
+	// [httperror.AsCertVerificationFailed]
+	if resp.StatusCode != http.StatusOK {
+		return nil, httperror.NewResponseError(fmt.Sprintf(
+			"received error response when reading from eigenda-proxy, code=%d, msg = %s",
+			resp.StatusCode,
+			string(b),
+		), resp.StatusCode, b)
+	}
+
+	return b, nil
+}
+
+// SetData writes raw byte data to DA and returns its op generic commitment.
+// The commitment doesn't include the altda version byte, which is added by the batcher when posting it on L1.
+func (c *Client) SetData(ctx context.Context, b []byte) ([]byte, error) {
+	url := fmt.Sprintf("%s/put", c.cfg.URL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// failover signal
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return nil, ErrServiceUnavailable
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httperror.NewResponseError(fmt.Sprintf(
+			"received error response when dispersing to eigenda-proxy, code=%d, err = %s",
+			resp.StatusCode,
+			string(b),
+		), resp.StatusCode, b)
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("received an empty commitment")
+	}
+
+	return b, nil
+}
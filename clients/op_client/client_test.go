@@ -0,0 +1,80 @@
+package op_client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/clients/httperror"
+	"github.com/Layr-Labs/eigenda-proxy/clients/op_client"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testCommitment = []byte{0x01, 0x00, 0x02, 0xab}
+	testPayload    = []byte("my-eigenda-payload")
+)
+
+// newTestClient returns a client of a fake proxy, which:
+//   - returns testCommitment for posted payloads, or a 503 for the "unavailable" payload
+//   - returns testPayload for testCommitment
+//   - returns a 418 for the 0x010002cd commitment
+func newTestClient(t *testing.T) *op_client.Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /put", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if string(body) == "unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(testCommitment)
+	})
+	mux.HandleFunc("GET /get/0x010002ab", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(testPayload)
+	})
+	mux.HandleFunc("GET /get/0x010002cd", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte(`{"StatusCode":3,"Msg":"invalid inclusion proof","RequestID":"req-1"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return op_client.New(&op_client.Config{URL: server.URL})
+}
+
+func TestClient_SetData(t *testing.T) {
+	client := newTestClient(t)
+
+	commitment, err := client.SetData(context.Background(), testPayload)
+	require.NoError(t, err)
+	require.Equal(t, testCommitment, commitment)
+}
+
+func TestClient_SetDataServiceUnavailable(t *testing.T) {
+	client := newTestClient(t)
+
+	// a 503 tells the batcher to failover to ethDA
+	_, err := client.SetData(context.Background(), []byte("unavailable"))
+	require.ErrorIs(t, err, op_client.ErrServiceUnavailable)
+}
+
+func TestClient_GetData(t *testing.T) {
+	client := newTestClient(t)
+
+	payload, err := client.GetData(context.Background(), testCommitment)
+	require.NoError(t, err)
+	require.Equal(t, testPayload, payload)
+}
+
+func TestClient_GetDataCertVerificationFailed(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.GetData(context.Background(), []byte{0x01, 0x00, 0x02, 0xcd})
+	certErr, ok := httperror.AsCertVerificationFailed(err)
+	require.True(t, ok, "expected a cert verification failure, got %v", err)
+	require.Equal(t,
+		httperror.CertVerificationFailed{StatusCode: 3, Msg: "invalid inclusion proof", RequestID: "req-1"}, *certErr)
+}
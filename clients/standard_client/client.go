@@ -17,11 +17,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/clients/httperror"
 )
 
 var (
 	// 503 error type informing rollup to failover to other DA location
-	ErrServiceUnavailable = fmt.Errorf("eigenda service is temporarily unavailable")
+	ErrServiceUnavailable = httperror.ErrServiceUnavailable
 )
 
 type Config struct {
@@ -109,12 +111,14 @@ func (c *Client) GetData(ctx context.Context, comm []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	// errors of 418 responses hold the decoded reason of the cert verification failure, see
+	// [httperror.AsCertVerificationFailed]
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
+		return nil, httperror.NewResponseError(fmt.Sprintf(
 			"received error response when reading from eigenda-proxy, code=%d, msg = %s",
 			resp.StatusCode,
 			string(b),
-		)
+		), resp.StatusCode, b)
 	}
 
 	return b, nil
@@ -146,11 +150,11 @@ func (c *Client) SetData(ctx context.Context, b []byte) ([]byte, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
+		return nil, httperror.NewResponseError(fmt.Sprintf(
 			"received error response when dispersing to eigenda-proxy, code=%d, err = %s",
 			resp.StatusCode,
 			string(b),
-		)
+		), resp.StatusCode, b)
 	}
 
 	if len(b) == 0 {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
+	proxy_metrics "github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Backfill fetches and verifies the payloads of existing commitments through the storage backends configured the
+// same way as the proxy, and writes them to the backfill targets. A JSON summary report is written once done.
+func Backfill(cliCtx *cli.Context) error {
+	logCfg, err := proxy_logging.ReadLoggerCLIConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	log, err := proxy_logging.NewLogger(*logCfg)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadAppConfig(cliCtx)
+	if err != nil {
+		return fmt.Errorf("read cli config: %w", err)
+	}
+	if err = cfg.Check(); err != nil {
+		return fmt.Errorf("check config: %w", err)
+	}
+	backfillCfg := backfill.ReadConfig(cliCtx)
+	if err = backfillCfg.Check(); err != nil {
+		return fmt.Errorf("check backfill config: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(cliCtx.Context)
+	defer cancel()
+	if backfillCfg.Tenant != "" {
+		t, tenantErr := findTenant(cfg.ServerConfig.Tenants, backfillCfg.Tenant)
+		if tenantErr != nil {
+			return tenantErr
+		}
+		ctx = tenant.ContextWithTenant(ctx, t)
+	}
+
+	storeManager, err := builder.BuildStoreManager(
+		ctx,
+		log,
+		proxy_metrics.NoopMetrics,
+		cfg.StoreBuilderConfig,
+		cfg.SecretConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("build storage manager: %w", err)
+	}
+	targets, err := builder.BuildSecondaryTargets(ctx, log, cfg.StoreBuilderConfig, backfillCfg.Targets)
+	if err != nil {
+		return fmt.Errorf("build backfill targets: %w", err)
+	}
+	source, err := newBackfillSource(ctx, log, backfillCfg)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting backfill", "source", source.Name(), "targets", backfillCfg.Targets,
+		"concurrency", backfillCfg.Concurrency)
+	report, runErr := backfill.NewBackfiller(log, storeManager, targets, backfillCfg).Run(ctx, source)
+	if err = writeReport(cliCtx, backfillCfg.ReportFile, report); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d commitments failed to be backfilled, see the report", report.Failed)
+	}
+	return nil
+}
+
+func newBackfillSource(ctx context.Context, log logging.Logger, cfg backfill.Config) (backfill.Source, error) {
+	if !cfg.ScansL1() {
+		return backfill.NewFileSource(cfg.CommitmentsFile, cfg.CommitmentMode), nil
+	}
+	client, err := ethclient.DialContext(ctx, cfg.L1EthRPC)
+	if err != nil {
+		return nil, fmt.Errorf("dial L1 ETH RPC: %w", err)
+	}
+	var batcher geth_common.Address
+	if cfg.BatcherAddress != "" {
+		batcher = geth_common.HexToAddress(cfg.BatcherAddress)
+	}
+	return backfill.NewL1Source(log, client, geth_common.HexToAddress(cfg.BatchInboxAddress), batcher,
+		cfg.FromBlock, cfg.ToBlock), nil
+}
+
+func findTenant(registry *tenant.Registry, name string) (*tenant.Tenant, error) {
+	if !registry.Enabled() {
+		return nil, fmt.Errorf("tenant %s requested, but multi-tenancy isn't configured", name)
+	}
+	for _, t := range registry.Tenants() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tenant %s", name)
+}
+
+// writeReport writes the JSON summary report of a command to path, or to stdout when path is empty
+func writeReport(cliCtx *cli.Context, path string, report any) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	data = append(data, '\n')
+	if path == "" {
+		_, err = cliCtx.App.Writer.Write(data)
+		return err
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
+	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	kzgverifier "github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	certPayloadFlag = &cli.StringFlag{
+		Name:     "payload",
+		Usage:    "File holding the payload the cert is checked against.",
+		Required: true,
+	}
+	certDisablePointEvaluationFlag = &cli.BoolFlag{
+		Name: "disable-point-evaluation",
+		Usage: "Whether the payload was dispersed with point evaluation disabled " +
+			"(--eigenda.v2.disable-point-evaluation, or --eigenda.disable-point-verification-mode for V1 certs).",
+	}
+	certEthRPCFlag = &cli.StringFlag{
+		Name:    "eth-rpc",
+		Usage:   "ETH RPC endpoint. When set, the cert is also verified against the EigenDA contracts.",
+		EnvVars: []string{config.GlobalEnvVarPrefix + "_CERT_ETH_RPC"},
+	}
+	certNetworkFlag = &cli.StringFlag{
+		Name:  "network",
+		Usage: "EigenDA network whose preset provides the contract addresses used for onchain verification.",
+	}
+	certServiceManagerAddrFlag = &cli.StringFlag{
+		Name:  "service-manager-addr",
+		Usage: "Address of the EigenDA service manager, used for onchain verification of V1 certs.",
+	}
+	certVerifierAddrFlag = &cli.StringFlag{
+		Name: "cert-verifier-addr",
+		Usage: "Address of the EigenDACertVerifierRouter or immutable EigenDACertVerifier, used for onchain " +
+			"verification of V2 certs.",
+	}
+)
+
+// certSubcommands work on commitments entirely in-process, without a running proxy.
+// Commitments which are not passed as arguments are read from stdin.
+var certSubcommands = []*cli.Command{
+	{
+		Name:      "decode",
+		Usage:     "Decode the cert of a commitment to JSON",
+		ArgsUsage: "[commitment]",
+		Flags:     []cli.Flag{clientCommitmentModeFlag, clientEncodingFlag},
+		Action:    CertDecode,
+	},
+	{
+		Name: "verify",
+		Usage: "Check that the KZG commitment of the cert of a commitment matches a payload, " +
+			"and verify the cert onchain when an ETH RPC is given",
+		ArgsUsage: "[commitment]",
+		Flags: append([]cli.Flag{
+			clientCommitmentModeFlag,
+			clientEncodingFlag,
+			certPayloadFlag,
+			certDisablePointEvaluationFlag,
+			certEthRPCFlag,
+			certNetworkFlag,
+			certServiceManagerAddrFlag,
+			certVerifierAddrFlag,
+		}, verify.KZGCLIFlags(config.GlobalEnvVarPrefix, "")...),
+		Action: CertVerify,
+	},
+}
+
+// decodedCert is the cert of a commitment, as printed by CertDecode
+type decodedCert struct {
+	CommitmentMode  commitments.CommitmentMode `json:"commitment_mode"`
+	CertVersion     certs.VersionByte          `json:"cert_version"`
+	CertVersionName string                     `json:"cert_version_name"`
+	// Cert is the decoded cert, with byte strings in hex and big integers in decimal
+	Cert any `json:"cert"`
+}
+
+// CertDecode prints the cert of a commitment as JSON
+func CertDecode(cliCtx *cli.Context) error {
+	mode, commitment, err := readCommitment(cliCtx)
+	if err != nil {
+		return err
+	}
+	versionedCert, err := commitments.DecodeCommitment(commitment, mode)
+	if err != nil {
+		return err
+	}
+	cert, err := decodeCert(versionedCert)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(cliCtx.App.Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(decodedCert{
+		CommitmentMode:  mode,
+		CertVersion:     versionedCert.Version,
+		CertVersionName: certVersionName(byte(versionedCert.Version)),
+		Cert:            toJSONValue(reflect.ValueOf(cert)),
+	})
+}
+
+// CertVerify checks that the KZG commitment of the cert of a commitment matches the payload, the same way as the
+// proxy does on retrieval. The cert is also verified against the EigenDA contracts when an ETH RPC is given.
+func CertVerify(cliCtx *cli.Context) error {
+	logCfg, err := proxy_logging.ReadLoggerCLIConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	log, err := proxy_logging.NewLogger(*logCfg)
+	if err != nil {
+		return err
+	}
+
+	mode, commitment, err := readCommitment(cliCtx)
+	if err != nil {
+		return err
+	}
+	versionedCert, err := commitments.DecodeCommitment(commitment, mode)
+	if err != nil {
+		return err
+	}
+	cert, err := decodeCert(versionedCert)
+	if err != nil {
+		return err
+	}
+	payload, err := os.ReadFile(cliCtx.String(certPayloadFlag.Name))
+	if err != nil {
+		return fmt.Errorf("read payload: %w", err)
+	}
+
+	switch cert := cert.(type) {
+	case *verify.Certificate:
+		err = verifyCertV1(cliCtx, log, cert, payload)
+	case coretypes.EigenDACert:
+		err = verifyCertV2(cliCtx, log, cert, payload)
+	default:
+		err = fmt.Errorf("unsupported cert type %T", cert)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cliCtx.App.Writer, "cert is valid")
+	return err
+}
+
+func verifyCertV1(cliCtx *cli.Context, log logging.Logger, cert *verify.Certificate, payload []byte) error {
+	if err := cert.NoNilFields(); err != nil {
+		return fmt.Errorf("check cert: %w", err)
+	}
+
+	encodedBlob, err := encodePayloadV1(payload, cliCtx.Bool(certDisablePointEvaluationFlag.Name))
+	if err != nil {
+		return err
+	}
+	kzgVerifier, err := newKZGVerifier(cliCtx, len(encodedBlob))
+	if err != nil {
+		return err
+	}
+
+	ethRPC := cliCtx.String(certEthRPCFlag.Name)
+	verifierConfig := &verify.Config{VerifyCerts: ethRPC != "", RPCURL: ethRPC}
+	if verifierConfig.VerifyCerts {
+		verifierConfig.SvcManagerAddr, err = readNetworkAddress(
+			cliCtx, certServiceManagerAddrFlag, common.EigenDANetwork.GetServiceManagerAddress)
+		if err != nil {
+			return err
+		}
+	}
+	verifier, err := verify.NewVerifier(verifierConfig, kzgVerifier, log)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+
+	if err = verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob); err != nil {
+		return fmt.Errorf("payload doesn't match the KZG commitment of the cert: %w", err)
+	}
+	log.Info("Payload matches the KZG commitment of the cert")
+
+	if !verifierConfig.VerifyCerts {
+		log.Warn("Skipping onchain verification of the cert, as no ETH RPC is configured")
+		return nil
+	}
+	if err = verifier.VerifyCert(cliCtx.Context, cert); err != nil {
+		return fmt.Errorf("verify cert onchain: %w", err)
+	}
+	log.Info("Cert is verified onchain")
+	return nil
+}
+
+func verifyCertV2(cliCtx *cli.Context, log logging.Logger, cert coretypes.EigenDACert, payload []byte) error {
+	polynomialForm := codecs.PolynomialFormEval
+	if cliCtx.Bool(certDisablePointEvaluationFlag.Name) {
+		polynomialForm = codecs.PolynomialFormCoeff
+	}
+	blob, err := payloadToBlobV2(payload, polynomialForm)
+	if err != nil {
+		return err
+	}
+	kzgVerifier, err := newKZGVerifier(cliCtx, len(blob))
+	if err != nil {
+		return err
+	}
+	if err = checkBlobCommitmentV2(kzgVerifier, cert, blob); err != nil {
+		return err
+	}
+	log.Info("Payload matches the KZG commitment of the cert")
+
+	ethRPC := cliCtx.String(certEthRPCFlag.Name)
+	if ethRPC == "" {
+		log.Warn("Skipping onchain verification of the cert, as no ETH RPC is configured")
+		return nil
+	}
+	certVerifierAddr, err := readNetworkAddress(
+		cliCtx, certVerifierAddrFlag, common.EigenDANetwork.GetCertVerifierRouterAddress)
+	if err != nil {
+		return err
+	}
+	ethClient, err := geth.NewClient(geth.EthClientConfig{RPCURLs: []string{ethRPC}}, geth_common.Address{}, 0, log)
+	if err != nil {
+		return fmt.Errorf("create geth client: %w", err)
+	}
+	certVerifier, _, err := builder.BuildCertVerifier(
+		cliCtx.Context, log, ethClient, geth_common.HexToAddress(certVerifierAddr))
+	if err != nil {
+		return fmt.Errorf("build cert verifier: %w", err)
+	}
+	if err = certVerifier.CheckDACert(cliCtx.Context, cert); err != nil {
+		return fmt.Errorf("verify cert onchain: %w", err)
+	}
+	log.Info("Cert is verified onchain")
+	return nil
+}
+
+// encodePayloadV1 encodes a payload to the blob committed to by V1 certs
+func encodePayloadV1(payload []byte, disablePointEvaluation bool) ([]byte, error) {
+	codec := codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec())
+	if disablePointEvaluation {
+		codec = codecs.NewNoIFFTCodec(codecs.NewDefaultBlobCodec())
+	}
+	encodedBlob, err := codec.EncodeBlob(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode blob: %w", err)
+	}
+	return encodedBlob, nil
+}
+
+// payloadToBlobV2 converts a payload to the serialized blob committed to by V2 certs
+func payloadToBlobV2(payload []byte, polynomialForm codecs.PolynomialForm) ([]byte, error) {
+	blob, err := coretypes.NewPayload(payload).ToBlob(polynomialForm)
+	if err != nil {
+		return nil, fmt.Errorf("convert payload to blob: %w", err)
+	}
+	return blob.Serialize(), nil
+}
+
+// checkBlobCommitmentV2 checks that the KZG commitment of a V2 cert is the commitment to blob
+func checkBlobCommitmentV2(kzgVerifier *kzgverifier.Verifier, cert coretypes.EigenDACert, blob []byte) error {
+	var certCommitment certV2G1Point
+	switch cert := cert.(type) {
+	case *coretypes.EigenDACertV2:
+		certCommitment = certV2G1Point(cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment)
+	case *coretypes.EigenDACertV3:
+		certCommitment = certV2G1Point(cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment)
+	default:
+		return fmt.Errorf("unsupported cert type %T", cert)
+	}
+
+	commitment, err := verification.GenerateBlobCommitment(kzgVerifier.Srs.G1, blob)
+	if err != nil {
+		return fmt.Errorf("generate blob commitment: %w", err)
+	}
+	if !certCommitment.equal(commitment) {
+		return fmt.Errorf("payload doesn't match the KZG commitment of the cert: computed (%s, %s), cert has (%s, %s)",
+			commitment.X.String(), commitment.Y.String(), certCommitment.X.String(), certCommitment.Y.String())
+	}
+	return nil
+}
+
+// certV2G1Point holds the coordinates of the KZG commitment of V2 certs, whose binding types differ between cert
+// versions but have the same fields.
+type certV2G1Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+func (p certV2G1Point) equal(commitment *bn254.G1Affine) bool {
+	return p.X != nil && p.Y != nil &&
+		commitment.X.BigInt(new(big.Int)).Cmp(p.X) == 0 &&
+		commitment.Y.BigInt(new(big.Int)).Cmp(p.Y) == 0
+}
+
+// newKZGVerifier loads the SRS points needed to commit to blobs of blobLength bytes
+func newKZGVerifier(cliCtx *cli.Context, blobLength int) (*kzgverifier.Verifier, error) {
+	// #nosec G115 - blob lengths are positive
+	kzgConfig := verify.ReadKzgConfig(cliCtx, uint64(blobLength))
+	kzgConfig.SRSNumberToLoad = max(kzgConfig.SRSNumberToLoad, 1)
+	// the verifier doesn't need g2 points, see BuildStoreManager
+	kzgConfig.LoadG2Points = false
+	kzgVerifier, err := kzgverifier.NewVerifier(&kzgConfig, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new kzg verifier: %w", err)
+	}
+	return kzgVerifier, nil
+}
+
+// readNetworkAddress returns the value of the address flag when it is set, and the address of the preset of the
+// network flag otherwise
+func readNetworkAddress(
+	cliCtx *cli.Context, flag *cli.StringFlag, fromNetwork func(common.EigenDANetwork) (string, error),
+) (string, error) {
+	if address := cliCtx.String(flag.Name); address != "" {
+		return address, nil
+	}
+	networkString := cliCtx.String(certNetworkFlag.Name)
+	if networkString == "" {
+		return "", fmt.Errorf("either --%s or --%s is required for onchain verification",
+			flag.Name, certNetworkFlag.Name)
+	}
+	network, err := common.EigenDANetworkFromString(networkString)
+	if err != nil {
+		return "", fmt.Errorf("parse network: %w", err)
+	}
+	address, err := fromNetwork(network)
+	if err != nil {
+		return "", fmt.Errorf("--%s wasn't specified, and failed to get it from the network: %w", flag.Name, err)
+	}
+	return address, nil
+}
+
+// decodeCert RLP-decodes a cert to its type, which depends on its version
+func decodeCert(versionedCert certs.VersionedCert) (any, error) {
+	switch versionedCert.Version {
+	case certs.V0VersionByte:
+		var cert verify.Certificate
+		if err := rlp.DecodeBytes(versionedCert.SerializedCert, &cert); err != nil {
+			return nil, fmt.Errorf("RLP decoding EigenDA v1 cert: %w", err)
+		}
+		return &cert, nil
+	case certs.V1VersionByte:
+		var cert coretypes.EigenDACertV2
+		if err := rlp.DecodeBytes(versionedCert.SerializedCert, &cert); err != nil {
+			return nil, fmt.Errorf("RLP decoding EigenDA v2 cert: %w", err)
+		}
+		return &cert, nil
+	case certs.V2VersionByte:
+		var cert coretypes.EigenDACertV3
+		if err := rlp.DecodeBytes(versionedCert.SerializedCert, &cert); err != nil {
+			return nil, fmt.Errorf("RLP decoding EigenDA v3 cert: %w", err)
+		}
+		return &cert, nil
+	default:
+		return nil, fmt.Errorf("unknown certificate version: %d", versionedCert.Version)
+	}
+}
+
+// certVersionName returns the name of the cert type of a cert version byte
+func certVersionName(versionByte byte) string {
+	version, err := certs.ByteToVersion(versionByte)
+	if err != nil {
+		return "unknown"
+	}
+	switch version {
+	case certs.V0VersionByte:
+		return "EigenDA V1 cert"
+	case certs.V1VersionByte:
+		return "EigenDACertV2"
+	case certs.V2VersionByte:
+		return "EigenDACertV3"
+	default:
+		return "unknown"
+	}
+}
+
+var bigIntType = reflect.TypeOf((*big.Int)(nil))
+
+// toJSONValue converts v to a value whose JSON encoding is readable: byte strings are encoded in hex rather than
+// base64 or arrays of numbers, big integers in decimal strings, and the unexported fields of structs (such as the
+// internal state of protobuf messages) are left out.
+func toJSONValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == bigIntType {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Interface().(*big.Int).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return toJSONValue(v.Elem())
+	case reflect.Struct:
+		fields := make(map[string]any, v.NumField())
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			fields[name] = toJSONValue(v.Field(i))
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, v.Len())
+			for i := range bytes {
+				bytes[i] = byte(v.Index(i).Uint())
+			}
+			return "0x" + hex.EncodeToString(bytes)
+		}
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		elems := make([]any, v.Len())
+		for i := range elems {
+			elems[i] = toJSONValue(v.Index(i))
+		}
+		return elems
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"math/big"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	eigenda_common "github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	kzgverifier "github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func testCertV0() *verify.Certificate {
+	return &verify.Certificate{
+		BlobHeader: &disperser.BlobHeader{
+			Commitment: &eigenda_common.G1Commitment{X: []byte{0x01, 0x02}, Y: []byte{0x03, 0x04}},
+			DataLength: 42,
+			BlobQuorumParams: []*disperser.BlobQuorumParam{
+				{QuorumNumber: 1, AdversaryThresholdPercentage: 29, ConfirmationThresholdPercentage: 30, ChunkLength: 300},
+			},
+		},
+		BlobVerificationProof: &disperser.BlobVerificationProof{
+			BatchMetadata: &disperser.BatchMetadata{
+				BatchHeader: &disperser.BatchHeader{
+					BatchRoot:            []byte{0xaa},
+					QuorumNumbers:        []byte{0x01},
+					ReferenceBlockNumber: 1000,
+				},
+				BatchHeaderHash: []byte{0xbb},
+			},
+			BatchId:   69,
+			BlobIndex: 420,
+		},
+	}
+}
+
+func testCertV2() *coretypes.EigenDACertV2 {
+	var cert coretypes.EigenDACertV2
+	cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X = big.NewInt(123)
+	cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y = big.NewInt(456)
+	return &cert
+}
+
+func testCertV3() *coretypes.EigenDACertV3 {
+	var cert coretypes.EigenDACertV3
+	cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X = big.NewInt(123)
+	cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y = big.NewInt(456)
+	cert.BlobInclusionInfo.BlobIndex = 7
+	cert.BatchHeader.ReferenceBlockNumber = 1000
+	return &cert
+}
+
+func TestDecodeCert(t *testing.T) {
+	tests := []struct {
+		name    string
+		version certs.VersionByte
+		cert    any
+		// path of a field in the JSON value of the cert, and its expected value
+		path []string
+		want any
+	}{
+		{
+			name:    "V0 cert",
+			version: certs.V0VersionByte,
+			cert:    testCertV0(),
+			path:    []string{"blob_header", "commitment", "x"},
+			want:    "0x0102",
+		},
+		{
+			name:    "V1 cert",
+			version: certs.V1VersionByte,
+			cert:    testCertV2(),
+			path:    []string{"BlobInclusionInfo", "BlobCertificate", "BlobHeader", "Commitment", "Commitment", "Y"},
+			want:    "456",
+		},
+		{
+			name:    "V2 cert",
+			version: certs.V2VersionByte,
+			cert:    testCertV3(),
+			path:    []string{"BatchHeader", "ReferenceBlockNumber"},
+			want:    float64(1000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serializedCert, err := rlp.EncodeToBytes(tt.cert)
+			require.NoError(t, err)
+
+			decoded, err := decodeCert(certs.NewVersionedCert(serializedCert, tt.version))
+			require.NoError(t, err)
+			require.IsType(t, tt.cert, decoded)
+			reencoded, err := rlp.EncodeToBytes(decoded)
+			require.NoError(t, err)
+			require.Equal(t, serializedCert, reencoded)
+
+			jsonCert, err := json.Marshal(toJSONValue(reflect.ValueOf(decoded)))
+			require.NoError(t, err)
+			var value any
+			require.NoError(t, json.Unmarshal(jsonCert, &value))
+			for _, field := range tt.path {
+				fields, ok := value.(map[string]any)
+				require.True(t, ok, "expected an object holding %s in %s", field, jsonCert)
+				value = fields[field]
+			}
+			require.Equal(t, tt.want, value)
+		})
+	}
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := decodeCert(certs.VersionedCert{Version: 3, SerializedCert: []byte{0xc0}})
+		require.ErrorContains(t, err, "unknown certificate version")
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		serializedCert, err := rlp.EncodeToBytes(testCertV0())
+		require.NoError(t, err)
+		_, err = decodeCert(certs.NewVersionedCert(serializedCert, certs.V2VersionByte))
+		require.ErrorContains(t, err, "RLP decoding EigenDA v3 cert")
+	})
+}
+
+func TestToJSONValue(t *testing.T) {
+	type inner struct {
+		Bytes []byte
+	}
+	type value struct {
+		Tagged   uint32 `json:"tagged_name"`
+		Skipped  string `json:"-"`
+		Int      *big.Int
+		NilInt   *big.Int
+		Hash     [2]byte
+		Inners   []inner
+		NilSlice []inner
+	}
+	v := value{
+		Tagged:  1,
+		Skipped: "skipped",
+		Int:     big.NewInt(123456789),
+		Hash:    [2]byte{0xab, 0xcd},
+		Inners:  []inner{{Bytes: []byte{0x01}}},
+	}
+
+	require.Equal(t, map[string]any{
+		"tagged_name": uint32(1),
+		"Int":         "123456789",
+		"NilInt":      nil,
+		"Hash":        "0xabcd",
+		"Inners":      []any{map[string]any{"Bytes": "0x01"}},
+		"NilSlice":    nil,
+	}, toJSONValue(reflect.ValueOf(&v)))
+}
+
+func TestCertVersionName(t *testing.T) {
+	tests := []struct {
+		versionByte byte
+		want        string
+	}{
+		{versionByte: byte(certs.V0VersionByte), want: "EigenDA V1 cert"},
+		{versionByte: byte(certs.V1VersionByte), want: "EigenDACertV2"},
+		{versionByte: byte(certs.V2VersionByte), want: "EigenDACertV3"},
+		{versionByte: 0xff, want: "unknown"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, certVersionName(tt.versionByte))
+	}
+}
+
+func TestCheckBlobCommitmentV2(t *testing.T) {
+	kzgConfig := kzg.KzgConfig{
+		G1Path:          "../../resources/g1.point",
+		G2Path:          "../../resources/g2.point",
+		G2TrailingPath:  "../../resources/g2.trailing.point",
+		CacheDir:        "../../resources/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 3000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		LoadG2Points:    false,
+	}
+	kzgVerifier, err := kzgverifier.NewVerifier(&kzgConfig, nil)
+	require.NoError(t, err)
+
+	blob, err := payloadToBlobV2([]byte("Four score and seven years ago"), codecs.PolynomialFormEval)
+	require.NoError(t, err)
+	otherBlob, err := payloadToBlobV2([]byte("Now we are engaged in a great civil war"), codecs.PolynomialFormEval)
+	require.NoError(t, err)
+	commitment, err := verification.GenerateBlobCommitment(kzgVerifier.Srs.G1, blob)
+	require.NoError(t, err)
+
+	certV2 := testCertV2()
+	certV2.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X = commitment.X.BigInt(new(big.Int))
+	certV2.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y = commitment.Y.BigInt(new(big.Int))
+	certV3 := testCertV3()
+	certV3.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X = commitment.X.BigInt(new(big.Int))
+	certV3.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y = commitment.Y.BigInt(new(big.Int))
+
+	tests := []struct {
+		name    string
+		cert    coretypes.EigenDACert
+		blob    []byte
+		wantErr string
+	}{
+		{name: "V2 cert matching the payload", cert: certV2, blob: blob},
+		{name: "V3 cert matching the payload", cert: certV3, blob: blob},
+		{
+			name:    "V2 cert of another payload",
+			cert:    certV2,
+			blob:    otherBlob,
+			wantErr: "payload doesn't match the KZG commitment of the cert",
+		},
+		{
+			name:    "V3 cert of another payload",
+			cert:    certV3,
+			blob:    otherBlob,
+			wantErr: "payload doesn't match the KZG commitment of the cert",
+		},
+		{
+			name:    "cert without commitment",
+			cert:    &coretypes.EigenDACertV3{},
+			blob:    blob,
+			wantErr: "payload doesn't match the KZG commitment of the cert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBlobCommitmentV2(kzgVerifier, tt.cert, tt.blob)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadNetworkAddress(t *testing.T) {
+	addressFlag := &cli.StringFlag{Name: "address"}
+	fromNetwork := func(network common.EigenDANetwork) (string, error) {
+		if network == common.MainnetEigenDANetwork {
+			return "", errors.New("no address on mainnet")
+		}
+		return "0xnetwork", nil
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		network string
+		want    string
+		wantErr string
+	}{
+		{name: "address flag", address: "0xflag", network: string(common.SepoliaTestnetEigenDANetwork), want: "0xflag"},
+		{name: "network preset", network: string(common.SepoliaTestnetEigenDANetwork), want: "0xnetwork"},
+		{name: "neither flag", wantErr: "either --address or --network is required"},
+		{name: "unknown network", network: "unknown", wantErr: "parse network"},
+		{
+			name:    "network without address",
+			network: string(common.MainnetEigenDANetwork),
+			wantErr: "--address wasn't specified, and failed to get it from the network: no address on mainnet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := flag.NewFlagSet(tt.name, flag.ContinueOnError)
+			set.String(addressFlag.Name, tt.address, "")
+			set.String(certNetworkFlag.Name, tt.network, "")
+			cliCtx := cli.NewContext(cli.NewApp(), set, nil)
+
+			address, err := readNetworkAddress(cliCtx, addressFlag, fromNetwork)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, address)
+		})
+	}
+}
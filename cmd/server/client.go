@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/clients/httperror"
+	"github.com/Layr-Labs/eigenda-proxy/clients/op_client"
+	"github.com/Layr-Labs/eigenda-proxy/clients/standard_client"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	hexEncoding    = "hex"
+	base64Encoding = "base64"
+)
+
+var (
+	clientURLFlag = &cli.StringFlag{
+		Name:    "url",
+		Usage:   "URL of the proxy REST API.",
+		Value:   "http://localhost:3100",
+		EnvVars: []string{config.GlobalEnvVarPrefix + "_CLIENT_URL"},
+	}
+	clientCommitmentModeFlag = &cli.StringFlag{
+		Name: "commitment-mode",
+		Usage: fmt.Sprintf("Commitment mode of the commitments: %s or %s. Gets, verifications and inspections "+
+			"also accept %s commitments.", commitments.StandardCommitmentMode,
+			commitments.OptimismGenericCommitmentMode, commitments.OptimismKeccakCommitmentMode),
+		Value: string(commitments.StandardCommitmentMode),
+	}
+	clientEncodingFlag = &cli.StringFlag{
+		Name:  "encoding",
+		Usage: fmt.Sprintf("Encoding of the commitments read and printed: %s or %s.", hexEncoding, base64Encoding),
+		Value: hexEncoding,
+	}
+	clientTimeoutFlag = &cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "Timeout of requests to the proxy. Dispersals can take several minutes on EigenDA networks.",
+		Value: 10 * time.Minute,
+	}
+	clientOutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File the payload is written to. Defaults to stdout.",
+	}
+)
+
+// clientSubcommands talk to a running proxy, which helps debugging without crafting hex commitments by hand.
+// Commitments and payloads which are not passed as arguments are read from stdin.
+var clientSubcommands = []*cli.Command{
+	{
+		Name:      "put",
+		Usage:     "Disperse a payload read from a file (or stdin), and print its commitment",
+		ArgsUsage: "[payload file]",
+		Flags:     []cli.Flag{clientURLFlag, clientCommitmentModeFlag, clientEncodingFlag, clientTimeoutFlag},
+		Action:    ClientPut,
+	},
+	{
+		Name:      "get",
+		Usage:     "Retrieve the payload of a commitment",
+		ArgsUsage: "[commitment]",
+		Flags: []cli.Flag{
+			clientURLFlag, clientCommitmentModeFlag, clientEncodingFlag, clientTimeoutFlag, clientOutFlag,
+		},
+		Action: ClientGet,
+	},
+	{
+		Name: "verify",
+		Usage: "Retrieve the payload of a commitment to check that its cert is valid, " +
+			"printing the reason of the verification failure otherwise",
+		ArgsUsage: "[commitment]",
+		Flags:     []cli.Flag{clientURLFlag, clientCommitmentModeFlag, clientEncodingFlag, clientTimeoutFlag},
+		Action:    ClientVerify,
+	},
+	{
+		Name:      "inspect",
+		Usage:     "Print the structure of a commitment as JSON, without contacting the proxy",
+		ArgsUsage: "[commitment]",
+		Flags:     []cli.Flag{clientCommitmentModeFlag, clientEncodingFlag},
+		Action:    ClientInspect,
+	},
+}
+
+// dataClient is implemented by the standard and op clients
+type dataClient interface {
+	GetData(ctx context.Context, comm []byte) ([]byte, error)
+	SetData(ctx context.Context, b []byte) ([]byte, error)
+}
+
+// ClientPut disperses a payload through the proxy, and prints the returned commitment
+func ClientPut(cliCtx *cli.Context) error {
+	mode, err := readCommitmentMode(cliCtx)
+	if err != nil {
+		return err
+	}
+	if mode == commitments.OptimismKeccakCommitmentMode {
+		return fmt.Errorf("puts of %s commitments are not supported, as they are written to secondary storage only",
+			mode)
+	}
+	payload, err := readInput(cliCtx)
+	if err != nil {
+		return fmt.Errorf("read payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(cliCtx.Context, cliCtx.Duration(clientTimeoutFlag.Name))
+	defer cancel()
+	commitment, err := newDataClient(cliCtx, mode).SetData(ctx, payload)
+	if err != nil {
+		return describeClientError(err)
+	}
+
+	encoded, err := encodeCommitment(cliCtx, commitment)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cliCtx.App.Writer, encoded)
+	return err
+}
+
+// ClientGet retrieves the payload of a commitment through the proxy, and writes it to the output file or stdout
+func ClientGet(cliCtx *cli.Context) error {
+	mode, commitment, err := readCommitment(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cliCtx.Context, cliCtx.Duration(clientTimeoutFlag.Name))
+	defer cancel()
+	payload, err := newDataClient(cliCtx, mode).GetData(ctx, commitment)
+	if err != nil {
+		return describeClientError(err)
+	}
+
+	if out := cliCtx.String(clientOutFlag.Name); out != "" {
+		if err = os.WriteFile(out, payload, 0o600); err != nil {
+			return fmt.Errorf("write payload: %w", err)
+		}
+		return nil
+	}
+	_, err = cliCtx.App.Writer.Write(payload)
+	return err
+}
+
+// ClientVerify retrieves the payload of a commitment through the proxy, which verifies its cert
+func ClientVerify(cliCtx *cli.Context) error {
+	mode, commitment, err := readCommitment(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cliCtx.Context, cliCtx.Duration(clientTimeoutFlag.Name))
+	defer cancel()
+	payload, err := newDataClient(cliCtx, mode).GetData(ctx, commitment)
+	if err != nil {
+		return describeClientError(err)
+	}
+	_, err = fmt.Fprintf(cliCtx.App.Writer, "cert is valid, payload is %d bytes\n", len(payload))
+	return err
+}
+
+// commitmentInfo is the structure of a commitment, as printed by ClientInspect
+type commitmentInfo struct {
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	Length         int                        `json:"length"`
+	// OPCommitmentType and DALayer are the header bytes of op commitments
+	OPCommitmentType *byte `json:"op_commitment_type,omitempty"`
+	DALayer          *byte `json:"da_layer,omitempty"`
+	// KeccakHash is the hash of the payload of op keccak256 commitments
+	KeccakHash string `json:"keccak_hash,omitempty"`
+	// CertVersion and Cert are the version byte and serialized cert of EigenDA commitments
+	CertVersion     *byte  `json:"cert_version,omitempty"`
+	CertVersionName string `json:"cert_version_name,omitempty"`
+	Cert            string `json:"cert,omitempty"`
+}
+
+// ClientInspect prints the header bytes, cert version and serialized cert of a commitment
+func ClientInspect(cliCtx *cli.Context) error {
+	mode, commitment, err := readCommitment(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	info, err := inspectCommitment(mode, commitment)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(cliCtx.App.Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(info)
+}
+
+func inspectCommitment(mode commitments.CommitmentMode, commitment []byte) (commitmentInfo, error) {
+	info := commitmentInfo{CommitmentMode: mode, Length: len(commitment)}
+	versionedCert := commitment
+	switch mode {
+	case commitments.OptimismKeccakCommitmentMode:
+		if len(commitment) != 33 || commitment[0] != byte(commitments.OPKeccak256CommitmentByte) {
+			return commitmentInfo{}, fmt.Errorf("%s commitments are 0x00 followed by a 32 bytes hash", mode)
+		}
+		info.OPCommitmentType = &commitment[0]
+		info.KeccakHash = "0x" + hex.EncodeToString(commitment[1:])
+		return info, nil
+	case commitments.OptimismGenericCommitmentMode:
+		if len(commitment) < 2 || commitment[0] != byte(commitments.OPGenericCommitmentByte) {
+			return commitmentInfo{}, fmt.Errorf("%s commitments start with 0x01", mode)
+		}
+		info.OPCommitmentType = &commitment[0]
+		info.DALayer = &commitment[1]
+		if commitment[1] != commitments.EigenDALayerByte {
+			return info, nil
+		}
+		versionedCert = commitment[2:]
+	}
+
+	if len(versionedCert) == 0 {
+		return commitmentInfo{}, fmt.Errorf("commitment has no cert")
+	}
+	info.CertVersion = &versionedCert[0]
+	info.Cert = "0x" + hex.EncodeToString(versionedCert[1:])
+	info.CertVersionName = certVersionName(versionedCert[0])
+	return info, nil
+}
+
+func readCommitmentMode(cliCtx *cli.Context) (commitments.CommitmentMode, error) {
+	mode := commitments.CommitmentMode(cliCtx.String(clientCommitmentModeFlag.Name))
+	switch mode {
+	case commitments.StandardCommitmentMode,
+		commitments.OptimismGenericCommitmentMode,
+		commitments.OptimismKeccakCommitmentMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown commitment mode %q", mode)
+	}
+}
+
+func newDataClient(cliCtx *cli.Context, mode commitments.CommitmentMode) dataClient {
+	url := strings.TrimSuffix(cliCtx.String(clientURLFlag.Name), "/")
+	if mode == commitments.StandardCommitmentMode {
+		return standard_client.New(&standard_client.Config{URL: url})
+	}
+	return op_client.New(&op_client.Config{URL: url})
+}
+
+// readInput returns the content of the file passed as argument, or of stdin when there is no argument or it is "-"
+func readInput(cliCtx *cli.Context) ([]byte, error) {
+	if cliCtx.NArg() > 1 {
+		return nil, fmt.Errorf("expected at most one argument, got %d", cliCtx.NArg())
+	}
+	if path := cliCtx.Args().First(); path != "" && path != "-" {
+		return os.ReadFile(path)
+	}
+	return io.ReadAll(os.Stdin)
+}
+
+// readCommitment returns the commitment passed as argument, or read from stdin, decoded with the configured encoding
+func readCommitment(cliCtx *cli.Context) (commitments.CommitmentMode, []byte, error) {
+	mode, err := readCommitmentMode(cliCtx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if cliCtx.NArg() > 1 {
+		return "", nil, fmt.Errorf("expected at most one argument, got %d", cliCtx.NArg())
+	}
+	encoded := cliCtx.Args().First()
+	if encoded == "" || encoded == "-" {
+		stdin, readErr := io.ReadAll(os.Stdin)
+		if readErr != nil {
+			return "", nil, fmt.Errorf("read commitment: %w", readErr)
+		}
+		encoded = string(stdin)
+	}
+	encoded = strings.TrimSpace(encoded)
+
+	var commitment []byte
+	switch encoding := cliCtx.String(clientEncodingFlag.Name); encoding {
+	case hexEncoding:
+		commitment, err = hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	case base64Encoding:
+		commitment, err = base64.StdEncoding.DecodeString(encoded)
+	default:
+		return "", nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("decode commitment: %w", err)
+	}
+	if len(commitment) == 0 {
+		return "", nil, fmt.Errorf("commitment is empty")
+	}
+	return mode, commitment, nil
+}
+
+func encodeCommitment(cliCtx *cli.Context, commitment []byte) (string, error) {
+	switch encoding := cliCtx.String(clientEncodingFlag.Name); encoding {
+	case hexEncoding:
+		return "0x" + hex.EncodeToString(commitment), nil
+	case base64Encoding:
+		return base64.StdEncoding.EncodeToString(commitment), nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q", encoding)
+	}
+}
+
+// describeClientError replaces the errors of the proxy responses with a readable description of their body
+func describeClientError(err error) error {
+	if certErr, ok := httperror.AsCertVerificationFailed(err); ok {
+		return fmt.Errorf("cert verification failed with status code %d: %s (request_id: %s)",
+			certErr.StatusCode, certErr.Msg, certErr.RequestID)
+	}
+	var respErr *httperror.ResponseError
+	if errors.As(err, &respErr) {
+		return fmt.Errorf("proxy responded %d %s: %s",
+			respErr.StatusCode, http.StatusText(respErr.StatusCode), strings.TrimSpace(string(respErr.Body)))
+	}
+	return err
+}
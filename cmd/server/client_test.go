@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/clients/httperror"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// setStdin replaces os.Stdin with a file holding content for the duration of the test
+func setStdin(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	stdin, err := os.Open(path)
+	require.NoError(t, err)
+	original := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() {
+		os.Stdin = original
+		_ = stdin.Close()
+	})
+}
+
+func newClientContext(t *testing.T, mode string, encoding string, args ...string) *cli.Context {
+	set := flag.NewFlagSet(t.Name(), flag.ContinueOnError)
+	set.String(clientCommitmentModeFlag.Name, mode, "")
+	set.String(clientEncodingFlag.Name, encoding, "")
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func TestReadCommitment(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		encoding string
+		args     []string
+		stdin    string
+		want     []byte
+		wantErr  string
+	}{
+		{name: "hex", encoding: hexEncoding, args: []string{"0x0102ab"}, want: []byte{0x01, 0x02, 0xab}},
+		{name: "hex without prefix", encoding: hexEncoding, args: []string{"0102ab"}, want: []byte{0x01, 0x02, 0xab}},
+		{name: "base64", encoding: base64Encoding, args: []string{"AQKr"}, want: []byte{0x01, 0x02, 0xab}},
+		{name: "stdin", encoding: hexEncoding, stdin: "0x0102ab\n", want: []byte{0x01, 0x02, 0xab}},
+		{
+			name: "dash reads stdin", encoding: base64Encoding, args: []string{"-"}, stdin: "AQKr\n",
+			want: []byte{0x01, 0x02, 0xab},
+		},
+		{name: "invalid hex", encoding: hexEncoding, args: []string{"0xzz"}, wantErr: "decode commitment"},
+		{name: "empty", encoding: hexEncoding, stdin: "\n", wantErr: "commitment is empty"},
+		{name: "unknown encoding", encoding: "base58", args: []string{"0x01"}, wantErr: `unknown encoding "base58"`},
+		{
+			name: "unknown mode", mode: "unknown", encoding: hexEncoding, args: []string{"0x01"},
+			wantErr: "unknown commitment mode",
+		},
+		{name: "too many args", encoding: hexEncoding, args: []string{"0x01", "0x02"}, wantErr: "at most one argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setStdin(t, tt.stdin)
+			mode := tt.mode
+			if mode == "" {
+				mode = string(commitments.StandardCommitmentMode)
+			}
+
+			gotMode, commitment, err := readCommitment(newClientContext(t, mode, tt.encoding, tt.args...))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, commitments.CommitmentMode(mode), gotMode)
+			require.Equal(t, tt.want, commitment)
+		})
+	}
+}
+
+func TestEncodeCommitment(t *testing.T) {
+	commitment := []byte{0x01, 0x02, 0xab}
+	mode := string(commitments.StandardCommitmentMode)
+
+	encoded, err := encodeCommitment(newClientContext(t, mode, hexEncoding), commitment)
+	require.NoError(t, err)
+	require.Equal(t, "0x0102ab", encoded)
+
+	encoded, err = encodeCommitment(newClientContext(t, mode, base64Encoding), commitment)
+	require.NoError(t, err)
+	require.Equal(t, "AQKr", encoded)
+
+	_, err = encodeCommitment(newClientContext(t, mode, "base58"), commitment)
+	require.ErrorContains(t, err, `unknown encoding "base58"`)
+}
+
+func bytePtr(b byte) *byte {
+	return &b
+}
+
+func TestInspectCommitment(t *testing.T) {
+	keccakHash := bytes.Repeat([]byte{0xaa}, 32)
+	tests := []struct {
+		name       string
+		mode       commitments.CommitmentMode
+		commitment []byte
+		want       commitmentInfo
+		wantErr    string
+	}{
+		{
+			name:       "standard",
+			mode:       commitments.StandardCommitmentMode,
+			commitment: []byte{0x02, 0xc0, 0xff},
+			want: commitmentInfo{
+				CommitmentMode:  commitments.StandardCommitmentMode,
+				Length:          3,
+				CertVersion:     bytePtr(0x02),
+				CertVersionName: "EigenDACertV3",
+				Cert:            "0xc0ff",
+			},
+		},
+		{
+			name:       "op generic",
+			mode:       commitments.OptimismGenericCommitmentMode,
+			commitment: []byte{0x01, 0x00, 0x00, 0xc0},
+			want: commitmentInfo{
+				CommitmentMode:   commitments.OptimismGenericCommitmentMode,
+				Length:           4,
+				OPCommitmentType: bytePtr(0x01),
+				DALayer:          bytePtr(0x00),
+				CertVersion:      bytePtr(0x00),
+				CertVersionName:  "EigenDA V1 cert",
+				Cert:             "0xc0",
+			},
+		},
+		{
+			name:       "op generic of another da layer",
+			mode:       commitments.OptimismGenericCommitmentMode,
+			commitment: []byte{0x01, 0x07, 0xc0},
+			want: commitmentInfo{
+				CommitmentMode:   commitments.OptimismGenericCommitmentMode,
+				Length:           3,
+				OPCommitmentType: bytePtr(0x01),
+				DALayer:          bytePtr(0x07),
+			},
+		},
+		{
+			name:       "op keccak",
+			mode:       commitments.OptimismKeccakCommitmentMode,
+			commitment: append([]byte{0x00}, keccakHash...),
+			want: commitmentInfo{
+				CommitmentMode:   commitments.OptimismKeccakCommitmentMode,
+				Length:           33,
+				OPCommitmentType: bytePtr(0x00),
+				KeccakHash:       "0x" + string(bytes.Repeat([]byte("aa"), 32)),
+			},
+		},
+		{
+			name:       "unknown cert version",
+			mode:       commitments.StandardCommitmentMode,
+			commitment: []byte{0x09},
+			want: commitmentInfo{
+				CommitmentMode:  commitments.StandardCommitmentMode,
+				Length:          1,
+				CertVersion:     bytePtr(0x09),
+				CertVersionName: "unknown",
+				Cert:            "0x",
+			},
+		},
+		{
+			name:       "op keccak without hash",
+			mode:       commitments.OptimismKeccakCommitmentMode,
+			commitment: []byte{0x00},
+			wantErr:    "followed by a 32 bytes hash",
+		},
+		{
+			name:       "op generic with wrong prefix",
+			mode:       commitments.OptimismGenericCommitmentMode,
+			commitment: []byte{0x00, 0x00, 0x02},
+			wantErr:    "start with 0x01",
+		},
+		{
+			name:       "op generic without cert",
+			mode:       commitments.OptimismGenericCommitmentMode,
+			commitment: []byte{0x01, 0x00},
+			wantErr:    "commitment has no cert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := inspectCommitment(tt.mode, tt.commitment)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, info)
+		})
+	}
+}
+
+func TestDescribeClientError(t *testing.T) {
+	certErr := httperror.NewResponseError("get failed", http.StatusTeapot,
+		[]byte(`{"StatusCode":3,"Msg":"invalid inclusion proof","RequestID":"req-1"}`))
+	require.EqualError(t, describeClientError(certErr),
+		"cert verification failed with status code 3: invalid inclusion proof (request_id: req-1)")
+
+	respErr := httperror.NewResponseError("get failed", http.StatusInternalServerError, []byte("internal error\n"))
+	require.EqualError(t, describeClientError(respErr), "proxy responded 500 Internal Server Error: internal error")
+
+	// 418 responses whose body isn't the expected JSON are described like other responses
+	respErr = httperror.NewResponseError("get failed", http.StatusTeapot, []byte("not json"))
+	require.EqualError(t, describeClientError(respErr), "proxy responded 418 I'm a teapot: not json")
+
+	err := errors.New("connection refused")
+	require.Equal(t, err, describeClientError(err))
+}
+
+// newTestProxy returns the URL of a fake proxy serving standard commitments, which:
+//   - returns the 0x02ab commitment for posted payloads, or a 503 for the "unavailable" payload
+//   - returns the "payload" payload for the 0x02ab commitment
+//   - returns a 418 for the 0x02cd commitment
+func newTestProxy(t *testing.T) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /put", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if string(body) == "unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte{0x02, 0xab})
+	})
+	mux.HandleFunc("GET /get/0x02ab", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("payload"))
+	})
+	mux.HandleFunc("GET /get/0x02cd", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte(`{"StatusCode":3,"Msg":"invalid inclusion proof","RequestID":"req-1"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// runClient runs the client subcommand args against the proxy of url, and returns its output
+func runClient(t *testing.T, url string, args ...string) (string, error) {
+	var out bytes.Buffer
+	app := &cli.App{
+		Commands: []*cli.Command{{Name: "client", Subcommands: clientSubcommands}},
+		Writer:   &out,
+	}
+	command := append([]string{"eigenda-proxy", "client", args[0], "--" + clientURLFlag.Name, url}, args[1:]...)
+	err := app.Run(command)
+	return out.String(), err
+}
+
+func TestClientCommands(t *testing.T) {
+	url := newTestProxy(t)
+
+	t.Run("put from stdin", func(t *testing.T) {
+		setStdin(t, "payload")
+		out, err := runClient(t, url, "put", "-")
+		require.NoError(t, err)
+		require.Equal(t, "0x02ab\n", out)
+	})
+
+	t.Run("put unavailable", func(t *testing.T) {
+		setStdin(t, "unavailable")
+		_, err := runClient(t, url, "put")
+		require.ErrorIs(t, err, httperror.ErrServiceUnavailable)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		out, err := runClient(t, url, "get", "0x02ab")
+		require.NoError(t, err)
+		require.Equal(t, "payload", out)
+	})
+
+	t.Run("verify from stdin", func(t *testing.T) {
+		setStdin(t, "Aqs=\n")
+		out, err := runClient(t, url, "verify", "--"+clientEncodingFlag.Name, base64Encoding, "-")
+		require.NoError(t, err)
+		require.Equal(t, "cert is valid, payload is 7 bytes\n", out)
+	})
+
+	t.Run("verify failed", func(t *testing.T) {
+		_, err := runClient(t, url, "verify", "0x02cd")
+		require.EqualError(t, err,
+			"cert verification failed with status code 3: invalid inclusion proof (request_id: req-1)")
+	})
+}
@@ -3,16 +3,25 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
 
 	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/config/configfile"
+	"github.com/Layr-Labs/eigenda-proxy/config/reload"
 	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	proxy_metrics "github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/server"
 	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2/payments"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/gorilla/mux"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
 )
 
@@ -21,6 +30,10 @@ func StartProxySvr(cliCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	// the log level can be changed by config reloads
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(logCfg.HandlerOpts.Level.Level())
+	logCfg.HandlerOpts.Level = logLevel
 
 	log, err := proxy_logging.NewLogger(*logCfg)
 	if err != nil {
@@ -29,7 +42,7 @@ func StartProxySvr(cliCtx *cli.Context) error {
 
 	log.Info("Starting EigenDA Proxy Server", "version", Version, "date", Date, "commit", Commit)
 
-	cfg, err := config.ReadAppConfig(cliCtx)
+	cfg, err := config.LoadAppConfig(cliCtx)
 	if err != nil {
 		return fmt.Errorf("read cli config: %w", err)
 	}
@@ -43,6 +56,19 @@ func StartProxySvr(cliCtx *cli.Context) error {
 	}
 
 	log.Infof("Initializing EigenDA proxy server with config (\"*****\" fields are hidden): %v", configString)
+	if cfg.ServerConfig.Tenants.Enabled() {
+		log.Info("Multi-tenancy enabled", "tenants", len(cfg.ServerConfig.Tenants.Tenants()))
+	}
+
+	shutdownTracing, err := tracing.Init(cliCtx.Context, log, cfg.TracingConfig, Version)
+	if err != nil {
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error("failed to shutdown tracing", "err", err)
+		}
+	}()
 
 	metrics := proxy_metrics.NewMetrics("default")
 
@@ -60,11 +86,27 @@ func StartProxySvr(cliCtx *cli.Context) error {
 		return fmt.Errorf("build storage manager: %w", err)
 	}
 
+	cfg.ServerConfig.BuildInfo = server.BuildInfo{Version: Version, Commit: Commit, Date: Date}
 	proxyServer := server.NewServer(cfg.ServerConfig, storeManager, log, metrics)
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)
 	if cfg.StoreBuilderConfig.MemstoreEnabled {
-		memconfig.NewHandlerHTTP(log, cfg.StoreBuilderConfig.MemstoreConfig).RegisterMemstoreConfigHandlers(router)
+		proxyServer.RegisterExternalRoutes(router,
+			memconfig.NewHandlerHTTP(log, cfg.StoreBuilderConfig.MemstoreConfig).RegisterMemstoreConfigHandlers)
+		proxyServer.RegisterExternalRoutes(router,
+			expiry.NewHandlerHTTP(log, cfg.StoreBuilderConfig.MemstoreExpiryNotifier).RegisterExpiryEventHandlers)
+	}
+	if cfg.ServerConfig.IsAPIEnabled(server.AdminAPIType) {
+		proxyServer.RegisterExternalRoutes(router,
+			payments.NewHandlerHTTP(log, cfg.StoreBuilderConfig.PaymentsTracker).RegisterPaymentsHandlers)
+	}
+
+	reloader := reload.NewReloader(log, cfg, func() (config.AppConfig, error) {
+		return rereadAppConfig(ctx)
+	}, logLevel, proxyServer)
+	go reloader.ReloadOnSIGHUP(ctx)
+	if cfg.ServerConfig.IsAPIEnabled(server.AdminAPIType) {
+		proxyServer.RegisterExternalRoutes(router, reload.NewHandlerHTTP(log, reloader).RegisterConfigHandlers)
 	}
 
 	if err := proxyServer.Start(router); err != nil {
@@ -98,3 +140,25 @@ func StartProxySvr(cliCtx *cli.Context) error {
 
 	return ctxinterrupt.Wait(cliCtx.Context)
 }
+
+// rereadAppConfig reads the config again from the command-line arguments, env vars and config file,
+// the same way as the proxy was started. Changes to the config file are picked up.
+func rereadAppConfig(ctx context.Context) (config.AppConfig, error) {
+	var cfg config.AppConfig
+	app := cli.NewApp()
+	app.Flags = cliapp.ProtectFlags(config.Flags)
+	app.HideHelp = true
+	app.Writer = io.Discard
+	app.Before = func(cliCtx *cli.Context) error {
+		return configfile.Apply(cliCtx, app.Flags)
+	}
+	app.Action = func(cliCtx *cli.Context) error {
+		var err error
+		cfg, err = config.ReadAppConfig(cliCtx)
+		return err
+	}
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		return config.AppConfig{}, err
+	}
+	return cfg, nil
+}
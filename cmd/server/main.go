@@ -8,6 +8,9 @@ import (
 	"os"
 
 	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/config/configfile"
+	"github.com/Layr-Labs/eigenda-proxy/store/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/migrate"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/joho/godotenv"
 	"github.com/urfave/cli/v2"
@@ -35,11 +38,81 @@ func main() {
 	app.Usage = "EigenDA Proxy Sidecar Service"
 	app.Description = "Service for more trustless and secure interactions with EigenDA"
 	app.Action = StartProxySvr
+	// the config file is applied before any command runs, flags and env vars taking precedence over it
+	app.Before = func(ctx *cli.Context) error {
+		return configfile.Apply(ctx, app.Flags)
+	}
+	validateFlags := cliapp.ProtectFlags(config.Flags)
+	backfillFlags := append(cliapp.ProtectFlags(config.Flags),
+		backfill.CLIFlags(config.GlobalEnvVarPrefix, config.BackfillCategory)...)
+	migrateFlags := append(cliapp.ProtectFlags(config.Flags),
+		migrate.CLIFlags(config.GlobalEnvVarPrefix, config.MigrateCategory)...)
 	app.Commands = []*cli.Command{
 		{
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		{
+			Name:  "config",
+			Usage: "Configuration utilities",
+			Subcommands: []*cli.Command{
+				{
+					Name: "validate",
+					Usage: "Check the config passed with flags, env vars and --config file, " +
+						"without connecting to any external service",
+					Flags: validateFlags,
+					Before: func(ctx *cli.Context) error {
+						return configfile.Apply(ctx, validateFlags)
+					},
+					Action: ValidateConfig,
+				},
+			},
+		},
+		{
+			Name:        "client",
+			Usage:       "Put, get, verify and inspect commitments against a running proxy",
+			Subcommands: clientSubcommands,
+		},
+		{
+			Name: "backfill",
+			Usage: "Fetch and verify the payloads of existing commitments, and write them to secondary storage " +
+				"targets",
+			Flags: backfillFlags,
+			Before: func(ctx *cli.Context) error {
+				return configfile.Apply(ctx, backfillFlags)
+			},
+			Action: Backfill,
+		},
+		{
+			Name:  "secondary",
+			Usage: "Copy entries between secondary storage backends, and check their consistency",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "copy",
+					Usage: "Copy all the entries of the source backend to the target backend",
+					Flags: migrateFlags,
+					Before: func(ctx *cli.Context) error {
+						return configfile.Apply(ctx, migrateFlags)
+					},
+					Action: SecondaryCopy,
+				},
+				{
+					Name: "check",
+					Usage: "Report the entries missing from or corrupted in the source and target backends, " +
+						"checking all of them or a sample",
+					Flags: migrateFlags,
+					Before: func(ctx *cli.Context) error {
+						return configfile.Apply(ctx, migrateFlags)
+					},
+					Action: SecondaryCheck,
+				},
+			},
+		},
+		{
+			Name:        "cert",
+			Usage:       "Decode and verify the certs of commitments, without a running proxy",
+			Subcommands: certSubcommands,
+		},
 	}
 
 	// load env file (if applicable)
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
+	"github.com/Layr-Labs/eigenda-proxy/store/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/migrate"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	kzgverifier "github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// SecondaryCopy copies all the entries of the source secondary storage backend to the target, both configured the
+// same way as the proxy. A JSON summary report is written once done.
+func SecondaryCopy(cliCtx *cli.Context) error {
+	log, cfg, migrateCfg, err := readMigrateConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	source, target, err := buildMigrateBackends(cliCtx.Context, log, cfg, migrateCfg)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting copy", "source", migrateCfg.Source, "target", migrateCfg.Target,
+		"concurrency", migrateCfg.Concurrency)
+	report, runErr := migrate.NewCopier(log, source, target, migrateCfg).Run(cliCtx.Context)
+	if err = writeReport(cliCtx, migrateCfg.ReportFile, report); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d entries failed to be copied, see the report", report.Failed)
+	}
+	return nil
+}
+
+// SecondaryCheck checks that the entries of two secondary storage backends are consistent. When a commitments file
+// is given, the payloads of its commitments are verified against their certs in both backends. Otherwise, the keys
+// of both backends are listed and their entries compared. A JSON summary report is written once done.
+func SecondaryCheck(cliCtx *cli.Context) error {
+	log, cfg, migrateCfg, err := readMigrateConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	source, target, err := buildMigrateBackends(cliCtx.Context, log, cfg, migrateCfg)
+	if err != nil {
+		return err
+	}
+	checker := migrate.NewChecker(log, source, target, migrateCfg)
+
+	var report *migrate.CheckReport
+	var runErr error
+	if migrateCfg.CommitmentsFile == "" {
+		log.Info("Checking the keys of both backends", "source", migrateCfg.Source, "target", migrateCfg.Target,
+			"sample_rate", migrateCfg.SampleRate)
+		report, runErr = checker.CheckKeys(cliCtx.Context)
+	} else {
+		ctx := cliCtx.Context
+		if migrateCfg.Tenant != "" {
+			t, tenantErr := findTenant(cfg.ServerConfig.Tenants, migrateCfg.Tenant)
+			if tenantErr != nil {
+				return tenantErr
+			}
+			ctx = tenant.ContextWithTenant(ctx, t)
+		}
+		verifier, verifierErr := newKZGPayloadVerifier(log, cfg.StoreBuilderConfig)
+		if verifierErr != nil {
+			return verifierErr
+		}
+		log.Info("Checking the entries of commitments", "source", migrateCfg.Source, "target", migrateCfg.Target,
+			"commitments", migrateCfg.CommitmentsFile, "sample_rate", migrateCfg.SampleRate)
+		commitments := backfill.NewFileSource(migrateCfg.CommitmentsFile, migrateCfg.CommitmentMode)
+		report, runErr = checker.CheckCommitments(ctx, commitments, verifier)
+	}
+	if err = writeReport(cliCtx, migrateCfg.ReportFile, report); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if inconsistencies := report.Inconsistencies(); inconsistencies > 0 {
+		return fmt.Errorf("%d inconsistencies found, see the report", inconsistencies)
+	}
+	return nil
+}
+
+func readMigrateConfig(cliCtx *cli.Context) (logging.Logger, config.AppConfig, migrate.Config, error) {
+	logCfg, err := proxy_logging.ReadLoggerCLIConfig(cliCtx)
+	if err != nil {
+		return nil, config.AppConfig{}, migrate.Config{}, err
+	}
+	log, err := proxy_logging.NewLogger(*logCfg)
+	if err != nil {
+		return nil, config.AppConfig{}, migrate.Config{}, err
+	}
+
+	cfg, err := config.LoadAppConfig(cliCtx)
+	if err != nil {
+		return nil, config.AppConfig{}, migrate.Config{}, fmt.Errorf("read cli config: %w", err)
+	}
+	if err = cfg.Check(); err != nil {
+		return nil, config.AppConfig{}, migrate.Config{}, fmt.Errorf("check config: %w", err)
+	}
+	migrateCfg := migrate.ReadConfig(cliCtx)
+	if err = migrateCfg.Check(); err != nil {
+		return nil, config.AppConfig{}, migrate.Config{}, fmt.Errorf("check migrate config: %w", err)
+	}
+	return log, cfg, migrateCfg, nil
+}
+
+func buildMigrateBackends(
+	ctx context.Context, log logging.Logger, cfg config.AppConfig, migrateCfg migrate.Config,
+) (common.ListableSecondaryStore, common.ListableSecondaryStore, error) {
+	backends, err := builder.BuildListableSecondaries(
+		ctx, log, cfg.StoreBuilderConfig, []string{migrateCfg.Source, migrateCfg.Target})
+	if err != nil {
+		return nil, nil, fmt.Errorf("build secondary storage backends: %w", err)
+	}
+	return backends[0], backends[1], nil
+}
+
+// kzgPayloadVerifier verifies payloads against the KZG commitments of their certs, with the polynomial forms the
+// proxy is configured with
+type kzgPayloadVerifier struct {
+	kzgVerifier *kzgverifier.Verifier
+	v1Verifier  *verify.Verifier
+	// v1DisablePointEvaluation and v2PolynomialForm are the encodings payloads were dispersed with
+	v1DisablePointEvaluation bool
+	v2PolynomialForm         codecs.PolynomialForm
+}
+
+var _ migrate.PayloadVerifier = (*kzgPayloadVerifier)(nil)
+
+func newKZGPayloadVerifier(log logging.Logger, cfg builder.Config) (*kzgPayloadVerifier, error) {
+	kzgConfig := cfg.KzgConfig
+	// the verifier doesn't need g2 points, see BuildStoreManager
+	kzgConfig.LoadG2Points = false
+	kzgVerifier, err := kzgverifier.NewVerifier(&kzgConfig, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new kzg verifier: %w", err)
+	}
+	// certs aren't verified onchain, only the commitments to their payloads are checked
+	v1Verifier, err := verify.NewVerifier(&verify.Config{}, kzgVerifier, log)
+	if err != nil {
+		return nil, fmt.Errorf("new verifier: %w", err)
+	}
+	return &kzgPayloadVerifier{
+		kzgVerifier:              kzgVerifier,
+		v1Verifier:               v1Verifier,
+		v1DisablePointEvaluation: cfg.ClientConfigV1.EdaClientCfg.DisablePointVerificationMode,
+		v2PolynomialForm:         cfg.ClientConfigV2.PayloadDisperserCfg.PayloadClientConfig.PayloadPolynomialForm,
+	}, nil
+}
+
+func (v *kzgPayloadVerifier) VerifyPayload(versionedCert certs.VersionedCert, payload []byte) error {
+	cert, err := decodeCert(versionedCert)
+	if err != nil {
+		return err
+	}
+	switch cert := cert.(type) {
+	case *verify.Certificate:
+		if err = cert.NoNilFields(); err != nil {
+			return fmt.Errorf("check cert: %w", err)
+		}
+		encodedBlob, encodeErr := encodePayloadV1(payload, v.v1DisablePointEvaluation)
+		if encodeErr != nil {
+			return encodeErr
+		}
+		if err = v.v1Verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob); err != nil {
+			return fmt.Errorf("payload doesn't match the KZG commitment of the cert: %w", err)
+		}
+		return nil
+	case coretypes.EigenDACert:
+		blob, blobErr := payloadToBlobV2(payload, v.v2PolynomialForm)
+		if blobErr != nil {
+			return blobErr
+		}
+		return checkBlobCommitmentV2(v.kzgVerifier, cert, blob)
+	default:
+		return fmt.Errorf("unsupported cert type %T", cert)
+	}
+}
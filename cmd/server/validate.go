@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
+	"github.com/Layr-Labs/eigenda-proxy/server/servertls"
+	"github.com/urfave/cli/v2"
+)
+
+// ValidateConfig reads the config from the flags, env vars and config file, and checks it the same way as
+// [StartProxySvr] does, without creating any network client. Local files referenced by the config
+// (tenants, TLS certificates) are loaded, so that they are validated as well.
+func ValidateConfig(cliCtx *cli.Context) error {
+	logCfg, err := proxy_logging.ReadLoggerCLIConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	log, err := proxy_logging.NewLogger(*logCfg)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadAppConfig(cliCtx)
+	if err != nil {
+		return fmt.Errorf("read cli config: %w", err)
+	}
+	if err := cfg.Check(); err != nil {
+		return fmt.Errorf("check config: %w", err)
+	}
+	if cfg.ServerConfig.TLS.Enabled() {
+		if _, err := servertls.NewTLSConfig(cfg.ServerConfig.TLS, log); err != nil {
+			return fmt.Errorf("check TLS config: %w", err)
+		}
+	}
+
+	configString, err := cfg.StoreBuilderConfig.ToString()
+	if err != nil {
+		return fmt.Errorf("convert config json to string: %w", err)
+	}
+	log.Infof("Config is valid (\"*****\" fields are hidden): %v", configString)
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runValidateConfig(args ...string) error {
+	app := &cli.App{
+		Flags:  cliapp.ProtectFlags(config.Flags),
+		Action: ValidateConfig,
+	}
+	return app.Run(append([]string{"eigenda-proxy"}, args...))
+}
+
+func TestValidateConfig_UnconfiguredCacheTarget(t *testing.T) {
+	args := []string{
+		"--" + memstore.EnabledFlagName,
+		"--" + verify.CertVerificationDisabledFlagName,
+		"--" + store.CacheTargetsFlagName, "redis",
+	}
+	require.ErrorContains(t, runValidateConfig(args...), "cache target redis is not a configured")
+
+	require.NoError(t, runValidateConfig(append(args, "--"+redis.EndpointFlagName, "localhost:6379")...))
+}
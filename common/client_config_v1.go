@@ -11,8 +11,11 @@ import (
 // though, and v1 is slated for deprecation, this wrapper is just a stopgap to better organize configs in the proxy
 // repo in the short term.
 type ClientConfigV1 struct {
-	EdaClientCfg     clients.EigenDAClientConfig
-	MaxBlobSizeBytes uint64
+	EdaClientCfg clients.EigenDAClientConfig
+	// Additional ETH RPC urls used for cert verification, next to EdaClientCfg.EthRpcUrl.
+	// The eigenda-client itself only uses EdaClientCfg.EthRpcUrl.
+	EthRPCFallbackURLs []string
+	MaxBlobSizeBytes   uint64
 	// Number of times to try blob dispersals:
 	// - If > 0: Try N times total
 	// - If < 0: Retry indefinitely until success
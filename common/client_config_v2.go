@@ -2,7 +2,12 @@ package common
 
 import (
 	"fmt"
+	"net"
 	"slices"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
 
 	clients_v2 "github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
@@ -38,6 +43,10 @@ type ClientConfigV2 struct {
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 
+	// RelayRegistryAddr is optional, as the relay registry is always read from the EigenDAServiceManager.
+	// When set, the two must match.
+	RelayRegistryAddr string
+
 	// Allowed distance (in L1 blocks) between the eigenDA cert's reference block number (RBN)
 	// and the L1 block number at which the cert was included in the rollup's batch inbox.
 	// A cert is valid if cert.L1InclusionBlock <= cert.RBN + RBNRecencyWindowSize, otherwise it
@@ -47,13 +56,89 @@ type ClientConfigV2 struct {
 	// This check is optional and will be skipped when RBNRecencyWindowSize is set to 0.
 	RBNRecencyWindowSize uint64
 
+	// Maximum number of cert verification results cached by the V2 store. 0 disables the cache.
+	CertVerificationCacheSize int
+	// Duration for which a cached cert verification result is valid. 0 means results never expire.
+	CertVerificationCacheTTL time.Duration
+
+	// Failover and quorum read settings for the ETH RPC endpoints. The endpoints themselves are part of SecretConfigV2.
+	EthRPC ethrpc.Config
+
+	// Signer of dispersal payments. The raw hex private key of the local signer is part of SecretConfigV2.
+	Signer signer.Config
+
 	// The EigenDA network that is being used.
-	// It is optional, and when set will be used for validating that the eth-rpc chain ID matches the network.
+	// It is optional, and when set will be used for validating that the eth-rpc chain ID matches the network, and
+	// for deriving the addresses and endpoints which are not explicitly configured from the network preset.
 	EigenDANetwork EigenDANetwork
+
+	// Sources records whether each of the values returned by NetworkValues was explicitly configured or derived from
+	// the EigenDANetwork preset, keyed by name (e.g. ServiceManagerAddressName). Unset values have no entry.
+	Sources map[string]ConfigSource
+}
+
+// ConfigSource is where a config value comes from
+type ConfigSource string
+
+const (
+	// ConfigSourceUnset is the source of values which are not configured
+	ConfigSourceUnset ConfigSource = "unset"
+	// ConfigSourceExplicit is the source of values configured with a flag, env var or config file
+	ConfigSourceExplicit ConfigSource = "explicit"
+	// ConfigSourceNetworkPreset is the source of values derived from the EigenDANetwork preset
+	ConfigSourceNetworkPreset ConfigSource = "network preset"
+)
+
+// Names of the ClientConfigV2 values which can be derived from the EigenDANetwork preset
+const (
+	DisperserAddressName                 = "disperser address"
+	CertVerifierRouterAddressName        = "cert verifier router address"
+	ServiceManagerAddressName            = "service manager address"
+	BLSOperatorStateRetrieverAddressName = "BLS operator state retriever address"
+	RelayRegistryAddressName             = "relay registry address"
+)
+
+// NetworkValue is a value of ClientConfigV2 which can be derived from the EigenDANetwork preset
+type NetworkValue struct {
+	Name   string
+	Value  string
+	Source ConfigSource
+}
+
+// NetworkValues returns the values which can be derived from the EigenDANetwork preset, along with their source
+func (cfg *ClientConfigV2) NetworkValues() []NetworkValue {
+	var disperserAddress string
+	if cfg.DisperserClientCfg.Hostname != "" {
+		disperserAddress = net.JoinHostPort(cfg.DisperserClientCfg.Hostname, cfg.DisperserClientCfg.Port)
+	}
+
+	values := []NetworkValue{
+		{Name: DisperserAddressName, Value: disperserAddress},
+		{Name: CertVerifierRouterAddressName, Value: cfg.EigenDACertVerifierOrRouterAddress},
+		{Name: ServiceManagerAddressName, Value: cfg.EigenDAServiceManagerAddr},
+		{Name: BLSOperatorStateRetrieverAddressName, Value: cfg.BLSOperatorStateRetrieverAddr},
+		{Name: RelayRegistryAddressName, Value: cfg.RelayRegistryAddr},
+	}
+	for i := range values {
+		source, ok := cfg.Sources[values[i].Name]
+		switch {
+		case values[i].Value == "":
+			values[i].Source = ConfigSourceUnset
+		case ok:
+			values[i].Source = source
+		default:
+			values[i].Source = ConfigSourceExplicit
+		}
+	}
+	return values
 }
 
 // Check checks config invariants, and returns an error if there is a problem with the config struct
 func (cfg *ClientConfigV2) Check() error {
+	if cfg.DisperserClientCfg.Hostname == "" && cfg.DisperserClientCfg.Port == "" {
+		return cfg.missingNetworkValue("EigenDA " + DisperserAddressName)
+	}
+
 	if cfg.DisperserClientCfg.Hostname == "" {
 		return fmt.Errorf("EigenDA disperser hostname is required for using EigenDA V2 backend")
 	}
@@ -63,8 +148,12 @@ func (cfg *ClientConfigV2) Check() error {
 	}
 
 	if cfg.EigenDACertVerifierOrRouterAddress == "" {
-		return fmt.Errorf(`immutable v3 cert verifier address or dynamic router 
-		address is required for using EigenDA V2 backend`)
+		return cfg.missingNetworkValue("immutable v3 cert verifier address or dynamic " + CertVerifierRouterAddressName)
+	}
+
+	// the service manager is needed by all retrievers, as the relay registry is read from it
+	if cfg.EigenDAServiceManagerAddr == "" {
+		return cfg.missingNetworkValue("EigenDA " + ServiceManagerAddressName)
 	}
 
 	if cfg.MaxBlobSizeBytes == 0 {
@@ -78,14 +167,12 @@ func (cfg *ClientConfigV2) Check() error {
 
 	if slices.Contains(cfg.RetrieversToEnable, ValidatorRetrieverType) {
 		if cfg.BLSOperatorStateRetrieverAddr == "" {
-			return fmt.Errorf(
-				"BLS operator state retriever address is required for validator retrieval in EigenDA V2 backend")
+			return cfg.missingNetworkValue(BLSOperatorStateRetrieverAddressName)
 		}
+	}
 
-		if cfg.EigenDAServiceManagerAddr == "" {
-			return fmt.Errorf(
-				"EigenDA service manager address is required for validator retrieval in EigenDA V2 backend")
-		}
+	if cfg.CertVerificationCacheSize < 0 {
+		return fmt.Errorf("cert verification cache size must be >= 0, got %d", cfg.CertVerificationCacheSize)
 	}
 
 	if cfg.PutTries == 0 {
@@ -95,6 +182,17 @@ func (cfg *ClientConfigV2) Check() error {
 	return nil
 }
 
+// missingNetworkValue returns the error for a required value which is neither explicitly configured nor derived from
+// the EigenDANetwork preset
+func (cfg *ClientConfigV2) missingNetworkValue(name string) error {
+	if cfg.EigenDANetwork == "" {
+		return fmt.Errorf("%s is required for using EigenDA V2 backend: configure it explicitly, "+
+			"or configure an EigenDA network to derive it from the network preset", name)
+	}
+	return fmt.Errorf("%s is required for using EigenDA V2 backend, and network %s has no preset for it: "+
+		"configure it explicitly", name, cfg.EigenDANetwork)
+}
+
 // RetrieverType defines the type of payload retriever
 type RetrieverType string
 
@@ -1,71 +1,163 @@
 package common
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
 
 type EigenDANetwork string
 
 const (
+	MainnetEigenDANetwork        EigenDANetwork = "mainnet"
 	HoleskyTestnetEigenDANetwork EigenDANetwork = "holesky_testnet"
 	HoleskyPreprodEigenDANetwork EigenDANetwork = "holesky_preprod"
 	SepoliaTestnetEigenDANetwork EigenDANetwork = "sepolia_testnet"
 )
 
+// EigenDANetworkPreset holds the chain and the addresses of the EigenDA contracts and services of a network,
+// which are used as default values when the network is configured.
+// Addresses which are empty have no default value, and must be configured explicitly.
+type EigenDANetworkPreset struct {
+	Name EigenDANetwork `json:"name"`
+	// ChainID is the decimal id of the chain the EigenDA contracts are deployed to
+	ChainID                          string `json:"chain_id"`
+	ServiceManagerAddress            string `json:"service_manager_address"`
+	BLSOperatorStateRetrieverAddress string `json:"bls_operator_state_retriever_address"`
+	CertVerifierRouterAddress        string `json:"cert_verifier_router_address"`
+	// RelayRegistryAddress is optional, as the relay registry is always read from the service manager. When set, it
+	// is cross-checked against the relay registry of the service manager on startup.
+	RelayRegistryAddress string `json:"relay_registry_address"`
+	// DisperserAddress has the format "<hostname>:<port>"
+	DisperserAddress string `json:"disperser_address"`
+}
+
+// builtinEigenDANetworkPresets are the public EigenDA networks
+var builtinEigenDANetworkPresets = []EigenDANetworkPreset{
+	{
+		Name:                             MainnetEigenDANetwork,
+		ChainID:                          "1",
+		ServiceManagerAddress:            "0x870679E138bCdf293b7Ff14dD44b70FC97e12fc0",
+		BLSOperatorStateRetrieverAddress: "0xEC35aa6521d23479318104E10B4aA216DBBE63Ce",
+		CertVerifierRouterAddress:        "0x61692e93b6B045c444e942A91EcD1527F23A3FB7",
+		DisperserAddress:                 "disperser.eigenda.xyz:443",
+	},
+	{
+		Name:                             HoleskyTestnetEigenDANetwork,
+		ChainID:                          "17000",
+		ServiceManagerAddress:            "0xD4A7E1Bd8015057293f0D0A557088c286942e84b",
+		BLSOperatorStateRetrieverAddress: "0x003497Dd77E5B73C40e8aCbB562C8bb0410320E7",
+		CertVerifierRouterAddress:        "0xd305aeBcdEc21D00fDF8796CE37d0e74836a6B6e",
+		DisperserAddress:                 "disperser-testnet-holesky.eigenda.xyz:443",
+	},
+	{
+		Name:                             HoleskyPreprodEigenDANetwork,
+		ChainID:                          "17000",
+		ServiceManagerAddress:            "0x54A03db2784E3D0aCC08344D05385d0b62d4F432",
+		BLSOperatorStateRetrieverAddress: "0x003497Dd77E5B73C40e8aCbB562C8bb0410320E7",
+		CertVerifierRouterAddress:        "0xCCFE3d87fB7D369f1eeE65221a29A83f1323043C",
+		DisperserAddress:                 "disperser-preprod-holesky.eigenda.xyz:443",
+	},
+	{
+		Name:                             SepoliaTestnetEigenDANetwork,
+		ChainID:                          "11155111",
+		ServiceManagerAddress:            "0x3a5acf46ba6890B8536420F4900AC9BC45Df4764",
+		BLSOperatorStateRetrieverAddress: "0x22478d082E9edaDc2baE8443E4aC9473F6E047Ff",
+		CertVerifierRouterAddress:        "0x58D2B844a894f00b7E6F9F492b9F43aD54Cd4429",
+		DisperserAddress:                 "disperser-testnet-sepolia.eigenda.xyz:443",
+	},
+}
+
+var (
+	eigenDANetworkPresetsLock sync.RWMutex
+	// eigenDANetworkPresets are the built-in presets, followed by the custom ones loaded with LoadEigenDANetworks
+	eigenDANetworkPresets = slices.Clone(builtinEigenDANetworkPresets)
+)
+
+// Preset returns the preset of the network
+func (n EigenDANetwork) Preset() (EigenDANetworkPreset, error) {
+	eigenDANetworkPresetsLock.RLock()
+	defer eigenDANetworkPresetsLock.RUnlock()
+	for _, preset := range eigenDANetworkPresets {
+		if preset.Name == n {
+			return preset, nil
+		}
+	}
+	return EigenDANetworkPreset{}, fmt.Errorf("unknown network type: %s", n)
+}
+
 // GetServiceManagerAddress returns, as a string, the address of the EigenDAServiceManager contract for the network.
 func (n EigenDANetwork) GetServiceManagerAddress() (string, error) {
-	switch n {
-	case HoleskyTestnetEigenDANetwork:
-		return "0xD4A7E1Bd8015057293f0D0A557088c286942e84b", nil
-	case HoleskyPreprodEigenDANetwork:
-		return "0x54A03db2784E3D0aCC08344D05385d0b62d4F432", nil
-	case SepoliaTestnetEigenDANetwork:
-		return "0x3a5acf46ba6890B8536420F4900AC9BC45Df4764", nil
-	default:
-		return "", fmt.Errorf("unknown network type: %s", n)
-	}
+	return n.presetValue(ServiceManagerAddressName, func(p EigenDANetworkPreset) string {
+		return p.ServiceManagerAddress
+	})
 }
 
 // GetDisperserAddress gets a string representing the address of the disperser for the network.
 // The format of the returned address is "<hostname>:<port>"
 func (n EigenDANetwork) GetDisperserAddress() (string, error) {
-	switch n {
-	case HoleskyTestnetEigenDANetwork:
-		return "disperser-testnet-holesky.eigenda.xyz:443", nil
-	case HoleskyPreprodEigenDANetwork:
-		return "disperser-preprod-holesky.eigenda.xyz:443", nil
-	case SepoliaTestnetEigenDANetwork:
-		return "disperser-testnet-sepolia.eigenda.xyz:443", nil
-	default:
-		return "", fmt.Errorf("unknown network type: %s", n)
-	}
+	return n.presetValue(DisperserAddressName, func(p EigenDANetworkPreset) string {
+		return p.DisperserAddress
+	})
 }
 
 // GetBLSOperatorStateRetrieverAddress returns, as a string, the address of the OperatorStateRetriever contract for the
 // network
 func (n EigenDANetwork) GetBLSOperatorStateRetrieverAddress() (string, error) {
-	switch n {
-	case HoleskyTestnetEigenDANetwork, HoleskyPreprodEigenDANetwork:
-		return "0x003497Dd77E5B73C40e8aCbB562C8bb0410320E7", nil
-	case SepoliaTestnetEigenDANetwork:
-		return "0x22478d082E9edaDc2baE8443E4aC9473F6E047Ff", nil
-	default:
-		return "", fmt.Errorf("unknown network: %s", n)
+	return n.presetValue(BLSOperatorStateRetrieverAddressName, func(p EigenDANetworkPreset) string {
+		return p.BLSOperatorStateRetrieverAddress
+	})
+}
+
+// GetCertVerifierRouterAddress returns, as a string, the address of the EigenDACertVerifierRouter contract for the
+// network
+func (n EigenDANetwork) GetCertVerifierRouterAddress() (string, error) {
+	return n.presetValue(CertVerifierRouterAddressName, func(p EigenDANetworkPreset) string {
+		return p.CertVerifierRouterAddress
+	})
+}
+
+// GetRelayRegistryAddress returns, as a string, the address of the EigenDARelayRegistry contract for the network
+func (n EigenDANetwork) GetRelayRegistryAddress() (string, error) {
+	return n.presetValue(RelayRegistryAddressName, func(p EigenDANetworkPreset) string {
+		return p.RelayRegistryAddress
+	})
+}
+
+// presetValue returns the value of the network preset returned by get, or an error if it is not set
+func (n EigenDANetwork) presetValue(name string, get func(EigenDANetworkPreset) string) (string, error) {
+	preset, err := n.Preset()
+	if err != nil {
+		return "", err
 	}
+	value := get(preset)
+	if value == "" {
+		return "", fmt.Errorf("no %s preset for network %s", name, n)
+	}
+	return value, nil
 }
 
 func (n EigenDANetwork) String() string {
 	return string(n)
 }
 
-// chainIDToNetworkMap maps chain IDs to EigenDA networks
-var chainIDToNetworkMap = map[string][]EigenDANetwork{
-	"17000":    {HoleskyTestnetEigenDANetwork, HoleskyPreprodEigenDANetwork},
-	"11155111": {SepoliaTestnetEigenDANetwork},
-}
-
 // EigenDANetworksFromChainID returns the EigenDA network(s) for a given chain ID
 func EigenDANetworksFromChainID(chainID string) ([]EigenDANetwork, error) {
-	networks, ok := chainIDToNetworkMap[chainID]
-	if !ok {
+	eigenDANetworkPresetsLock.RLock()
+	defer eigenDANetworkPresetsLock.RUnlock()
+	var networks []EigenDANetwork
+	for _, preset := range eigenDANetworkPresets {
+		if preset.ChainID == chainID {
+			networks = append(networks, preset.Name)
+		}
+	}
+	if len(networks) == 0 {
 		return nil, fmt.Errorf("unknown chain ID: %s", chainID)
 	}
 	return networks, nil
@@ -73,11 +165,77 @@ func EigenDANetworksFromChainID(chainID string) ([]EigenDANetwork, error) {
 
 func EigenDANetworkFromString(inputString string) (EigenDANetwork, error) {
 	network := EigenDANetwork(inputString)
+	if _, err := network.Preset(); err != nil {
+		return "", err
+	}
+	return network, nil
+}
+
+// LoadEigenDANetworks loads custom network presets from a JSON file holding an array of [EigenDANetworkPreset], so
+// that private networks can be configured by name like the built-in ones. Custom networks loaded before under the
+// same name are replaced, while built-in networks can't be redefined.
+func LoadEigenDANetworks(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read networks file: %w", err)
+	}
+
+	var presets []EigenDANetworkPreset
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&presets); err != nil {
+		return fmt.Errorf("decode networks file %s: %w", path, err)
+	}
 
-	switch network {
-	case HoleskyTestnetEigenDANetwork, HoleskyPreprodEigenDANetwork, SepoliaTestnetEigenDANetwork:
-		return network, nil
-	default:
-		return "", fmt.Errorf("unknown network type: %s", inputString)
+	names := make(map[EigenDANetwork]bool, len(presets))
+	for _, preset := range presets {
+		if err = preset.check(); err != nil {
+			return fmt.Errorf("network %q in %s: %w", preset.Name, path, err)
+		}
+		if names[preset.Name] {
+			return fmt.Errorf("network %q is defined more than once in %s", preset.Name, path)
+		}
+		names[preset.Name] = true
+	}
+
+	eigenDANetworkPresetsLock.Lock()
+	defer eigenDANetworkPresetsLock.Unlock()
+	for _, preset := range presets {
+		i := slices.IndexFunc(eigenDANetworkPresets, func(p EigenDANetworkPreset) bool {
+			return p.Name == preset.Name
+		})
+		if i < 0 {
+			eigenDANetworkPresets = append(eigenDANetworkPresets, preset)
+		} else {
+			eigenDANetworkPresets[i] = preset
+		}
+	}
+	return nil
+}
+
+// check checks the invariants of a custom network preset
+func (p EigenDANetworkPreset) check() error {
+	if p.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	for _, builtin := range builtinEigenDANetworkPresets {
+		if builtin.Name == p.Name {
+			return fmt.Errorf("built-in networks can't be redefined")
+		}
+	}
+	if _, err := strconv.ParseUint(p.ChainID, 10, 64); err != nil {
+		return fmt.Errorf("chain_id must be a decimal number, got %q", p.ChainID)
+	}
+	addresses := []struct{ name, value string }{
+		{"service_manager_address", p.ServiceManagerAddress},
+		{"bls_operator_state_retriever_address", p.BLSOperatorStateRetrieverAddress},
+		{"cert_verifier_router_address", p.CertVerifierRouterAddress},
+		{"relay_registry_address", p.RelayRegistryAddress},
+	}
+	for _, address := range addresses {
+		if address.value != "" && !geth_common.IsHexAddress(address.value) {
+			return fmt.Errorf("%s is not a hex address: %q", address.name, address.value)
+		}
 	}
+	return nil
 }
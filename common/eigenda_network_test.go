@@ -0,0 +1,113 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEigenDANetworkPresets(t *testing.T) {
+	network, err := EigenDANetworkFromString("mainnet")
+	require.NoError(t, err)
+	require.Equal(t, MainnetEigenDANetwork, network)
+
+	address, err := MainnetEigenDANetwork.GetServiceManagerAddress()
+	require.NoError(t, err)
+	require.Equal(t, "0x870679E138bCdf293b7Ff14dD44b70FC97e12fc0", address)
+
+	address, err = SepoliaTestnetEigenDANetwork.GetCertVerifierRouterAddress()
+	require.NoError(t, err)
+	require.Equal(t, "0x58D2B844a894f00b7E6F9F492b9F43aD54Cd4429", address)
+
+	networks, err := EigenDANetworksFromChainID("17000")
+	require.NoError(t, err)
+	require.Equal(t, []EigenDANetwork{HoleskyTestnetEigenDANetwork, HoleskyPreprodEigenDANetwork}, networks)
+
+	_, err = EigenDANetworkFromString("unknown")
+	require.Error(t, err)
+	_, err = EigenDANetworksFromChainID("42")
+	require.Error(t, err)
+}
+
+func TestBuiltinEigenDANetworkPresetsAreComplete(t *testing.T) {
+	for _, preset := range builtinEigenDANetworkPresets {
+		t.Run(string(preset.Name), func(t *testing.T) {
+			// the relay registry is optional, as it is read from the service manager
+			contracts := map[string]func() (string, error){
+				ServiceManagerAddressName:            preset.Name.GetServiceManagerAddress,
+				BLSOperatorStateRetrieverAddressName: preset.Name.GetBLSOperatorStateRetrieverAddress,
+				CertVerifierRouterAddressName:        preset.Name.GetCertVerifierRouterAddress,
+			}
+			for name, get := range contracts {
+				address, err := get()
+				require.NoError(t, err)
+				require.True(t, geth_common.IsHexAddress(address), "%s %q is not a hex address", name, address)
+			}
+			_, err := preset.Name.GetDisperserAddress()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadEigenDANetworks(t *testing.T) {
+	// the loaded networks are global, restore the presets so that they don't leak into other tests
+	eigenDANetworkPresetsLock.RLock()
+	presets := slices.Clone(eigenDANetworkPresets)
+	eigenDANetworkPresetsLock.RUnlock()
+	t.Cleanup(func() {
+		eigenDANetworkPresetsLock.Lock()
+		defer eigenDANetworkPresetsLock.Unlock()
+		eigenDANetworkPresets = presets
+	})
+
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "networks.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	path := writeFile(t, `[{
+		"name": "test_devnet",
+		"chain_id": "31337",
+		"service_manager_address": "0x0000000000000000000000000000000000000001",
+		"disperser_address": "localhost:32001"
+	}]`)
+	require.NoError(t, LoadEigenDANetworks(path))
+
+	network, err := EigenDANetworkFromString("test_devnet")
+	require.NoError(t, err)
+	address, err := network.GetDisperserAddress()
+	require.NoError(t, err)
+	require.Equal(t, "localhost:32001", address)
+	_, err = network.GetBLSOperatorStateRetrieverAddress()
+	require.Error(t, err, "not defined by the custom network")
+	networks, err := EigenDANetworksFromChainID("31337")
+	require.NoError(t, err)
+	require.Equal(t, []EigenDANetwork{"test_devnet"}, networks)
+
+	// loading the network again replaces it
+	path = writeFile(t, `[{"name": "test_devnet", "chain_id": "31337", "disperser_address": "localhost:32002"}]`)
+	require.NoError(t, LoadEigenDANetworks(path))
+	address, err = network.GetDisperserAddress()
+	require.NoError(t, err)
+	require.Equal(t, "localhost:32002", address)
+
+	invalidFiles := map[string]string{
+		"built-in network":  `[{"name": "mainnet", "chain_id": "1"}]`,
+		"missing name":      `[{"chain_id": "1"}]`,
+		"invalid chain id":  `[{"name": "a", "chain_id": "0x1"}]`,
+		"invalid address":   `[{"name": "a", "chain_id": "1", "service_manager_address": "0x1"}]`,
+		"unknown field":     `[{"name": "a", "chain_id": "1", "unknown": "x"}]`,
+		"duplicate network": `[{"name": "a", "chain_id": "1"}, {"name": "a", "chain_id": "2"}]`,
+	}
+	for name, content := range invalidFiles {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, LoadEigenDANetworks(writeFile(t, content)))
+		})
+	}
+	require.Error(t, LoadEigenDANetworks(filepath.Join(t.TempDir(), "missing.json")))
+}
@@ -0,0 +1,50 @@
+package ethrpc
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	HealthCheckIntervalFlagName = withFlagPrefix("health-check-interval")
+	QuorumSizeFlagName          = withFlagPrefix("quorum-size")
+)
+
+func withFlagPrefix(s string) string {
+	return "eth-rpc." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_ETH_RPC_" + s}
+}
+
+// CLIFlags ... used for configuring failover and quorum reads across multiple ETH RPC endpoints
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name: HealthCheckIntervalFlagName,
+			Usage: "Interval at which every configured ETH RPC endpoint is health checked. " +
+				"Requests are routed to healthy endpoints first. 0 disables active health checks.",
+			Value:    10 * time.Second,
+			EnvVars:  withEnvPrefix(envPrefix, "HEALTH_CHECK_INTERVAL"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name: QuorumSizeFlagName,
+			Usage: "Number of ETH RPC endpoints that must return identical results for cert verification eth_calls. " +
+				"0 or 1 disables quorum reads. Cannot exceed the number of configured ETH RPC urls.",
+			Value:    0,
+			EnvVars:  withEnvPrefix(envPrefix, "QUORUM_SIZE"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		HealthCheckInterval: ctx.Duration(HealthCheckIntervalFlagName),
+		QuorumSize:          ctx.Int(QuorumSizeFlagName),
+	}
+}
@@ -0,0 +1,343 @@
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrQuorumNotReached is returned by quorum reads when not enough endpoints returned identical results.
+var ErrQuorumNotReached = errors.New("eth rpc quorum not reached")
+
+// endpoint is a single ETH RPC provider along with its last known health status.
+type endpoint struct {
+	client  *ethclient.Client
+	healthy atomic.Bool
+}
+
+// Client spreads requests over a list of ETH RPC endpoints.
+//
+// Regular requests are sent to the first healthy endpoint (in configuration order), and fail over to the next one
+// when an endpoint can't be reached. JSON-RPC errors returned by a node (e.g. execution reverted) are not
+// failed over, since every node would return the same error.
+//
+// Quorum reads (see QuorumCaller) are sent to all endpoints concurrently, and only succeed if at least
+// QuorumSize endpoints returned identical results (or identical node errors).
+type Client struct {
+	log        logging.Logger
+	endpoints  []*endpoint
+	quorumSize int
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ bind.ContractCaller = (*Client)(nil)
+
+// NewClient dials every url and starts health checking them in the background.
+// Dialing http(s) urls doesn't perform any network request, so this only fails on malformed urls.
+// Close must be called to stop the health checks.
+func NewClient(log logging.Logger, urls []string, cfg Config) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("at least one eth rpc url is required")
+	}
+	if err := cfg.Check(len(urls)); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		log:        log,
+		quorumSize: cfg.QuorumSize,
+		stop:       make(chan struct{}),
+	}
+	for i, url := range urls {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			c.Close()
+			// the url is not included in the error since it may contain an API key
+			return nil, fmt.Errorf("dial eth rpc endpoint #%d: %w", i, err)
+		}
+		e := &endpoint{client: client}
+		e.healthy.Store(true)
+		c.endpoints = append(c.endpoints, e)
+	}
+
+	if cfg.HealthCheckInterval > 0 && len(c.endpoints) > 1 {
+		go c.healthCheckLoop(cfg.HealthCheckInterval)
+	}
+	return c, nil
+}
+
+// Close stops the health checks and closes all underlying connections.
+func (c *Client) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		for _, e := range c.endpoints {
+			e.client.Close()
+		}
+	})
+}
+
+// NumEndpoints returns the number of configured endpoints
+func (c *Client) NumEndpoints() int {
+	return len(c.endpoints)
+}
+
+// NumHealthyEndpoints returns the number of endpoints currently considered healthy
+func (c *Client) NumHealthyEndpoints() int {
+	n := 0
+	for _, e := range c.endpoints {
+		if e.healthy.Load() {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *Client) healthCheckLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.checkHealth(interval)
+		}
+	}
+}
+
+// checkHealth probes every endpoint concurrently with an eth_blockNumber request.
+func (c *Client) checkHealth(timeout time.Duration) {
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			_, err := e.client.BlockNumber(ctx)
+			c.setHealthy(i, e, err == nil)
+		}(i, e)
+	}
+	wg.Wait()
+}
+
+func (c *Client) setHealthy(i int, e *endpoint, healthy bool) {
+	if e.healthy.Swap(healthy) != healthy {
+		if healthy {
+			c.log.Info("ETH RPC endpoint is healthy again", "endpoint", i)
+		} else {
+			c.log.Warn("ETH RPC endpoint marked unhealthy", "endpoint", i)
+		}
+	}
+}
+
+// orderedEndpoints returns the indexes of healthy endpoints followed by unhealthy ones,
+// each in configuration order. Unhealthy endpoints are kept as a last resort.
+func (c *Client) orderedEndpoints() []int {
+	healthy := make([]int, 0, len(c.endpoints))
+	var unhealthy []int
+	for i, e := range c.endpoints {
+		if e.healthy.Load() {
+			healthy = append(healthy, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// isNodeError returns true if err was returned by a node that was successfully reached,
+// in which case failing over to another endpoint would not help.
+func isNodeError(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// withFailover calls fn on each endpoint in turn until one succeeds or returns a node error.
+func withFailover[T any](ctx context.Context, c *Client, fn func(*ethclient.Client) (T, error)) (T, error) {
+	var result T
+	var errs []error
+	for _, i := range c.orderedEndpoints() {
+		e := c.endpoints[i]
+		res, err := fn(e.client)
+		if err == nil || isNodeError(err) {
+			c.setHealthy(i, e, true)
+			return res, err
+		}
+		if ctx.Err() != nil {
+			return result, err
+		}
+		c.setHealthy(i, e, false)
+		errs = append(errs, fmt.Errorf("endpoint #%d: %w", i, err))
+	}
+	return result, fmt.Errorf("all eth rpc endpoints failed: %w", errors.Join(errs...))
+}
+
+// CallContract executes an eth_call, failing over between endpoints.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return withFailover(ctx, c, func(client *ethclient.Client) ([]byte, error) {
+		return client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// CodeAt returns the contract code of the given account, failing over between endpoints.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return withFailover(ctx, c, func(client *ethclient.Client) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// BlockNumber returns the most recent block number, failing over between endpoints.
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return withFailover(ctx, c, func(client *ethclient.Client) (uint64, error) {
+		return client.BlockNumber(ctx)
+	})
+}
+
+// ChainID returns the chain ID, failing over between endpoints.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return withFailover(ctx, c, func(client *ethclient.Client) (*big.Int, error) {
+		return client.ChainID(ctx)
+	})
+}
+
+// CallContext performs a raw JSON-RPC call, failing over between endpoints.
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	_, err := withFailover(ctx, c, func(client *ethclient.Client) (struct{}, error) {
+		return struct{}{}, client.Client().CallContext(ctx, result, method, args...)
+	})
+	return err
+}
+
+// QuorumCaller returns a bind.ContractCaller to be used for verifying eth_calls.
+// If quorum reads are disabled, the Client itself (with plain failover) is returned.
+func (c *Client) QuorumCaller() bind.ContractCaller {
+	if c.quorumSize <= 1 {
+		return c
+	}
+	return &quorumCaller{c}
+}
+
+// quorumCaller sends eth_calls to all endpoints and requires quorumSize of them to agree on the result.
+type quorumCaller struct {
+	*Client
+}
+
+func (q *quorumCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return quorumCall(ctx, q.Client, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (q *quorumCaller) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return quorumCall(ctx, q.Client, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// quorumCall calls fn on every endpoint concurrently, and returns the first outcome that was returned by at least
+// quorumSize endpoints: either a result, or a node error (e.g. execution reverted), which is deterministic and
+// therefore agreed on the same way as results. The calls still in flight once the outcome is known are cancelled.
+// Note that calls made against the "latest" block (nil blockNumber) can spuriously disagree when endpoints are not
+// synced to the same head; callers that need strong guarantees should pin a block number.
+func quorumCall(
+	ctx context.Context,
+	c *Client,
+	fn func(context.Context, *ethclient.Client) ([]byte, error),
+) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type response struct {
+		idx    int
+		result []byte
+		err    error
+	}
+	responses := make(chan response, len(c.endpoints))
+	for i, e := range c.endpoints {
+		go func(i int, e *endpoint) {
+			result, err := fn(ctx, e.client)
+			responses <- response{idx: i, result: result, err: err}
+		}(i, e)
+	}
+
+	// outcome is a distinct result or node error, along with the number of endpoints which returned it
+	type outcome struct {
+		result []byte
+		err    error
+		count  int
+	}
+	var outcomes []*outcome
+	var errs []error
+	for range c.endpoints {
+		resp := <-responses
+		e := c.endpoints[resp.idx]
+		if resp.err != nil && !isNodeError(resp.err) {
+			if ctx.Err() == nil {
+				c.setHealthy(resp.idx, e, false)
+			}
+			errs = append(errs, fmt.Errorf("endpoint #%d: %w", resp.idx, resp.err))
+			continue
+		}
+		c.setHealthy(resp.idx, e, true)
+
+		var matched *outcome
+		for _, o := range outcomes {
+			sameResult := resp.err == nil && o.err == nil && bytes.Equal(o.result, resp.result)
+			if sameResult || (resp.err != nil && o.err != nil && sameNodeError(o.err, resp.err)) {
+				matched = o
+				break
+			}
+		}
+		if matched == nil {
+			matched = &outcome{result: resp.result, err: resp.err}
+			outcomes = append(outcomes, matched)
+		}
+		matched.count++
+		if matched.count >= c.quorumSize {
+			return matched.result, matched.err
+		}
+	}
+
+	if len(outcomes) > 1 {
+		c.log.Error("ETH RPC endpoints returned conflicting eth_call results", "distinctResults", len(outcomes))
+	}
+	for _, o := range outcomes {
+		if o.err != nil {
+			errs = append(errs, o.err)
+		}
+	}
+	return nil, fmt.Errorf("%w: needed %d identical responses out of %d endpoints (%d distinct results): %w",
+		ErrQuorumNotReached, c.quorumSize, len(c.endpoints), len(outcomes), errors.Join(errs...))
+}
+
+// sameNodeError returns whether two node errors have the same code, message and data (e.g. the revert reason).
+func sameNodeError(a, b error) bool {
+	var rpcErrA, rpcErrB rpc.Error
+	if !errors.As(a, &rpcErrA) || !errors.As(b, &rpcErrB) {
+		return false
+	}
+	if rpcErrA.ErrorCode() != rpcErrB.ErrorCode() || rpcErrA.Error() != rpcErrB.Error() {
+		return false
+	}
+	var dataErrA, dataErrB rpc.DataError
+	hasDataA, hasDataB := errors.As(a, &dataErrA), errors.As(b, &dataErrB)
+	if hasDataA != hasDataB {
+		return false
+	}
+	return !hasDataA || fmt.Sprint(dataErrA.ErrorData()) == fmt.Sprint(dataErrB.ErrorData())
+}
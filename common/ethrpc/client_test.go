@@ -0,0 +1,170 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// newTestRPCServer returns a JSON-RPC server answering every eth_call with callResult,
+// or with a JSON-RPC error if callResult is empty.
+func newTestRPCServer(t *testing.T, callResult string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch {
+		case req.Method == "eth_blockNumber":
+			resp["result"] = "0x10"
+		case req.Method == "eth_call" && callResult != "":
+			resp["result"] = callResult
+		default:
+			resp["error"] = map[string]interface{}{"code": 3, "message": "execution reverted"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newDeadURL returns the url of a server that is already closed
+func newDeadURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func TestFailoverToHealthyEndpoint(t *testing.T) {
+	t.Parallel()
+	healthy := newTestRPCServer(t, "0x01")
+
+	client, err := NewClient(testLogger, []string{newDeadURL(), healthy.URL}, Config{})
+	require.NoError(t, err)
+	defer client.Close()
+
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, result)
+	require.Equal(t, 1, client.NumHealthyEndpoints())
+
+	// the unhealthy endpoint is now skipped, and only tried as a last resort
+	require.Equal(t, []int{1, 0}, client.orderedEndpoints())
+}
+
+func TestNodeErrorsAreNotFailedOver(t *testing.T) {
+	t.Parallel()
+	reverting := newTestRPCServer(t, "")
+	healthy := newTestRPCServer(t, "0x01")
+
+	client, err := NewClient(testLogger, []string{reverting.URL, healthy.URL}, Config{})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.ErrorContains(t, err, "execution reverted")
+	require.Equal(t, 2, client.NumHealthyEndpoints())
+}
+
+func TestAllEndpointsDown(t *testing.T) {
+	t.Parallel()
+	client, err := NewClient(testLogger, []string{newDeadURL(), newDeadURL()}, Config{})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.BlockNumber(context.Background())
+	require.ErrorContains(t, err, "all eth rpc endpoints failed")
+	require.Equal(t, 0, client.NumHealthyEndpoints())
+}
+
+func TestQuorumReads(t *testing.T) {
+	t.Parallel()
+	agreeing1 := newTestRPCServer(t, "0x01")
+	agreeing2 := newTestRPCServer(t, "0x01")
+	disagreeing := newTestRPCServer(t, "0x02")
+
+	t.Run("quorum reached despite a dissenting endpoint", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(testLogger,
+			[]string{disagreeing.URL, agreeing1.URL, agreeing2.URL}, Config{QuorumSize: 2})
+		require.NoError(t, err)
+		defer client.Close()
+
+		result, err := client.QuorumCaller().CallContract(context.Background(), ethereum.CallMsg{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01}, result)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(testLogger,
+			[]string{disagreeing.URL, agreeing1.URL, newDeadURL()}, Config{QuorumSize: 2})
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.QuorumCaller().CallContract(context.Background(), ethereum.CallMsg{}, nil)
+		require.ErrorIs(t, err, ErrQuorumNotReached)
+	})
+
+	t.Run("quorum reached on a node error", func(t *testing.T) {
+		t.Parallel()
+		reverting1 := newTestRPCServer(t, "")
+		reverting2 := newTestRPCServer(t, "")
+		client, err := NewClient(testLogger,
+			[]string{disagreeing.URL, reverting1.URL, reverting2.URL}, Config{QuorumSize: 2})
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.QuorumCaller().CallContract(context.Background(), ethereum.CallMsg{}, nil)
+		require.ErrorContains(t, err, "execution reverted")
+		require.NotErrorIs(t, err, ErrQuorumNotReached)
+		require.True(t, isNodeError(err))
+		require.Equal(t, 3, client.NumHealthyEndpoints())
+	})
+
+	t.Run("calls in flight are cancelled once quorum is reached", func(t *testing.T) {
+		t.Parallel()
+		cancelled := make(chan struct{})
+		hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the server only notices the client going away once the request body was read
+			_, _ = io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			close(cancelled)
+		}))
+		t.Cleanup(hanging.Close)
+		client, err := NewClient(testLogger,
+			[]string{hanging.URL, agreeing1.URL, agreeing2.URL}, Config{QuorumSize: 2})
+		require.NoError(t, err)
+		defer client.Close()
+
+		result, err := client.QuorumCaller().CallContract(context.Background(), ethereum.CallMsg{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01}, result)
+		select {
+		case <-cancelled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("call to the hanging endpoint was not cancelled")
+		}
+	})
+
+	t.Run("quorum size larger than the number of endpoints", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewClient(testLogger, []string{agreeing1.URL}, Config{QuorumSize: 2})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,39 @@
+package ethrpc
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config contains the settings shared by the V1 and V2 ETH RPC clients.
+// The RPC URLs themselves are secrets (they often embed API keys), and are therefore part of the
+// V1 client config and V2 secret config respectively.
+type Config struct {
+	// Interval at which every configured endpoint is probed with an eth_blockNumber request.
+	// Unhealthy endpoints are only used once all healthy endpoints have failed. 0 disables active health checks,
+	// in which case endpoints are only marked unhealthy/healthy based on the outcome of regular requests.
+	HealthCheckInterval time.Duration
+	// Number of endpoints that must return identical results for cert verification eth_calls.
+	// 0 or 1 disables quorum reads, in which case calls are sent to a single healthy endpoint.
+	QuorumSize int
+}
+
+// Check checks config invariants against the number of configured RPC URLs
+func (c Config) Check(numURLs int) error {
+	if c.QuorumSize < 0 {
+		return fmt.Errorf("eth rpc quorum size must be >= 0, got %d", c.QuorumSize)
+	}
+	if c.QuorumSize > numURLs {
+		return fmt.Errorf("eth rpc quorum size (%d) cannot exceed the number of configured eth rpc urls (%d)",
+			c.QuorumSize, numURLs)
+	}
+	if c.HealthCheckInterval < 0 {
+		return fmt.Errorf("eth rpc health check interval must be >= 0, got %s", c.HealthCheckInterval)
+	}
+	return nil
+}
+
+// QuorumEnabled returns true if verifying eth_calls must be agreed upon by multiple endpoints
+func (c Config) QuorumEnabled() bool {
+	return c.QuorumSize > 1
+}
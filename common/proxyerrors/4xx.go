@@ -3,10 +3,13 @@ package proxyerrors
 import (
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
 	_ "github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,6 +34,26 @@ func Is400(err error) bool {
 		errors.Is(err, s3.ErrKeccakKeyNotFound)
 }
 
+// 401 UNAUTHORIZED is returned when multi-tenancy is enabled and a cert request doesn't carry
+// the API key of a configured tenant.
+func Is401(err error) bool {
+	return errors.Is(err, tenant.ErrMissingAPIKey) || errors.Is(err, tenant.ErrUnknownAPIKey)
+}
+
+// 403 FORBIDDEN is returned when a tenant uses a commitment mode it isn't allowed to.
+func Is403(err error) bool {
+	var notAllowedErr tenant.CommitmentModeNotAllowedError
+	return errors.As(err, &notAllowedErr)
+}
+
+// 413 REQUEST_ENTITY_TOO_LARGE is returned when the body of a POST request exceeds the configured max size,
+// which by default is the max blob size: such payloads could never be dispersed.
+// It must be checked before [Is400], which matches every [ReadRequestBodyError].
+func Is413(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
 // We return a 418 TEAPOT error for any cert validation error.
 // Rollup derivation pipeline should drop any certs that return this error.
 // See https://github.com/Layr-Labs/optimism/pull/45 for how this is
@@ -44,7 +67,14 @@ func Is418(err error) bool {
 // on the EigenDA disperser. The disperser returns a grpc RESOURCE_EXHAUSTED error, which we convert
 // to an HTTP error. It doesn't have any meaning other than to request the client to retry later,
 // and/or slow down their rate of requests.
+// It is also returned when a tenant exhausted its quota, or a request is rejected by the proxy's rate or
+// in-flight limits, in which case the Retry-After header is set.
 func Is429(err error) bool {
+	var quotaErr *tenant.QuotaExceededError
+	var limitErr *ratelimit.LimitExceededError
+	if errors.As(err, &quotaErr) || errors.As(err, &limitErr) {
+		return true
+	}
 	st, isGRPCError := status.FromError(err)
 	return isGRPCError && st.Code() == codes.ResourceExhausted
 }
@@ -114,6 +144,10 @@ func (me ReadRequestBodyError) Error() string {
 	return fmt.Sprintf("reading at most %d bytes from body: %s", me.bodyLimit, me.err.Error())
 }
 
+func (me ReadRequestBodyError) Unwrap() error {
+	return me.err
+}
+
 type UnmarshalJSONError struct {
 	err error
 }
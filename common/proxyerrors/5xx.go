@@ -1,9 +1,12 @@
 package proxyerrors
 
 import (
+	"context"
 	"errors"
 
 	"github.com/Layr-Labs/eigenda/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // 503 is returned to tell the caller (batcher) to failover to ethda b/c eigenda is temporarily down
@@ -11,3 +14,14 @@ func Is503(err error) bool {
 	// TODO: would be cleaner to define a sentinel error in eigenda-core and use that instead
 	return errors.Is(err, &api.ErrorFailover{})
 }
+
+// 504 GATEWAY_TIMEOUT is returned when the deadline of a request expired before it could be processed,
+// either the one configured for its route or the one requested by the client.
+// It must be checked after [Is503], since failover errors may be caused by a deadline.
+func Is504(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	st, isGRPCError := status.FromError(err)
+	return isGRPCError && st.Code() == codes.DeadlineExceeded
+}
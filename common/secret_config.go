@@ -1,22 +1,44 @@
 package common
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
 // SecretConfigV2 contains sensitive config data that must be protected from leakage
 type SecretConfigV2 struct {
-	// SignerPaymentKey is the hex representation of the private payment key, that pays for payload dispersal
+	// SignerPaymentKey is the hex representation of the private payment key, that pays for payload dispersal.
+	// Only used by the local signer (see [signer.TypeLocal]).
 	SignerPaymentKey string
 	EthRPCURL        string
+	// EthRPCFallbackURLs are used whenever EthRPCURL is unhealthy, and for quorum reads
+	EthRPCFallbackURLs []string
 }
 
-// Check checks config invariants, and returns an error if there is a problem with the config struct
-func (s *SecretConfigV2) Check() error {
-	if s.SignerPaymentKey == "" {
-		return fmt.Errorf("signer payment private key is required for using EigenDA V2 backend")
+// MarshalJSON masks the secrets which are set, so that the config can be logged or served.
+func (s SecretConfigV2) MarshalJSON() ([]byte, error) {
+	type Alias SecretConfigV2 // Use an alias to avoid recursion with MarshalJSON
+	aux := (Alias)(s)
+	if aux.SignerPaymentKey != "" {
+		aux.SignerPaymentKey = "*****"
+	}
+	if aux.EthRPCURL != "" {
+		// hiding as RPC providers typically use sensitive API keys within
+		aux.EthRPCURL = "*****"
 	}
+	if len(aux.EthRPCFallbackURLs) > 0 {
+		aux.EthRPCFallbackURLs = []string{"*****"}
+	}
+	return json.Marshal(aux)
+}
 
+// EthRPCURLs returns the primary ETH RPC url followed by the fallback urls
+func (s *SecretConfigV2) EthRPCURLs() []string {
+	return append([]string{s.EthRPCURL}, s.EthRPCFallbackURLs...)
+}
+
+// Check checks config invariants, and returns an error if there is a problem with the config struct
+func (s *SecretConfigV2) Check() error {
 	if s.EthRPCURL == "" {
 		return fmt.Errorf("eth rpc url is required for using EigenDA V2 backend")
 	}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -22,18 +23,34 @@ func TestValidSecretConfig(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestSignerPaymentKeyMissing(t *testing.T) {
+func TestEthRPCMissing(t *testing.T) {
 	cfg := validSecretConfig()
-	cfg.SignerPaymentKey = ""
+	cfg.EthRPCURL = ""
 
 	err := cfg.Check()
 	require.Error(t, err)
 }
 
-func TestEthRPCMissing(t *testing.T) {
+func TestEthRPCURLs(t *testing.T) {
 	cfg := validSecretConfig()
-	cfg.EthRPCURL = ""
+	require.Equal(t, []string{"http://localhost:8545"}, cfg.EthRPCURLs())
 
-	err := cfg.Check()
-	require.Error(t, err)
+	cfg.EthRPCFallbackURLs = []string{"http://localhost:8546", "http://localhost:8547"}
+	require.Equal(t,
+		[]string{"http://localhost:8545", "http://localhost:8546", "http://localhost:8547"}, cfg.EthRPCURLs())
+}
+
+func TestSecretConfigMarshalJSON(t *testing.T) {
+	cfg := validSecretConfig()
+	cfg.EthRPCFallbackURLs = []string{"http://localhost:8546"}
+
+	configJSON, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"SignerPaymentKey":"*****","EthRPCURL":"*****","EthRPCFallbackURLs":["*****"]}`, string(configJSON))
+	require.Equal(t, "0x000000000000000", cfg.SignerPaymentKey, "the config is not modified")
+
+	configJSON, err = json.Marshal(SecretConfigV2{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"SignerPaymentKey":"","EthRPCURL":"","EthRPCFallbackURLs":null}`, string(configJSON))
 }
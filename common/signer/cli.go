@@ -0,0 +1,85 @@
+package signer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	TypeFlagName                 = withFlagPrefix("type")
+	KeystorePathFlagName         = withFlagPrefix("keystore-path")
+	KeystorePasswordFileFlagName = withFlagPrefix("keystore-password-file")
+	RemoteURLFlagName            = withFlagPrefix("remote-url")
+	RemoteAddressFlagName        = withFlagPrefix("remote-address")
+	RemoteTimeoutFlagName        = withFlagPrefix("remote-timeout")
+)
+
+func withFlagPrefix(s string) string {
+	return "eigenda.v2.signer." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_EIGENDA_V2_SIGNER_" + s}
+}
+
+// CLIFlags ... used for selecting and configuring the signer of EigenDA V2 dispersal payments
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: TypeFlagName,
+			Usage: fmt.Sprintf("Signer of EigenDA V2 dispersal payments. %s: raw hex private key (see "+
+				"--eigenda.v2.signer-payment-key-hex), %s: encrypted geth keystore file, "+
+				"%s: remote signer of raw digests (see --eigenda.v2.signer.remote-url).", TypeLocal, TypeKeystore, TypeRemote),
+			Value:    string(TypeLocal),
+			EnvVars:  withEnvPrefix(envPrefix, "TYPE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     KeystorePathFlagName,
+			Usage:    "Path to the encrypted geth keystore file holding the signer key. Used by the keystore signer.",
+			EnvVars:  withEnvPrefix(envPrefix, "KEYSTORE_PATH"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     KeystorePasswordFileFlagName,
+			Usage:    "Path to a file containing the password of the keystore file. Used by the keystore signer.",
+			EnvVars:  withEnvPrefix(envPrefix, "KEYSTORE_PASSWORD_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: RemoteURLFlagName,
+			Usage: "Base URL of the remote signer. Digests are signed with POST <url>/api/v1/eth1/sign/<address>, " +
+				"which must sign the raw 32 byte digest without EIP-191 prefixing or hashing (unlike Web3Signer). " +
+				"Used by the remote signer.",
+			EnvVars:  withEnvPrefix(envPrefix, "REMOTE_URL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     RemoteAddressFlagName,
+			Usage:    "Ethereum address of the remote signer key that pays for dispersals. Used by the remote signer.",
+			EnvVars:  withEnvPrefix(envPrefix, "REMOTE_ADDRESS"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     RemoteTimeoutFlagName,
+			Usage:    "Timeout of each signing request sent to the remote signer. Used by the remote signer.",
+			Value:    5 * time.Second,
+			EnvVars:  withEnvPrefix(envPrefix, "REMOTE_TIMEOUT"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Type:                 Type(ctx.String(TypeFlagName)),
+		KeystorePath:         ctx.String(KeystorePathFlagName),
+		KeystorePasswordFile: ctx.String(KeystorePasswordFileFlagName),
+		RemoteURL:            ctx.String(RemoteURLFlagName),
+		RemoteAddress:        ctx.String(RemoteAddressFlagName),
+		RemoteTimeout:        ctx.Duration(RemoteTimeoutFlagName),
+	}
+}
@@ -0,0 +1,74 @@
+package signer
+
+import (
+	"fmt"
+	"time"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// Type ... selects where the key signing EigenDA V2 dispersal payments comes from
+type Type string
+
+const (
+	// TypeLocal signs with the raw hex private key of the secret config.
+	TypeLocal Type = "local"
+	// TypeKeystore signs with a key decrypted from an encrypted geth keystore file.
+	TypeKeystore Type = "keystore"
+	// TypeRemote delegates signing to a remote signer over HTTP, so that the key never enters the proxy.
+	TypeRemote Type = "remote"
+)
+
+// Config ... configures the signer of EigenDA V2 dispersal payments.
+// The raw hex private key used by [TypeLocal] is a secret, and is therefore part of the V2 secret config.
+type Config struct {
+	Type Type
+
+	// Path to the encrypted geth keystore file, for [TypeKeystore].
+	KeystorePath string
+	// Path to a file containing the password of the keystore file, for [TypeKeystore].
+	// The password is read from a file rather than a flag so that it doesn't leak through the process arguments.
+	KeystorePasswordFile string
+
+	// Base URL of the remote signer, for [TypeRemote].
+	RemoteURL string
+	// Ethereum address of the key to sign with, for [TypeRemote].
+	RemoteAddress string
+	// Timeout of each signing request sent to the remote signer, for [TypeRemote].
+	RemoteTimeout time.Duration
+}
+
+// Check checks config invariants, given whether a raw hex private key was provided in the secret config
+func (c Config) Check(hasPrivateKey bool) error {
+	switch c.Type {
+	case TypeLocal:
+		if !hasPrivateKey {
+			return fmt.Errorf("signer payment private key is required for using EigenDA V2 backend with a %s signer",
+				TypeLocal)
+		}
+	case TypeKeystore:
+		if c.KeystorePath == "" {
+			return fmt.Errorf("keystore path is required for a %s signer", TypeKeystore)
+		}
+		if c.KeystorePasswordFile == "" {
+			return fmt.Errorf("keystore password file is required for a %s signer", TypeKeystore)
+		}
+	case TypeRemote:
+		if c.RemoteURL == "" {
+			return fmt.Errorf("remote signer url is required for a %s signer", TypeRemote)
+		}
+		if !geth_common.IsHexAddress(c.RemoteAddress) {
+			return fmt.Errorf("remote signer address must be a hex ethereum address, got %q", c.RemoteAddress)
+		}
+		if c.RemoteTimeout <= 0 {
+			return fmt.Errorf("remote signer timeout must be > 0, got %s", c.RemoteTimeout)
+		}
+	default:
+		return fmt.Errorf("unknown signer type %q, must be one of %s, %s, %s", c.Type, TypeLocal, TypeKeystore, TypeRemote)
+	}
+
+	if c.Type != TypeLocal && hasPrivateKey {
+		return fmt.Errorf("signer payment private key must not be set when using a %s signer", c.Type)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package signer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCheck(t *testing.T) {
+	remote := Config{
+		Type:          TypeRemote,
+		RemoteURL:     "http://localhost:9000",
+		RemoteAddress: "0x1111111111111111111111111111111111111111",
+		RemoteTimeout: time.Second,
+	}
+	keystore := Config{Type: TypeKeystore, KeystorePath: "key.json", KeystorePasswordFile: "password"}
+
+	require.NoError(t, Config{Type: TypeLocal}.Check(true))
+	require.Error(t, Config{Type: TypeLocal}.Check(false), "local signer requires a private key")
+	require.NoError(t, keystore.Check(false))
+	require.Error(t, keystore.Check(true), "a private key alongside another signer type is ambiguous")
+	require.NoError(t, remote.Check(false))
+
+	remote.RemoteAddress = "not an address"
+	require.Error(t, remote.Check(false))
+	require.Error(t, Config{Type: "kms"}.Check(false))
+}
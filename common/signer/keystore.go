@@ -0,0 +1,29 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// LoadKeystoreKey decrypts the private key held by the geth keystore file at keystorePath,
+// with the password held by the file at passwordFile. Trailing newlines of the password file are ignored.
+func LoadKeystoreKey(keystorePath string, passwordFile string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := os.ReadFile(keystorePath) // #nosec G304 -- path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("read keystore file: %w", err)
+	}
+	password, err := os.ReadFile(passwordFile) // #nosec G304 -- path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("read keystore password file: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, strings.TrimRight(string(password), "\r\n"))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore %s: %w", keystorePath, err)
+	}
+	return key.PrivateKey, nil
+}
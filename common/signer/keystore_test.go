@@ -0,0 +1,32 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeystoreKey(t *testing.T) {
+	dir := t.TempDir()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "correct horse")
+	require.NoError(t, err)
+
+	passwordFile := filepath.Join(dir, "password")
+	// trailing newlines, as added by most editors, are ignored
+	require.NoError(t, os.WriteFile(passwordFile, []byte("correct horse\n"), 0o600))
+
+	loaded, err := LoadKeystoreKey(account.URL.Path, passwordFile)
+	require.NoError(t, err)
+	require.Equal(t, key.D, loaded.D)
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("wrong horse"), 0o600))
+	_, err = LoadKeystoreKey(account.URL.Path, passwordFile)
+	require.ErrorContains(t, err, "decrypt keystore")
+}
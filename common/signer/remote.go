@@ -0,0 +1,122 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxResponseBytes bounds the size of a remote signer response, which is expected to be a hex encoded signature.
+const maxResponseBytes = 4096
+
+// signRequest ... is the body of a raw digest signing request
+type signRequest struct {
+	Data hexutil.Bytes `json:"data"`
+}
+
+// RemoteSigner ... signs digests with a key held by a remote signer exposing the following HTTP API:
+//
+//	POST <url>/api/v1/eth1/sign/<address>
+//	Body: {"data": "0x<32 byte digest>"}
+//	Response: "0x<65 byte [R || S || V] signature>"
+//
+// The digest must be signed as-is, without any further hashing or prefixing, since the disperser checks the
+// signatures against the raw blob keys and payment request hashes.
+// Although the route is the one of Web3Signer's eth1 sign endpoint, Web3Signer itself can't be used: it signs the
+// keccak hash of the EIP-191 prefixed data. A custom signer signing raw digests is needed.
+// Every returned signature is checked to recover to the configured address, so a misbehaving or
+// misconfigured signer is detected before the signature is sent to the disperser.
+type RemoteSigner struct {
+	url     string
+	address geth_common.Address
+	client  *http.Client
+}
+
+// NewRemoteSigner ... constructor
+func NewRemoteSigner(url string, address geth_common.Address, timeout time.Duration) *RemoteSigner {
+	return &RemoteSigner{
+		url:     strings.TrimSuffix(url, "/"),
+		address: address,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Address returns the ethereum address of the remote key
+func (s *RemoteSigner) Address() geth_common.Address {
+	return s.address
+}
+
+// SignDigest signs a 32 byte digest with the remote key. The returned signature is in the [R || S || V] format
+// with V in {0, 1}, as returned by [crypto.Sign].
+func (s *RemoteSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+
+	body, err := json.Marshal(signRequest{Data: digest})
+	if err != nil {
+		return nil, fmt.Errorf("encode sign request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, s.url+"/api/v1/eth1/sign/"+s.address.Hex(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("new sign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send sign request to remote signer: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read remote signer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	sig, err := hexutil.Decode(strings.Trim(strings.TrimSpace(string(respBody)), `"`))
+	if err != nil {
+		return nil, fmt.Errorf("decode remote signer signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer signature must be %d bytes, got %d", crypto.SignatureLength, len(sig))
+	}
+	// signers following the ethereum convention return V in {27, 28}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return nil, fmt.Errorf("recover remote signer public key: %w", err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pubKey); recovered != s.address {
+		if signedPrefixedData(digest, sig, s.address) {
+			return nil, fmt.Errorf("remote signer signed the EIP-191 prefixed digest, as Web3Signer does, " +
+				"instead of the raw digest")
+		}
+		return nil, fmt.Errorf("remote signer signature recovers to %s instead of %s", recovered.Hex(), s.address.Hex())
+	}
+	return sig, nil
+}
+
+// signedPrefixedData returns true if sig is the signature by address of the EIP-191 prefixed data, rather than of
+// the data itself
+func signedPrefixedData(data []byte, sig []byte, address geth_common.Address) bool {
+	pubKey, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	return err == nil && crypto.PubkeyToAddress(*pubKey) == address
+}
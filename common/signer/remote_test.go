@@ -0,0 +1,115 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newStandInSigner starts a local stand-in for a remote signer of raw digests holding key.
+// Signatures are returned with V in {27, 28}, following the ethereum convention.
+func newStandInSigner(t *testing.T, key *ecdsa.PrivateKey) *httptest.Server {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/eth1/sign/"+address.Hex() {
+			http.NotFound(w, r)
+			return
+		}
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig, err := crypto.Sign(req.Data, key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig[crypto.RecoveryIDOffset] += 27
+		_, _ = w.Write([]byte(hexutil.Encode(sig)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRemoteSigner_SignDigest(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	srv := newStandInSigner(t, key)
+
+	digest := crypto.Keccak256([]byte("blob key"))
+	remote := NewRemoteSigner(srv.URL+"/", address, time.Second)
+	sig, err := remote.SignDigest(context.Background(), digest)
+	require.NoError(t, err)
+
+	expected, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig, "signature should match a local signature, with V normalized to {0, 1}")
+}
+
+func TestRemoteSigner_RejectsSignatureOfAnotherKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherAddress := crypto.PubkeyToAddress(otherKey.PublicKey)
+	// a misconfigured signer, which signs requests for any address with another key
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sig, err := crypto.Sign(req.Data, otherKey)
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(hexutil.Encode(sig)))
+	}))
+	defer srv.Close()
+
+	remote := NewRemoteSigner(srv.URL, crypto.PubkeyToAddress(key.PublicKey), time.Second)
+	_, err = remote.SignDigest(context.Background(), crypto.Keccak256([]byte("blob key")))
+	require.ErrorContains(t, err, "recovers to "+otherAddress.Hex())
+}
+
+func TestRemoteSigner_RejectsWeb3Signer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	// Web3Signer's eth1 sign endpoint signs the keccak hash of the EIP-191 prefixed data
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sig, err := crypto.Sign(accounts.TextHash(req.Data), key)
+		require.NoError(t, err)
+		sig[crypto.RecoveryIDOffset] += 27
+		_, _ = w.Write([]byte(hexutil.Encode(sig)))
+	}))
+	defer srv.Close()
+
+	remote := NewRemoteSigner(srv.URL, address, time.Second)
+	_, err = remote.SignDigest(context.Background(), crypto.Keccak256([]byte("blob key")))
+	require.ErrorContains(t, err, "signed the EIP-191 prefixed digest")
+}
+
+func TestRemoteSigner_Errors(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	srv := newStandInSigner(t, key)
+
+	remote := NewRemoteSigner(srv.URL, crypto.PubkeyToAddress(key.PublicKey), time.Second)
+	_, err = remote.SignDigest(context.Background(), []byte("too short"))
+	require.ErrorContains(t, err, "digest must be 32 bytes")
+
+	unknownKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	remote = NewRemoteSigner(srv.URL, crypto.PubkeyToAddress(unknownKey.PublicKey), time.Second)
+	_, err = remote.SignDigest(context.Background(), crypto.Keccak256([]byte("blob key")))
+	require.ErrorContains(t, err, "status 404")
+}
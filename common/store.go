@@ -17,6 +17,7 @@ const (
 	MemstoreV2BackendType
 	S3BackendType
 	RedisBackendType
+	GCSBackendType
 
 	UnknownBackendType
 )
@@ -35,6 +36,8 @@ func (b BackendType) String() string {
 		return "S3"
 	case RedisBackendType:
 		return "Redis"
+	case GCSBackendType:
+		return "GCS"
 	case UnknownBackendType:
 		fallthrough
 	default:
@@ -58,6 +61,8 @@ func StringToBackendType(s string) BackendType {
 		return S3BackendType
 	case "redis":
 		return RedisBackendType
+	case "gcs":
+		return GCSBackendType
 	case "unknown":
 		fallthrough
 	default:
@@ -118,3 +123,12 @@ type SecondaryStore interface {
 	// Verify verifies the given key-value pair.
 	Verify(ctx context.Context, key []byte, value []byte) error
 }
+
+// ListableSecondaryStore is a SecondaryStore whose keys can be listed, e.g. to migrate its entries to another
+// backend.
+type ListableSecondaryStore interface {
+	SecondaryStore
+	// ForEachKey calls fn with each key of the data store, in no particular order, and stops at the first error
+	// returned by fn. Keys inserted or removed while listing may or may not be visited.
+	ForEachKey(ctx context.Context, fn func(key []byte) error) error
+}
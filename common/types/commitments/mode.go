@@ -43,3 +43,38 @@ func EncodeCommitment(
 	}
 	return nil, fmt.Errorf("unknown commitment mode")
 }
+
+// DecodeCommitment is the inverse of EncodeCommitment: it strips the commitmentMode-related header bytes from a
+// commitment, and returns the versionedCert it holds. OP keccak commitments hold the hash of the payload rather than a
+// cert, so they can't be decoded.
+func DecodeCommitment(
+	commitment []byte,
+	commitmentMode CommitmentMode,
+) (certs.VersionedCert, error) {
+	switch commitmentMode {
+	case OptimismKeccakCommitmentMode:
+		return certs.VersionedCert{}, fmt.Errorf("%s commitments hold the hash of the payload, not a cert",
+			commitmentMode)
+	case OptimismGenericCommitmentMode:
+		if len(commitment) < 2 || commitment[0] != byte(OPGenericCommitmentByte) {
+			return certs.VersionedCert{}, fmt.Errorf("%s commitments start with 0x%02x",
+				commitmentMode, byte(OPGenericCommitmentByte))
+		}
+		if commitment[1] != EigenDALayerByte {
+			return certs.VersionedCert{}, fmt.Errorf("unknown DA layer byte 0x%02x", commitment[1])
+		}
+		commitment = commitment[2:]
+	case StandardCommitmentMode:
+	default:
+		return certs.VersionedCert{}, fmt.Errorf("unknown commitment mode")
+	}
+
+	if len(commitment) == 0 {
+		return certs.VersionedCert{}, fmt.Errorf("commitment has no cert")
+	}
+	version, err := certs.ByteToVersion(commitment[0])
+	if err != nil {
+		return certs.VersionedCert{}, err
+	}
+	return certs.NewVersionedCert(commitment[1:], version), nil
+}
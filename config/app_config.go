@@ -1,23 +1,31 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"slices"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/config/v2/eigendaflags"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/server"
 	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/urfave/cli/v2"
 )
 
 // AppConfig ... Highest order config. Stores all relevant fields necessary for running both proxy & metrics servers.
 type AppConfig struct {
+	// LogLevel is the lowest log level that will be output
+	LogLevel            slog.Level
 	StoreBuilderConfig  builder.Config
 	SecretConfig        common.SecretConfigV2
 	ServerConfig        server.Config
 	MetricsServerConfig metrics.Config
+	TracingConfig       tracing.Config
 }
 
 // Check checks config invariants, and returns an error if there is a problem with the config struct
@@ -27,27 +35,78 @@ func (c AppConfig) Check() error {
 		return fmt.Errorf("check eigenDAConfig: %w", err)
 	}
 
+	err = c.TracingConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check tracing config: %w", err)
+	}
+
+	err = c.ServerConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check server config: %w", err)
+	}
+
 	v2Enabled := slices.Contains(c.StoreBuilderConfig.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
-	if v2Enabled && !c.StoreBuilderConfig.MemstoreEnabled {
+	if v2Enabled && !c.StoreBuilderConfig.MemstoreEnabled && !c.StoreBuilderConfig.RecordingConfig.ReplayEnabled() {
 		err = c.SecretConfig.Check()
 		if err != nil {
 			return fmt.Errorf("check secret config: %w", err)
 		}
+		err = c.StoreBuilderConfig.ClientConfigV2.EthRPC.Check(len(c.SecretConfig.EthRPCURLs()))
+		if err != nil {
+			return fmt.Errorf("check v2 eth rpc config: %w", err)
+		}
+		err = c.StoreBuilderConfig.ClientConfigV2.Signer.Check(c.SecretConfig.SignerPaymentKey != "")
+		if err != nil {
+			return fmt.Errorf("check v2 signer config: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// MarshalJSON hides the secrets of the config, so that it can be logged or served.
+func (c AppConfig) MarshalJSON() ([]byte, error) {
+	type Alias AppConfig // Use an alias to avoid recursion with MarshalJSON
+	aux := (Alias)(c)
+	aux.StoreBuilderConfig = c.StoreBuilderConfig.Redacted()
+	return json.Marshal(aux)
+}
+
+// LoadAppConfig ... reads the config at startup, after loading the global state it depends on (the custom EigenDA
+// networks). Reloads only use ReadAppConfig, so that they leave the global state untouched.
+func LoadAppConfig(ctx *cli.Context) (AppConfig, error) {
+	if err := eigendaflags.LoadNetworks(ctx); err != nil {
+		return AppConfig{}, err
+	}
+	return ReadAppConfig(ctx)
+}
+
 func ReadAppConfig(ctx *cli.Context) (AppConfig, error) {
 	storeBuilderConfig, err := builder.ReadConfig(ctx)
 	if err != nil {
 		return AppConfig{}, fmt.Errorf("read proxy config: %w", err)
 	}
 
+	logLevel, err := proxy_logging.ReadLogLevel(ctx)
+	if err != nil {
+		return AppConfig{}, fmt.Errorf("read log level: %w", err)
+	}
+
+	serverConfig := server.ReadConfig(ctx)
+	// the server resolves the tenant of each request, while the store builder only needs the tenants' signers
+	serverConfig.Tenants = tenant.NewRegistry(storeBuilderConfig.TenantsConfig)
+	serverConfig.EnabledBackends = storeBuilderConfig.StoreConfig.BackendsToEnable
+	if serverConfig.MaxPOSTBodyBytes == 0 {
+		// payloads larger than a blob can never be dispersed, so they are rejected upfront with a 413
+		serverConfig.MaxPOSTBodyBytes = int64(storeBuilderConfig.MaxBlobSizeBytes()) // #nosec G115
+	}
+
 	return AppConfig{
+		LogLevel:            logLevel,
 		StoreBuilderConfig:  storeBuilderConfig,
 		SecretConfig:        eigendaflags.ReadSecretConfigV2(ctx),
-		ServerConfig:        server.ReadConfig(ctx),
+		ServerConfig:        serverConfig,
 		MetricsServerConfig: metrics.ReadConfig(ctx),
+		TracingConfig:       tracing.ReadConfig(ctx),
 	}, nil
 }
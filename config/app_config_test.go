@@ -0,0 +1,32 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppConfigMarshalJSONRedactsTenantAPIKeyHashes(t *testing.T) {
+	apiKeyHash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	cfg := AppConfig{
+		StoreBuilderConfig: builder.Config{
+			TenantsConfig: tenant.Config{
+				Tenants: []tenant.Tenant{{Name: "rollup-a", APIKeySHA256: apiKeyHash}},
+			},
+		},
+	}
+
+	configJSON, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NotContains(t, string(configJSON), apiKeyHash)
+	require.Contains(t, string(configJSON), "rollup-a", "only the hash is redacted")
+	require.Equal(t, apiKeyHash, cfg.StoreBuilderConfig.TenantsConfig.Tenants[0].APIKeySHA256,
+		"the config is not modified")
+
+	builderJSON, err := cfg.StoreBuilderConfig.ToString()
+	require.NoError(t, err)
+	require.NotContains(t, builderJSON, apiKeyHash)
+}
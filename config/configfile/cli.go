@@ -0,0 +1,19 @@
+package configfile
+
+import "github.com/urfave/cli/v2"
+
+const FlagName = "config"
+
+// CLIFlags ... used for passing a config file
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: FlagName,
+			Usage: "Path to a YAML (.yaml, .yml) or TOML (.toml) config file, whose keys are flag names. " +
+				"Nested tables are joined with dots. Flags and env vars take precedence over the file.",
+			EnvVars:  []string{envPrefix + "_CONFIG"},
+			Category: category,
+		},
+	}
+}
@@ -0,0 +1,152 @@
+// Package configfile lets the proxy be configured with a YAML or TOML file, as an alternative to flags and env vars.
+//
+// The keys of the file are flag names, and nested tables are joined with dots, such that the two following files
+// are equivalent:
+//
+//	eigenda.v2.disperser-rpc: disperser-testnet-holesky.eigenda.xyz:443
+//
+//	eigenda:
+//	  v2:
+//	    disperser-rpc: disperser-testnet-holesky.eigenda.xyz:443
+//
+// Flags and env vars take precedence over the values of the file.
+package configfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the config file at path, and returns the values of the flags it sets, keyed by flag name.
+// The format of the file is determined by its extension: .yaml, .yml or .toml.
+func Load(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	raw := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		// an empty file is a valid (empty) config
+		if err = decoder.Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("decode yaml config file: %w", err)
+		}
+	case ".toml":
+		if err = toml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("decode toml config file: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, expected .yaml, .yml or .toml", ext)
+	}
+
+	values := make(map[string][]string)
+	if err = flatten("", raw, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// flatten adds the values of raw to values, joining the keys of nested tables with dots.
+func flatten(prefix string, raw map[string]any, values map[string][]string) error {
+	for key, value := range raw {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flatten(name, v, values); err != nil {
+				return err
+			}
+		case []any:
+			// list values are used for slice flags, which are set once per element
+			values[name] = []string{}
+			for _, elem := range v {
+				s, err := scalarString(name, elem)
+				if err != nil {
+					return err
+				}
+				values[name] = append(values[name], s)
+			}
+		default:
+			s, err := scalarString(name, v)
+			if err != nil {
+				return err
+			}
+			values[name] = []string{s}
+		}
+	}
+	return nil
+}
+
+func scalarString(name string, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v of type %T for %s", value, value, name)
+	}
+}
+
+// Apply sets the flags of ctx that are defined in the config file passed with the --config flag,
+// unless they were already set with a flag or an env var. It is a noop when no config file is passed.
+// flags are the flags defined on ctx, which are used to reject unknown keys in the config file.
+func Apply(ctx *cli.Context, flags []cli.Flag) error {
+	path := ctx.String(FlagName)
+	if path == "" {
+		return nil
+	}
+	values, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	for _, flag := range flags {
+		for _, name := range flag.Names() {
+			known[name] = true
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	// sorted so that errors are deterministic
+	slices.Sort(names)
+	for _, name := range names {
+		if !known[name] || name == FlagName {
+			return fmt.Errorf("config file %s: unknown flag %q", path, name)
+		}
+		if ctx.IsSet(name) {
+			continue
+		}
+		for _, value := range values[name] {
+			if err = ctx.Set(name, value); err != nil {
+				return fmt.Errorf("config file %s: set flag %s: %w", path, name, err)
+			}
+		}
+	}
+	return nil
+}
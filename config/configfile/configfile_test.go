@@ -0,0 +1,126 @@
+package configfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// runApp runs an app with a few flags of each type, applying the config file, and returns the parsed context.
+func runApp(t *testing.T, args ...string) (*cli.Context, error) {
+	flags := append(CLIFlags("TEST", ""),
+		&cli.StringFlag{Name: "eigenda.v2.disperser-rpc", Aliases: []string{"eigenda-disperser-rpc"}},
+		&cli.IntFlag{Name: "port", Value: 3100},
+		&cli.BoolFlag{Name: "memstore.enabled"},
+		&cli.DurationFlag{Name: "write-timeout"},
+		&cli.Float64Flag{Name: "ratelimit.put.rate"},
+		&cli.StringSliceFlag{Name: "api-enabled"},
+		&cli.StringFlag{Name: "s3.bucket", EnvVars: []string{"TEST_S3_BUCKET"}},
+	)
+	var parsed *cli.Context
+	app := &cli.App{
+		Flags:  flags,
+		Before: func(ctx *cli.Context) error { return Apply(ctx, flags) },
+		Action: func(ctx *cli.Context) error {
+			parsed = ctx
+			return nil
+		},
+	}
+	err := app.Run(append([]string{"eigenda-proxy"}, args...))
+	return parsed, err
+}
+
+func TestApply(t *testing.T) {
+	yamlPath := writeConfigFile(t, "config.yaml", `
+eigenda:
+  v2:
+    disperser-rpc: disperser:443
+port: 4242
+memstore.enabled: true
+write-timeout: 10m
+ratelimit:
+  put:
+    rate: 0.5
+api-enabled: [admin, metrics]
+s3:
+  bucket: from-file
+`)
+	tomlPath := writeConfigFile(t, "config.toml", `
+port = 4242
+memstore.enabled = true
+write-timeout = "10m"
+api-enabled = ["admin", "metrics"]
+s3.bucket = "from-file"
+
+[eigenda.v2]
+disperser-rpc = "disperser:443"
+
+[ratelimit.put]
+rate = 0.5
+`)
+	for _, path := range []string{yamlPath, tomlPath} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			ctx, err := runApp(t, "--config", path)
+			require.NoError(t, err)
+			require.Equal(t, "disperser:443", ctx.String("eigenda.v2.disperser-rpc"))
+			require.Equal(t, 4242, ctx.Int("port"))
+			require.True(t, ctx.Bool("memstore.enabled"))
+			require.Equal(t, 10*time.Minute, ctx.Duration("write-timeout"))
+			require.InDelta(t, 0.5, ctx.Float64("ratelimit.put.rate"), 0)
+			require.Equal(t, []string{"admin", "metrics"}, ctx.StringSlice("api-enabled"))
+			require.Equal(t, "from-file", ctx.String("s3.bucket"))
+		})
+	}
+
+	t.Run("flags and env vars take precedence", func(t *testing.T) {
+		t.Setenv("TEST_S3_BUCKET", "from-env")
+		ctx, err := runApp(t, "--config", yamlPath, "--port", "1234", "--eigenda-disperser-rpc", "other:443")
+		require.NoError(t, err)
+		require.Equal(t, 1234, ctx.Int("port"))
+		require.Equal(t, "other:443", ctx.String("eigenda.v2.disperser-rpc"))
+		require.Equal(t, "from-env", ctx.String("s3.bucket"))
+	})
+
+	t.Run("config file from env var", func(t *testing.T) {
+		t.Setenv("TEST_CONFIG", tomlPath)
+		ctx, err := runApp(t)
+		require.NoError(t, err)
+		require.Equal(t, 4242, ctx.Int("port"))
+	})
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+	}{
+		{name: "unknown flag", file: "config.yaml", content: "eigenda:\n  v2:\n    disperser: x\n",
+			expected: `unknown flag "eigenda.v2.disperser"`},
+		{name: "invalid value", file: "config.yaml", content: "port: not-a-port\n", expected: "set flag port"},
+		{name: "invalid syntax", file: "config.toml", content: "port = \n", expected: "decode toml"},
+		{name: "unsupported extension", file: "config.ini", content: "port=1\n", expected: "unsupported config file"},
+		{name: "nested config", file: "config.yaml", content: "config: other.yaml\n", expected: `unknown flag "config"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runApp(t, "--config", writeConfigFile(t, tt.file, tt.content))
+			require.ErrorContains(t, err, tt.expected)
+		})
+	}
+
+	_, err := runApp(t, "--config", writeConfigFile(t, "empty.yaml", ""))
+	require.NoError(t, err)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/consts"
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
 	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/urfave/cli/v2"
@@ -32,6 +33,7 @@ var (
 	DisablePointVerificationModeFlagName = withFlagPrefix("disable-point-verification-mode")
 	ConfirmationDepthFlagName            = withFlagPrefix("confirmation-depth")
 	EthRPCURLFlagName                    = withFlagPrefix("eth-rpc")
+	EthRPCFallbackURLsFlagName           = withFlagPrefix("eth-rpc-fallbacks")
 	SvcManagerAddrFlagName               = withFlagPrefix("svc-manager-addr")
 	// Flags that are proxy specific, and not used by the eigenda-client
 	PutRetriesFlagName    = withFlagPrefix("put-retries")
@@ -141,6 +143,14 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Category: category,
 			Required: false,
 		},
+		&cli.StringSliceFlag{
+			Name: EthRPCFallbackURLsFlagName,
+			Usage: fmt.Sprintf("URLs of additional Ethereum RPC endpoints, used for cert verification when %s "+
+				"is unhealthy, and for quorum reads (see --%s).", EthRPCURLFlagName, ethrpc.QuorumSizeFlagName),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "ETH_RPC_FALLBACKS")},
+			Category: category,
+			Required: false,
+		},
 		&cli.StringFlag{
 			Name:     SvcManagerAddrFlagName,
 			Usage:    "Address of the EigenDAServiceManager contract. Required to confirm blobs landed onchain. See https://github.com/Layr-Labs/eigenlayer-middleware/?tab=readme-ov-file#current-mainnet-deployment",
@@ -201,9 +211,10 @@ func ReadClientConfigV1(ctx *cli.Context) (common.ClientConfigV1, error) {
 	}
 
 	return common.ClientConfigV1{
-		EdaClientCfg:     eigenDAClientConfig,
-		MaxBlobSizeBytes: maxBlobLengthBytes,
-		PutTries:         ctx.Int(PutRetriesFlagName),
+		EdaClientCfg:       eigenDAClientConfig,
+		EthRPCFallbackURLs: ctx.StringSlice(EthRPCFallbackURLsFlagName),
+		MaxBlobSizeBytes:   maxBlobLengthBytes,
+		PutTries:           ctx.Int(PutRetriesFlagName),
 	}, nil
 }
 
@@ -1,32 +1,53 @@
 package config
 
 import (
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
+	"github.com/Layr-Labs/eigenda-proxy/config/configfile"
 	"github.com/Layr-Labs/eigenda-proxy/config/eigendaflags"
 	eigenda_v2_flags "github.com/Layr-Labs/eigenda-proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/server/servertls"
 	"github.com/Layr-Labs/eigenda-proxy/store"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 
 	"github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/recording"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2/payments"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/gcs"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/urfave/cli/v2"
 )
 
 const (
 	EigenDAClientCategory   = "EigenDA V1 Client"
 	EigenDAV2ClientCategory = "EigenDA V2 Client"
+	PaymentsCategory        = "EigenDA V2 Payments"
 	LoggingFlagsCategory    = "Logging"
 	MetricsFlagCategory     = "Metrics"
 	MemstoreFlagsCategory   = "Memstore (for testing purposes - replaces EigenDA backend)"
 	StorageFlagsCategory    = "Storage"
 	RedisCategory           = "Redis Cache/Fallback"
 	S3Category              = "S3 Cache/Fallback"
+	GCSCategory             = "GCS Cache/Fallback"
 	VerifierCategory        = "Cert Verifier (V1 only)"
 	KZGCategory             = "KZG"
 	ProxyServerCategory     = "Proxy Server"
+	RecordingCategory       = "Record/Replay (for reproducing incidents)"
+	EthRPCCategory          = "ETH RPC Failover"
+	TracingCategory         = "Tracing"
+	TenantsCategory         = "Multi-Tenancy"
+	RateLimitCategory       = "Rate Limiting"
+	TLSCategory             = "TLS"
+	ConfigFileCategory      = "Config File"
+	BackfillCategory        = "Backfill"
+	MigrateCategory         = "Secondary Storage Migration"
 )
 
 // EnvVar prefix added in front of all environment variables accepted by the binary.
@@ -37,17 +58,27 @@ const GlobalEnvVarPrefix = "EIGENDA_PROXY"
 var Flags = []cli.Flag{}
 
 func init() {
+	Flags = append(Flags, configfile.CLIFlags(GlobalEnvVarPrefix, ConfigFileCategory)...)
 	Flags = append(Flags, server.CLIFlags(GlobalEnvVarPrefix, ProxyServerCategory)...)
+	Flags = append(Flags, servertls.CLIFlags(GlobalEnvVarPrefix, TLSCategory)...)
+	Flags = append(Flags, ratelimit.CLIFlags(GlobalEnvVarPrefix, RateLimitCategory)...)
 	Flags = append(Flags, logging.CLIFlags(GlobalEnvVarPrefix, LoggingFlagsCategory)...)
 	Flags = append(Flags, metrics.CLIFlags(GlobalEnvVarPrefix, MetricsFlagCategory)...)
+	Flags = append(Flags, tracing.CLIFlags(GlobalEnvVarPrefix, TracingCategory)...)
 	Flags = append(Flags, eigendaflags.CLIFlags(GlobalEnvVarPrefix, EigenDAClientCategory)...)
 	Flags = append(Flags, eigenda_v2_flags.CLIFlags(GlobalEnvVarPrefix, EigenDAV2ClientCategory)...)
+	Flags = append(Flags, signer.CLIFlags(GlobalEnvVarPrefix, EigenDAV2ClientCategory)...)
+	Flags = append(Flags, payments.CLIFlags(GlobalEnvVarPrefix, PaymentsCategory)...)
 	Flags = append(Flags, store.CLIFlags(GlobalEnvVarPrefix, StorageFlagsCategory)...)
 	Flags = append(Flags, redis.CLIFlags(GlobalEnvVarPrefix, RedisCategory)...)
 	Flags = append(Flags, s3.CLIFlags(GlobalEnvVarPrefix, S3Category)...)
+	Flags = append(Flags, gcs.CLIFlags(GlobalEnvVarPrefix, GCSCategory)...)
 	Flags = append(Flags, memstore.CLIFlags(GlobalEnvVarPrefix, MemstoreFlagsCategory)...)
+	Flags = append(Flags, recording.CLIFlags(GlobalEnvVarPrefix, RecordingCategory)...)
+	Flags = append(Flags, ethrpc.CLIFlags(GlobalEnvVarPrefix, EthRPCCategory)...)
 	Flags = append(Flags, verify.VerifierCLIFlags(GlobalEnvVarPrefix, VerifierCategory)...)
 	Flags = append(Flags, verify.KZGCLIFlags(GlobalEnvVarPrefix, KZGCategory)...)
+	Flags = append(Flags, tenant.CLIFlags(GlobalEnvVarPrefix, TenantsCategory)...)
 
 	Flags = append(Flags, eigendaflags.DeprecatedCLIFlags(GlobalEnvVarPrefix, EigenDAClientCategory)...)
 	Flags = append(Flags, verify.DeprecatedCLIFlags(GlobalEnvVarPrefix, VerifierCategory)...)
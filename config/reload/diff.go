@@ -0,0 +1,190 @@
+package reload
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+)
+
+// redacted replaces the values of the fields holding secrets in the diff
+const redacted = "******"
+
+const (
+	memstorePutLatencyField = "StoreBuilderConfig.MemstoreConfig.PutLatency"
+	memstoreGetLatencyField = "StoreBuilderConfig.MemstoreConfig.GetLatency"
+)
+
+// reloadableFields are the fields of the [config.AppConfig] applied by a reload.
+// A field ending with a "." matches all its subfields.
+var reloadableFields = []string{
+	"LogLevel",
+	"StoreBuilderConfig.StoreConfig.CacheTargets",
+	"StoreBuilderConfig.StoreConfig.FallbackTargets",
+	"StoreBuilderConfig.StoreConfig.WriteOnCacheMiss",
+	"StoreBuilderConfig.ClientConfigV2.RBNRecencyWindowSize",
+	"StoreBuilderConfig.RedisConfig.Eviction",
+	memstorePutLatencyField,
+	memstoreGetLatencyField,
+	"ServerConfig.RateLimit.",
+}
+
+// secretFields are the fields of the [config.AppConfig] whose values are hidden in the diff, as in
+// [builder.Config.ToString]. A field ending with a "." matches all its subfields.
+var secretFields = []string{
+	"SecretConfig.",
+	"StoreBuilderConfig.ClientConfigV1.EdaClientCfg.SignerPrivateKeyHex",
+	"StoreBuilderConfig.ClientConfigV1.EdaClientCfg.EthRpcUrl",
+	"StoreBuilderConfig.ClientConfigV1.EthRPCFallbackURLs",
+	"StoreBuilderConfig.RedisConfig.Password",
+	"StoreBuilderConfig.S3Config.AccessKeyID",
+	"StoreBuilderConfig.S3Config.AccessKeySecret",
+	"StoreBuilderConfig.GCSConfig.CredentialsJSON",
+	// the tenants hold the API key hashes and payment keys of the tenants
+	"StoreBuilderConfig.TenantsConfig.",
+}
+
+// runtimeFields are the fields of the [config.AppConfig] holding runtime state rather than config,
+// which are set when the proxy starts and ignored by the diff.
+var runtimeFields = []string{
+	"StoreBuilderConfig.PaymentsTracker",
+	"StoreBuilderConfig.MemstoreExpiryNotifier",
+	"StoreBuilderConfig.Reloader",
+	"ServerConfig.Tenants",
+	"ServerConfig.BuildInfo",
+	"ServerConfig.EnabledBackends",
+}
+
+// Change ... a config field whose value is changed by a reload
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Diff returns the fields whose value differs between the old and new configs, in declaration order.
+// The runtimeFields are ignored. Slices of structs are compared element by element, identified by their index,
+// other slices and maps are compared as a whole.
+func Diff(oldCfg config.AppConfig, newCfg config.AppConfig) []Change {
+	oldFields := flatten(reflect.ValueOf(oldCfg))
+	newFields := flatten(reflect.ValueOf(newCfg))
+
+	newValues := make(map[string]string, len(newFields))
+	for _, f := range newFields {
+		newValues[f.path] = f.value
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(oldFields))
+	for _, f := range oldFields {
+		seen[f.path] = true
+		newValue, ok := newValues[f.path]
+		if !ok {
+			// the field is nested in a pointer which is nil in the new config
+			newValue = "<nil>"
+		}
+		if newValue != f.value {
+			changes = append(changes, newChange(f.path, f.value, newValue))
+		}
+	}
+	for _, f := range newFields {
+		// a nil pointer of the new config whose fields are set in the old config was reported above
+		if !seen[f.path] && f.value != "<nil>" {
+			changes = append(changes, newChange(f.path, "<nil>", f.value))
+		}
+	}
+	return changes
+}
+
+// Reloadable returns true if the field can be changed by a reload
+func Reloadable(field string) bool {
+	return matchesAny(field, reloadableFields)
+}
+
+func newChange(field string, oldValue string, newValue string) Change {
+	if matchesAny(field, secretFields) {
+		oldValue, newValue = redacted, redacted
+	}
+	return Change{Field: field, Old: oldValue, New: newValue}
+}
+
+func matchesAny(field string, patterns []string) bool {
+	for _, p := range patterns {
+		if field == p || strings.HasSuffix(p, ".") && strings.HasPrefix(field, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// field ... a leaf of a config struct
+type field struct {
+	path  string
+	value string
+}
+
+// flatten returns the leaves of v, identified by the dot separated names of the fields leading to them
+func flatten(v reflect.Value) []field {
+	var fields []field
+	walk(v, "", &fields)
+	return fields
+}
+
+func walk(v reflect.Value, path string, fields *[]field) {
+	switch v.Kind() { //nolint:exhaustive // all other kinds are leaves
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			*fields = append(*fields, field{path: path, value: "<nil>"})
+			return
+		}
+		// the memstore config is only accessible through its getters
+		if safeConfig, ok := v.Interface().(*memconfig.SafeConfig); ok {
+			walk(reflect.ValueOf(safeConfig.Config()), path, fields)
+			return
+		}
+		walk(v.Elem(), path, fields)
+	case reflect.Struct:
+		t := v.Type()
+		leaf := true
+		for i := range t.NumField() {
+			f := t.Field(i)
+			fieldPath := joinPath(path, f.Name)
+			if !f.IsExported() || matchesAny(fieldPath, runtimeFields) {
+				continue
+			}
+			leaf = false
+			walk(v.Field(i), fieldPath, fields)
+		}
+		// structs without exported fields, such as time.Time, are compared as a whole
+		if leaf {
+			*fields = append(*fields, field{path: path, value: fmt.Sprintf("%v", v.Interface())})
+		}
+	case reflect.Slice, reflect.Array:
+		elemType := v.Type().Elem()
+		if elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			*fields = append(*fields, field{path: path, value: fmt.Sprintf("%v", v.Interface())})
+			return
+		}
+		// the fields of struct elements, such as the tenants, are compared one by one, so that the pointers
+		// they hold aren't compared by address, and their secrets are found
+		for i := range v.Len() {
+			walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	default:
+		*fields = append(*fields, field{path: path, value: fmt.Sprintf("%v", v.Interface())})
+	}
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
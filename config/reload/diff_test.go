@@ -0,0 +1,75 @@
+package reload
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	oldCfg := config.AppConfig{LogLevel: slog.LevelInfo}
+	oldCfg.StoreBuilderConfig.StoreConfig.CacheTargets = []string{"redis"}
+	oldCfg.StoreBuilderConfig.RedisConfig.Password = "old-password"
+	oldCfg.StoreBuilderConfig.MemstoreConfig = memconfig.NewSafeConfig(memconfig.Config{PutLatency: time.Second})
+	oldCfg.SecretConfig.SignerPaymentKey = "old-key"
+
+	require.Empty(t, Diff(oldCfg, oldCfg))
+
+	newCfg := oldCfg
+	newCfg.LogLevel = slog.LevelDebug
+	newCfg.StoreBuilderConfig.StoreConfig.CacheTargets = []string{"redis", "s3"}
+	newCfg.StoreBuilderConfig.RedisConfig.Password = "new-password"
+	newCfg.StoreBuilderConfig.MemstoreConfig = memconfig.NewSafeConfig(memconfig.Config{PutLatency: 2 * time.Second})
+	newCfg.SecretConfig.SignerPaymentKey = "new-key"
+	newCfg.ServerConfig.Port = 4242
+
+	require.Equal(t, []Change{
+		{Field: "LogLevel", Old: "INFO", New: "DEBUG"},
+		{Field: "StoreBuilderConfig.StoreConfig.CacheTargets", Old: "[redis]", New: "[redis s3]"},
+		{Field: "StoreBuilderConfig.MemstoreConfig.PutLatency", Old: "1s", New: "2s"},
+		{Field: "StoreBuilderConfig.RedisConfig.Password", Old: redacted, New: redacted},
+		{Field: "SecretConfig.SignerPaymentKey", Old: redacted, New: redacted},
+		{Field: "ServerConfig.Port", Old: "0", New: "4242"},
+	}, Diff(oldCfg, newCfg))
+}
+
+func TestDiffTenants(t *testing.T) {
+	oldCfg := config.AppConfig{}
+	oldCfg.StoreBuilderConfig.TenantsConfig.Tenants = []tenant.Tenant{{
+		Name:             "rollup-a",
+		Signer:           &signer.Config{Type: signer.TypeLocal},
+		SignerPaymentKey: "old-key",
+	}}
+	// runtime state isn't diffed
+	oldCfg.ServerConfig.BuildInfo = server.BuildInfo{Version: "v1"}
+
+	newCfg := oldCfg
+	newCfg.StoreBuilderConfig.TenantsConfig.Tenants = slices.Clone(oldCfg.StoreBuilderConfig.TenantsConfig.Tenants)
+	// an equal signer at another address is not a change
+	newCfg.StoreBuilderConfig.TenantsConfig.Tenants[0].Signer = &signer.Config{Type: signer.TypeLocal}
+	newCfg.ServerConfig.BuildInfo = server.BuildInfo{Version: "v2"}
+	require.Empty(t, Diff(oldCfg, newCfg))
+
+	newCfg.StoreBuilderConfig.TenantsConfig.Tenants[0].SignerPaymentKey = "new-key"
+	require.Equal(t, []Change{
+		{Field: "StoreBuilderConfig.TenantsConfig.Tenants[0].SignerPaymentKey", Old: redacted, New: redacted},
+	}, Diff(oldCfg, newCfg))
+	require.False(t, Reloadable("StoreBuilderConfig.TenantsConfig.Tenants[0].SignerPaymentKey"))
+}
+
+func TestReloadable(t *testing.T) {
+	require.True(t, Reloadable("LogLevel"))
+	require.True(t, Reloadable("StoreBuilderConfig.StoreConfig.FallbackTargets"))
+	require.True(t, Reloadable("ServerConfig.RateLimit.Put.Rate"))
+	require.False(t, Reloadable("ServerConfig.RateLimitX"))
+	require.False(t, Reloadable("ServerConfig.Port"))
+	require.False(t, Reloadable("StoreBuilderConfig.MemstoreConfig.MaxBlobSizeBytes"))
+}
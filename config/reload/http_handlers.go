@@ -0,0 +1,71 @@
+package reload
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+)
+
+// HandlerHTTP serves the effective config and config reloads.
+// It adds routes to the proxy's main router (to be served on same port as the main proxy routes):
+// - GET /admin/config: returns the config currently applied, with its secrets hidden
+// - POST /admin/reload: reloads the config, and returns the changed fields
+type HandlerHTTP struct {
+	log      logging.Logger
+	reloader *Reloader
+}
+
+func NewHandlerHTTP(log logging.Logger, reloader *Reloader) HandlerHTTP {
+	return HandlerHTTP{
+		log:      log,
+		reloader: reloader,
+	}
+}
+
+func (api HandlerHTTP) RegisterConfigHandlers(r *mux.Router) {
+	admin := r.PathPrefix("/admin").Subrouter()
+	admin.HandleFunc("/config", api.handleGetConfig).Methods("GET")
+	admin.HandleFunc("/reload", api.handleReload).Methods("POST")
+}
+
+func (api HandlerHTTP) handleGetConfig(w http.ResponseWriter, _ *http.Request) {
+	// the secrets are hidden by config.AppConfig.MarshalJSON
+	configJSON, err := json.Marshal(api.reloader.Current())
+	if err != nil {
+		api.log.Error("failed to encode config", "error", err)
+		http.Error(w, "failed to encode config", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = w.Write(configJSON); err != nil {
+		api.log.Error("failed to write config", "error", err)
+	}
+}
+
+func (api HandlerHTTP) handleReload(w http.ResponseWriter, _ *http.Request) {
+	changes, err := api.reloader.Reload()
+	if err != nil {
+		api.log.Error("Failed to reload config", "err", err)
+		var unsafeErr *UnsafeChangeError
+		if errors.As(err, &unsafeErr) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if changes == nil {
+		changes = []Change{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if encodeErr := json.NewEncoder(w).Encode(struct {
+		Changes []Change `json:"changes"`
+	}{Changes: changes}); encodeErr != nil {
+		api.log.Error("failed to encode reloaded config changes", "error", encodeErr)
+	}
+}
@@ -0,0 +1,179 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// UnsafeChangeError is returned when a reloaded config changes fields which are only read at startup.
+type UnsafeChangeError struct {
+	Fields []string
+}
+
+func (e *UnsafeChangeError) Error() string {
+	return fmt.Sprintf("config fields can only be changed by restarting the proxy: %s", strings.Join(e.Fields, ", "))
+}
+
+// RateLimiter ... is the server whose cert request limits are updated by reloads
+type RateLimiter interface {
+	SetRateLimits(cfg ratelimit.Config)
+}
+
+// Reloader ... re-reads the config, and applies the changes of the fields which are safe to change at runtime:
+// the log level, the secondary targets and their order, WriteOnCacheMiss, the redis eviction time, the rate limits,
+// the RBN recency window and the memstore latencies. Reloads changing any other field are rejected as a whole.
+type Reloader struct {
+	log logging.Logger
+	// read reads the config the same way as at startup
+	read     func() (config.AppConfig, error)
+	logLevel *slog.LevelVar
+	limiter  RateLimiter
+	// memstore is the memstore config used by the stores, which is also changed by PATCH /memstore/config.
+	// Nil when memstore is disabled.
+	memstore *memconfig.SafeConfig
+
+	mu sync.Mutex
+	// current holds a copy of the memstore config rather than the one used by the stores, so that its changes
+	// made through PATCH /memstore/config are neither seen as config changes nor reverted by reloads.
+	current config.AppConfig
+}
+
+// NewReloader ... constructor. current is the config the proxy was started with, whose StoreBuilderConfig.Reloader
+// must have been used to build the stores.
+func NewReloader(
+	log logging.Logger,
+	current config.AppConfig,
+	read func() (config.AppConfig, error),
+	logLevel *slog.LevelVar,
+	limiter RateLimiter,
+) *Reloader {
+	memstore := current.StoreBuilderConfig.MemstoreConfig
+	if memstore != nil {
+		current.StoreBuilderConfig.MemstoreConfig = memconfig.NewSafeConfig(memstore.Config())
+	}
+	return &Reloader{
+		log:      log,
+		read:     read,
+		logLevel: logLevel,
+		limiter:  limiter,
+		memstore: memstore,
+		current:  current,
+	}
+}
+
+// Reload re-reads the config and applies its changes, which are logged and returned. Nothing is applied if the
+// config is invalid, or if it changes fields which can't be changed at runtime, in which case
+// an [UnsafeChangeError] is returned.
+func (r *Reloader) Reload() ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := r.read()
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if checkErr := cfg.Check(); checkErr != nil {
+		return nil, fmt.Errorf("check config: %w", checkErr)
+	}
+
+	changes := Diff(r.current, cfg)
+	var unsafe []string
+	for _, change := range changes {
+		if !Reloadable(change.Field) {
+			unsafe = append(unsafe, change.Field)
+		}
+	}
+	if len(unsafe) > 0 {
+		return nil, &UnsafeChangeError{Fields: unsafe}
+	}
+	if len(changes) == 0 {
+		r.log.Info("Reloaded config, nothing changed")
+		return changes, nil
+	}
+
+	// the stores are updated first, since they are the only ones which can reject the config
+	if stores := r.current.StoreBuilderConfig.Reloader; stores != nil {
+		if reloadErr := stores.Reload(cfg.StoreBuilderConfig); reloadErr != nil {
+			return nil, fmt.Errorf("reload stores: %w", reloadErr)
+		}
+	}
+	r.limiter.SetRateLimits(cfg.ServerConfig.RateLimit)
+	r.logLevel.Set(cfg.LogLevel)
+	r.applyMemstoreLatencies(changes, cfg)
+
+	r.current.LogLevel = cfg.LogLevel
+	r.current.ServerConfig.RateLimit = cfg.ServerConfig.RateLimit
+	current := &r.current.StoreBuilderConfig
+	current.StoreConfig.CacheTargets = cfg.StoreBuilderConfig.StoreConfig.CacheTargets
+	current.StoreConfig.FallbackTargets = cfg.StoreBuilderConfig.StoreConfig.FallbackTargets
+	current.StoreConfig.WriteOnCacheMiss = cfg.StoreBuilderConfig.StoreConfig.WriteOnCacheMiss
+	current.ClientConfigV2.RBNRecencyWindowSize = cfg.StoreBuilderConfig.ClientConfigV2.RBNRecencyWindowSize
+	current.RedisConfig.Eviction = cfg.StoreBuilderConfig.RedisConfig.Eviction
+
+	for _, change := range changes {
+		r.log.Info("Reloaded config field", "field", change.Field, "old", change.Old, "new", change.New)
+	}
+	return changes, nil
+}
+
+// applyMemstoreLatencies applies the memstore latencies changed by the reloaded config. The latencies which
+// weren't changed keep the value they may have been set to by PATCH /memstore/config.
+func (r *Reloader) applyMemstoreLatencies(changes []Change, cfg config.AppConfig) {
+	if r.memstore == nil || cfg.StoreBuilderConfig.MemstoreConfig == nil {
+		return
+	}
+	current := r.current.StoreBuilderConfig.MemstoreConfig
+	reloaded := cfg.StoreBuilderConfig.MemstoreConfig
+	for _, change := range changes {
+		switch change.Field {
+		case memstorePutLatencyField:
+			r.memstore.SetLatencyPUTRoute(reloaded.LatencyPUTRoute())
+			current.SetLatencyPUTRoute(reloaded.LatencyPUTRoute())
+		case memstoreGetLatencyField:
+			r.memstore.SetLatencyGETRoute(reloaded.LatencyGETRoute())
+			current.SetLatencyGETRoute(reloaded.LatencyGETRoute())
+		}
+	}
+}
+
+// Current returns the config currently applied, including the changes made by reloads and
+// by PATCH /memstore/config.
+func (r *Reloader) Current() config.AppConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.current
+	if r.memstore != nil {
+		current.StoreBuilderConfig.MemstoreConfig = r.memstore
+	}
+	return current
+}
+
+// ReloadOnSIGHUP reloads the config every time the process receives a SIGHUP, until ctx is done.
+func (r *Reloader) ReloadOnSIGHUP(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-sighup:
+			r.log.Info("Received SIGHUP, reloading config")
+			if _, err := r.Reload(); err != nil {
+				r.log.Error("Failed to reload config", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package reload
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/config"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+type noopRateLimiter struct{}
+
+func (noopRateLimiter) SetRateLimits(ratelimit.Config) {}
+
+// memstoreAppConfig returns a valid config using memstore, whose memstore config is a new SafeConfig built from
+// memstoreCfg, the same way as when reading the config from the flags
+func memstoreAppConfig(memstoreCfg memconfig.Config) config.AppConfig {
+	cfg := config.AppConfig{LogLevel: slog.LevelInfo}
+	cfg.StoreBuilderConfig.StoreConfig.BackendsToEnable = []common.EigenDABackend{common.V2EigenDABackend}
+	cfg.StoreBuilderConfig.StoreConfig.DispersalBackend = common.V2EigenDABackend
+	cfg.StoreBuilderConfig.MemstoreEnabled = true
+	cfg.StoreBuilderConfig.MemstoreConfig = memconfig.NewSafeConfig(memstoreCfg)
+	return cfg
+}
+
+func TestReloadAfterMemstorePatch(t *testing.T) {
+	fileCfg := memconfig.Config{
+		MaxBlobSizeBytes: 1024,
+		PutLatency:       time.Second,
+		GetLatency:       time.Second,
+		EvictionPolicy:   memconfig.EvictionPolicyFIFO,
+	}
+	startup := memstoreAppConfig(fileCfg)
+	live := startup.StoreBuilderConfig.MemstoreConfig
+
+	reloader := NewReloader(
+		logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{}),
+		startup,
+		func() (config.AppConfig, error) { return memstoreAppConfig(fileCfg), nil },
+		new(slog.LevelVar),
+		noopRateLimiter{},
+	)
+
+	// the same changes as a PATCH /memstore/config
+	live.SetLatencyGETRoute(5 * time.Second)
+	live.SetMaxEntries(10)
+	live.SetEvictionPolicy(memconfig.EvictionPolicyLRU)
+
+	changes, err := reloader.Reload()
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, 5*time.Second, live.LatencyGETRoute())
+	require.Equal(t, uint64(10), live.MaxEntries())
+	require.Equal(t, memconfig.EvictionPolicyLRU, live.EvictionPolicy())
+
+	// only the latency changed in the file is applied, the PATCHed one is kept
+	fileCfg.PutLatency = 2 * time.Second
+	changes, err = reloader.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []Change{{Field: memstorePutLatencyField, Old: "1s", New: "2s"}}, changes)
+	require.Equal(t, 2*time.Second, live.LatencyPUTRoute())
+	require.Equal(t, 5*time.Second, live.LatencyGETRoute())
+
+	// the current config reports the live memstore config
+	current := reloader.Current().StoreBuilderConfig.MemstoreConfig
+	require.Equal(t, 5*time.Second, current.LatencyGETRoute())
+	require.Equal(t, uint64(10), current.MaxEntries())
+
+	// the memstore fields which can't be PATCHed are still rejected
+	fileCfg.MaxBlobSizeBytes = 2048
+	_, err = reloader.Reload()
+	var unsafeErr *UnsafeChangeError
+	require.ErrorAs(t, err, &unsafeErr)
+	require.Equal(t, []string{"StoreBuilderConfig.MemstoreConfig.MaxBlobSizeBytes"}, unsafeErr.Fields)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
 	"github.com/Layr-Labs/eigenda-proxy/config/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	clients_v2 "github.com/Layr-Labs/eigenda/api/clients/v2"
@@ -29,14 +31,19 @@ var (
 	)
 	ServiceManagerAddrFlagName        = withFlagPrefix("service-manager-addr")
 	BLSOperatorStateRetrieverFlagName = withFlagPrefix("bls-operator-state-retriever-addr")
+	RelayRegistryAddrFlagName         = withFlagPrefix("relay-registry-addr")
 	RelayTimeoutFlagName              = withFlagPrefix("relay-timeout")
 	ValidatorTimeoutFlagName          = withFlagPrefix("validator-timeout")
 	ContractCallTimeoutFlagName       = withFlagPrefix("contract-call-timeout")
 	BlobParamsVersionFlagName         = withFlagPrefix("blob-version")
 	EthRPCURLFlagName                 = withFlagPrefix("eth-rpc")
+	EthRPCFallbackURLsFlagName        = withFlagPrefix("eth-rpc-fallbacks")
 	MaxBlobLengthFlagName             = withFlagPrefix("max-blob-length")
 	NetworkFlagName                   = withFlagPrefix("network")
+	NetworksFileFlagName              = withFlagPrefix("networks-file")
 	RBNRecencyWindowSizeFlagName      = withFlagPrefix("rbn-recency-window-size")
+	CertVerificationCacheSizeFlagName = withFlagPrefix("cert-verification-cache-size")
+	CertVerificationCacheTTLFlagName  = withFlagPrefix("cert-verification-cache-ttl")
 )
 
 func withFlagPrefix(s string) string {
@@ -64,8 +71,10 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Category: category,
 		},
 		&cli.StringFlag{
-			Name:     SignerPaymentKeyHexFlagName,
-			Usage:    "Hex-encoded signer private key. Used for authorizing payments with EigenDA disperser. Should not be associated with an Ethereum address holding any funds.",
+			Name: SignerPaymentKeyHexFlagName,
+			Usage: "Hex-encoded signer private key. Used for authorizing payments with EigenDA disperser " +
+				"when using the local signer (see --" + signer.TypeFlagName + "). " +
+				"Should not be associated with an Ethereum address holding any funds.",
 			EnvVars:  []string{withEnvPrefix(envPrefix, "SIGNER_PRIVATE_KEY_HEX")},
 			Category: category,
 		},
@@ -84,6 +93,14 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Category: category,
 			Required: false,
 		},
+		&cli.StringSliceFlag{
+			Name: EthRPCFallbackURLsFlagName,
+			Usage: fmt.Sprintf("URLs of additional Ethereum RPC endpoints, used whenever %s is unhealthy, "+
+				"and for cert verification quorum reads (see --%s).", EthRPCURLFlagName, ethrpc.QuorumSizeFlagName),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "ETH_RPC_FALLBACKS")},
+			Category: category,
+			Required: false,
+		},
 		&cli.IntFlag{
 			Name: PutRetriesFlagName,
 			Usage: "Total number of times to try blob dispersals before serving an error response." +
@@ -111,7 +128,8 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 		&cli.StringFlag{
 			Name: CertVerifierRouterOrImmutableVerifierAddrFlagName,
 			Usage: "Address of either the EigenDACertVerifierRouter or immutable EigenDACertVerifier contract. " +
-				"Required for performing eth_calls to verify EigenDA certificates.",
+				"Required for performing eth_calls to verify EigenDA certificates. " +
+				"Defaults to the router of the network preset (see --" + NetworkFlagName + ").",
 			EnvVars:  []string{withEnvPrefix(envPrefix, "CERT_VERIFIER_ROUTER_OR_IMMUTABLE_VERIFIER_ADDR")},
 			Category: category,
 			Required: false,
@@ -130,6 +148,14 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Category: category,
 			Required: false,
 		},
+		&cli.StringFlag{
+			Name: RelayRegistryAddrFlagName,
+			Usage: "Address of the EigenDA relay registry contract. Optional, as the relay registry is read from " +
+				"the service manager: when set, startup fails if the two don't match.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "RELAY_REGISTRY_ADDR")},
+			Category: category,
+			Required: false,
+		},
 		&cli.DurationFlag{
 			Name:     ContractCallTimeoutFlagName,
 			Usage:    "Timeout used when performing smart contract call operation (i.e, eth_call).",
@@ -184,20 +210,32 @@ slightly exceeds 1GB.`,
 		&cli.StringFlag{
 			Name: NetworkFlagName,
 			Usage: fmt.Sprintf(`The EigenDA network that is being used. This is an optional flag, to configure
-default values for %s, %s, and %s. If all of these fields are explicitly configured, the
-network flag may be omitted. If some or all of these fields are configured, and the network
+default values for %s, %s, %s, %s, and %s. If all of these fields are explicitly configured,
+the network flag may be omitted. If some or all of these fields are configured, and the network
 is also configured, then the explicitly defined field values will take precedence. Permitted
-EigenDANetwork values include %s, %s, & %s.`,
+EigenDANetwork values include %s, %s, %s, %s, & the networks defined in %s.`,
 				DisperserFlagName,
+				CertVerifierRouterOrImmutableVerifierAddrFlagName,
 				ServiceManagerAddrFlagName,
 				BLSOperatorStateRetrieverFlagName,
+				RelayRegistryAddrFlagName,
+				common.MainnetEigenDANetwork,
 				common.HoleskyTestnetEigenDANetwork,
 				common.HoleskyPreprodEigenDANetwork,
 				common.SepoliaTestnetEigenDANetwork,
+				NetworksFileFlagName,
 			),
 			EnvVars:  []string{withEnvPrefix(envPrefix, "NETWORK")},
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: NetworksFileFlagName,
+			Usage: fmt.Sprintf("Path to a JSON file defining custom EigenDA networks, such as private devnets, "+
+				"which can then be selected with %s. See the README for the format. Only read at startup.",
+				NetworkFlagName),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "NETWORKS_FILE")},
+			Category: category,
+		},
 		&cli.Uint64Flag{
 			Name: RBNRecencyWindowSizeFlagName,
 			Usage: `Allowed distance (in L1 blocks) between the eigenDA cert's reference 
@@ -209,50 +247,77 @@ This check is optional and will be skipped when set to 0.`,
 			EnvVars:  []string{withEnvPrefix(envPrefix, "RBN_RECENCY_WINDOW_SIZE")},
 			Category: category,
 		},
+		&cli.IntFlag{
+			Name: CertVerificationCacheSizeFlagName,
+			Usage: "Maximum number of cert verification results cached, to avoid repeating CertVerifier.checkDACert " +
+				"eth-calls for certs that were already verified. Setting to (0) disables the cache.",
+			Value:    10_000,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "CERT_VERIFICATION_CACHE_SIZE")},
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     CertVerificationCacheTTLFlagName,
+			Usage:    "Duration for which a cached cert verification result is valid. Setting to (0) results in no expiration.",
+			Value:    time.Hour,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "CERT_VERIFICATION_CACHE_TTL")},
+			Category: category,
+		},
 	}
 }
 
-func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
-	disperserConfig, err := readDisperserCfg(ctx)
-	if err != nil {
-		return common.ClientConfigV2{}, fmt.Errorf("read disperser config: %w", err)
+// LoadNetworks loads the custom networks of the networks file, if any. The networks are global, so they are only
+// loaded once at startup, before the config is read: a reload doesn't publish networks of a config it may reject.
+func LoadNetworks(ctx *cli.Context) error {
+	networksFile := ctx.String(NetworksFileFlagName)
+	if networksFile == "" {
+		return nil
 	}
-
-	maxBlobLengthFlagContents := ctx.String(MaxBlobLengthFlagName)
-	maxBlobLengthBytes, err := eigendaflags.ParseMaxBlobLength(maxBlobLengthFlagContents)
-	if err != nil {
-		return common.ClientConfigV2{}, fmt.Errorf(
-			"parse max blob length flag \"%v\": %w", maxBlobLengthFlagContents, err)
+	if err := common.LoadEigenDANetworks(networksFile); err != nil {
+		return fmt.Errorf("load networks file: %w", err)
 	}
+	return nil
+}
 
+// ReadClientConfigV2 ... reads the V2 client config. The custom networks must be loaded with LoadNetworks beforehand.
+func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
 	var eigenDANetwork common.EigenDANetwork
-	networkString := ctx.String(NetworkFlagName)
-	if networkString != "" {
-		eigenDANetwork, err = common.EigenDANetworkFromString(networkString)
+	// the zero preset, which has no values, is used when no network is configured
+	var preset common.EigenDANetworkPreset
+	if networkString := ctx.String(NetworkFlagName); networkString != "" {
+		network, err := common.EigenDANetworkFromString(networkString)
 		if err != nil {
 			return common.ClientConfigV2{}, fmt.Errorf("parse eigenDANetwork: %w", err)
 		}
-	}
-
-	serviceManagerAddress := ctx.String(ServiceManagerAddrFlagName)
-	if serviceManagerAddress == "" {
-		serviceManagerAddress, err = eigenDANetwork.GetServiceManagerAddress()
+		preset, err = network.Preset()
 		if err != nil {
-			return common.ClientConfigV2{}, fmt.Errorf(
-				"service manager address wasn't specified, and failed to get it from the specified network: %w", err)
+			return common.ClientConfigV2{}, fmt.Errorf("get preset of network %s: %w", network, err)
 		}
+		eigenDANetwork = network
 	}
 
-	blsOperatorStateRetrieverAddress := ctx.String(BLSOperatorStateRetrieverFlagName)
-	if blsOperatorStateRetrieverAddress == "" {
-		blsOperatorStateRetrieverAddress, err = eigenDANetwork.GetBLSOperatorStateRetrieverAddress()
-		if err != nil {
-			return common.ClientConfigV2{}, fmt.Errorf(
-				`BLS operator state retriever address wasn't specified, and failed to get it from the
-							specified network : %w`, err)
-		}
+	sources := make(map[string]common.ConfigSource)
+	disperserConfig, err := readDisperserCfg(
+		ctx, readWithPreset(ctx, DisperserFlagName, common.DisperserAddressName, preset.DisperserAddress, sources))
+	if err != nil {
+		return common.ClientConfigV2{}, fmt.Errorf("read disperser config: %w", err)
+	}
+
+	maxBlobLengthFlagContents := ctx.String(MaxBlobLengthFlagName)
+	maxBlobLengthBytes, err := eigendaflags.ParseMaxBlobLength(maxBlobLengthFlagContents)
+	if err != nil {
+		return common.ClientConfigV2{}, fmt.Errorf(
+			"parse max blob length flag \"%v\": %w", maxBlobLengthFlagContents, err)
 	}
 
+	certVerifierRouterAddress := readWithPreset(ctx, CertVerifierRouterOrImmutableVerifierAddrFlagName,
+		common.CertVerifierRouterAddressName, preset.CertVerifierRouterAddress, sources)
+	serviceManagerAddress := readWithPreset(ctx, ServiceManagerAddrFlagName,
+		common.ServiceManagerAddressName, preset.ServiceManagerAddress, sources)
+	blsOperatorStateRetrieverAddress := readWithPreset(ctx, BLSOperatorStateRetrieverFlagName,
+		common.BLSOperatorStateRetrieverAddressName, preset.BLSOperatorStateRetrieverAddress, sources)
+	relayRegistryAddress := readWithPreset(ctx, RelayRegistryAddrFlagName,
+		common.RelayRegistryAddressName, preset.RelayRegistryAddress, sources)
+
 	return common.ClientConfigV2{
 		DisperserClientCfg:           disperserConfig,
 		PayloadDisperserCfg:          readPayloadDisperserCfg(ctx),
@@ -269,17 +334,39 @@ func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
 			common.ValidatorRetrieverType,
 		},
 		BLSOperatorStateRetrieverAddr:      blsOperatorStateRetrieverAddress,
-		EigenDACertVerifierOrRouterAddress: ctx.String(CertVerifierRouterOrImmutableVerifierAddrFlagName),
+		EigenDACertVerifierOrRouterAddress: certVerifierRouterAddress,
 		EigenDAServiceManagerAddr:          serviceManagerAddress,
+		RelayRegistryAddr:                  relayRegistryAddress,
 		RBNRecencyWindowSize:               ctx.Uint64(RBNRecencyWindowSizeFlagName),
+		CertVerificationCacheSize:          ctx.Int(CertVerificationCacheSizeFlagName),
+		CertVerificationCacheTTL:           ctx.Duration(CertVerificationCacheTTLFlagName),
+		EthRPC:                             ethrpc.ReadConfig(ctx),
+		Signer:                             signer.ReadConfig(ctx),
 		EigenDANetwork:                     eigenDANetwork,
+		Sources:                            sources,
 	}, nil
 }
 
+// readWithPreset returns the value of the flag when it is set, and the value of the network preset otherwise,
+// recording the source of the returned value in sources under name.
+func readWithPreset(
+	ctx *cli.Context, flagName string, name string, presetValue string, sources map[string]common.ConfigSource,
+) string {
+	if value := ctx.String(flagName); value != "" {
+		sources[name] = common.ConfigSourceExplicit
+		return value
+	}
+	if presetValue != "" {
+		sources[name] = common.ConfigSourceNetworkPreset
+	}
+	return presetValue
+}
+
 func ReadSecretConfigV2(ctx *cli.Context) common.SecretConfigV2 {
 	return common.SecretConfigV2{
-		SignerPaymentKey: ctx.String(SignerPaymentKeyHexFlagName),
-		EthRPCURL:        ctx.String(EthRPCURLFlagName),
+		SignerPaymentKey:   ctx.String(SignerPaymentKeyHexFlagName),
+		EthRPCURL:          ctx.String(EthRPCURLFlagName),
+		EthRPCFallbackURLs: ctx.StringSlice(EthRPCFallbackURLsFlagName),
 	}
 }
 
@@ -311,25 +398,11 @@ func readPayloadDisperserCfg(ctx *cli.Context) payloaddispersal.PayloadDisperser
 	}
 }
 
-func readDisperserCfg(ctx *cli.Context) (clients_v2.DisperserClientConfig, error) {
-	disperserAddressString := ctx.String(DisperserFlagName)
-
+// readDisperserCfg reads the disperser config for the disperser at the given "<hostname>:<port>" address.
+// An empty address results in an empty hostname and port, which are rejected by ClientConfigV2.Check.
+func readDisperserCfg(ctx *cli.Context, disperserAddressString string) (clients_v2.DisperserClientConfig, error) {
 	if disperserAddressString == "" {
-		networkString := ctx.String(NetworkFlagName)
-		if networkString == "" {
-			return clients_v2.DisperserClientConfig{},
-				fmt.Errorf("either disperser address or EigenDANetwork must be specified")
-		}
-
-		eigenDANetwork, err := common.EigenDANetworkFromString(networkString)
-		if err != nil {
-			return clients_v2.DisperserClientConfig{}, fmt.Errorf("parse eigenDANetwork: %w", err)
-		}
-
-		disperserAddressString, err = eigenDANetwork.GetDisperserAddress()
-		if err != nil {
-			return clients_v2.DisperserClientConfig{}, fmt.Errorf("get disperser address: %w", err)
-		}
+		return clients_v2.DisperserClientConfig{UseSecureGrpcFlag: !ctx.Bool(DisableTLSFlagName)}, nil
 	}
 
 	hostStr, portStr, err := net.SplitHostPort(disperserAddressString)
@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// RequestIDLogKey is the key under which the request ID is attached to log lines.
+const RequestIDLogKey = "request_id"
+
+// requestIDKey is the context key of the request ID.
+// A custom type is used to avoid collisions with other context keys.
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying requestID,
+// which is then attached to every log line emitted by a logger obtained via [FromContext].
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "" if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok {
+		return ""
+	}
+	return requestID
+}
+
+// FromContext returns log annotated with the request ID carried by ctx, if any.
+// Code serving a request should log through it so that all its log lines can be correlated.
+func FromContext(ctx context.Context, log logging.Logger) logging.Logger {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return log
+	}
+	return log.With(RequestIDLogKey, requestID)
+}
@@ -163,17 +163,26 @@ func ReadLoggerCLIConfig(ctx *cli.Context) (*LoggerConfig, error) {
 		}
 		cfg.OutputWriter = io.MultiWriter(os.Stdout, f)
 	}
-	logLevel := ctx.String(common.PrefixFlag(FlagPrefix, LevelFlagName))
-	var level slog.Level
-	err := level.UnmarshalText([]byte(logLevel))
+	level, err := ReadLogLevel(ctx)
 	if err != nil {
-		panic("failed to parse log level " + logLevel)
+		return nil, err
 	}
 	cfg.HandlerOpts.Level = level
 
 	return &cfg, nil
 }
 
+// ReadLogLevel parses the lowest log level that will be output from the provided flags or environment variables.
+func ReadLogLevel(ctx *cli.Context) (slog.Level, error) {
+	logLevel := ctx.String(common.PrefixFlag(FlagPrefix, LevelFlagName))
+	var level slog.Level
+	err := level.UnmarshalText([]byte(logLevel))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse log level %s: %w", logLevel, err)
+	}
+	return level, nil
+}
+
 func NewLogger(cfg LoggerConfig) (logging.Logger, error) {
 	if cfg.Format == JSONLogFormat {
 		return logging.NewJsonSLogger(cfg.OutputWriter, &cfg.HandlerOpts), nil
@@ -122,6 +122,15 @@ func (n *EmulatedMetricer) RecordRPCServerRequest(method string) func(status, mo
 	}
 }
 
+// RecordHTTPRouteRequest ... noop
+func (n *EmulatedMetricer) RecordHTTPRouteRequest(_ string, _ string) func(status string) {
+	return func(string) {}
+}
+
+// RecordRateLimited ... noop
+func (n *EmulatedMetricer) RecordRateLimited(_ string, _ string) {
+}
+
 // RecordSecondaryRequest ... updates secondary insertion counter associated with label fingerprint
 func (n *EmulatedMetricer) RecordSecondaryRequest(x string, y string) func(status string) {
 	return func(z string) {
@@ -131,3 +140,54 @@ func (n *EmulatedMetricer) RecordSecondaryRequest(x string, y string) func(statu
 		}
 	}
 }
+
+// RecordMemstoreSize ... noop
+func (n *EmulatedMetricer) RecordMemstoreSize(_ string, _ int, _ uint64) {
+}
+
+// RecordMemstoreEviction ... noop
+func (n *EmulatedMetricer) RecordMemstoreEviction(_ string, _ string) {
+}
+
+// RecordCertVerificationCacheLookup ... noop
+func (n *EmulatedMetricer) RecordCertVerificationCacheLookup(_ bool, _ int) {
+}
+
+// RecordDispersalStage ... noop
+func (n *EmulatedMetricer) RecordDispersalStage(_ string, _ string) func() {
+	return func() {}
+}
+
+// RecordDispersalRetry ... noop
+func (n *EmulatedMetricer) RecordDispersalRetry(_ string) {
+}
+
+// RecordRetrieval ... noop
+func (n *EmulatedMetricer) RecordRetrieval(_ string, _ string) func(status string) {
+	return func(string) {}
+}
+
+// RecordVerificationStage ... noop
+func (n *EmulatedMetricer) RecordVerificationStage(_ string, _ string) func() {
+	return func() {}
+}
+
+// RecordPayloadSize ... noop
+func (n *EmulatedMetricer) RecordPayloadSize(_ string, _ string, _ int) {
+}
+
+// RecordReservationUsage ... noop
+func (n *EmulatedMetricer) RecordReservationUsage(_ uint64, _ uint64) {
+}
+
+// RecordOnDemandBalance ... noop
+func (n *EmulatedMetricer) RecordOnDemandBalance(_ float64, _ float64) {
+}
+
+// RecordTenantRequest ... noop
+func (n *EmulatedMetricer) RecordTenantRequest(_ string, _ string, _ string, _ string) {
+}
+
+// RecordTenantPayloadBytes ... noop
+func (n *EmulatedMetricer) RecordTenantPayloadBytes(_ string, _ string, _ int) {
+}
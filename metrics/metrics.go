@@ -14,9 +14,37 @@ import (
 )
 
 const (
-	namespace           = "eigenda_proxy"
-	httpServerSubsystem = "http_server"
-	secondarySubsystem  = "secondary"
+	namespace                      = "eigenda_proxy"
+	httpServerSubsystem            = "http_server"
+	secondarySubsystem             = "secondary"
+	memstoreSubsystem              = "memstore"
+	certVerificationCacheSubsystem = "cert_verification_cache"
+	dispersalSubsystem             = "dispersal"
+	retrievalSubsystem             = "retrieval"
+	verificationSubsystem          = "verification"
+	paymentsSubsystem              = "payments"
+	tenantSubsystem                = "tenant"
+)
+
+// Stages of a dispersal, used as the stage label of [Metricer.RecordDispersalStage].
+// The EigenDA clients send the blob, poll for its confirmation and (for V2) build the cert in a single call,
+// so sending and confirmation are not timed separately: the send_and_confirm stage covers all of them.
+// The cert_serialize stage only covers the serialization of the cert returned to the client.
+const (
+	DispersalStageSendAndConfirm = "send_and_confirm"
+	DispersalStageCertSerialize  = "cert_serialize"
+)
+
+// Statuses of a payload retrieval, passed to the function returned by [Metricer.RecordRetrieval].
+const (
+	RetrievalStatusSuccess = "success"
+	RetrievalStatusFailed  = "failed"
+)
+
+// Stages of a cert verification, used as the stage label of [Metricer.RecordVerificationStage].
+const (
+	VerificationStageEthCall       = "eth_call"
+	VerificationStageKZGCommitment = "kzg_commitment"
 )
 
 // Config ... Metrics server configuration
@@ -32,8 +60,27 @@ type Metricer interface {
 	RecordUp()
 
 	RecordRPCServerRequest(method string) func(status string, mode string, ver string)
+	RecordHTTPRouteRequest(method string, route string) func(status string)
+	RecordRateLimited(method string, limit string)
 	RecordSecondaryRequest(bt string, method string) func(status string)
 
+	RecordMemstoreSize(bt string, entries int, sizeBytes uint64)
+	RecordMemstoreEviction(bt string, reason string)
+
+	RecordCertVerificationCacheLookup(hit bool, savedRPCCalls int)
+
+	RecordDispersalStage(bt string, stage string) func()
+	RecordDispersalRetry(bt string)
+	RecordRetrieval(bt string, retriever string) func(status string)
+	RecordVerificationStage(bt string, stage string) func()
+	RecordPayloadSize(method string, mode string, sizeBytes int)
+
+	RecordReservationUsage(usedSymbols uint64, capacitySymbols uint64)
+	RecordOnDemandBalance(remainingWei float64, secondsUntilExhaustion float64)
+
+	RecordTenantRequest(tenant string, method string, mode string, status string)
+	RecordTenantPayloadBytes(tenant string, method string, sizeBytes int)
+
 	Document() []metrics.DocumentedMetric
 }
 
@@ -46,11 +93,42 @@ type Metrics struct {
 	HTTPServerRequestsTotal          *prometheus.CounterVec
 	HTTPServerBadRequestHeader       *prometheus.CounterVec
 	HTTPServerRequestDurationSeconds *prometheus.HistogramVec
+	HTTPServerPayloadSizeBytes       *prometheus.HistogramVec
+	// metrics of non-cert routes (health, admin, memstore...)
+	HTTPServerRouteRequestsTotal          *prometheus.CounterVec
+	HTTPServerRouteRequestDurationSeconds *prometheus.HistogramVec
+	HTTPServerRateLimitedTotal            *prometheus.CounterVec
 
 	// secondary metrics
 	SecondaryRequestsTotal      *prometheus.CounterVec
 	SecondaryRequestDurationSec *prometheus.HistogramVec
 
+	// memstore metrics
+	MemstoreEntries        *prometheus.GaugeVec
+	MemstoreSizeBytes      *prometheus.GaugeVec
+	MemstoreEvictionsTotal *prometheus.CounterVec
+
+	// cert verification cache metrics
+	CertVerificationCacheLookupsTotal  *prometheus.CounterVec
+	CertVerificationCacheSavedRPCCalls prometheus.Counter
+
+	// EigenDA backend metrics
+	DispersalStageDurationSeconds    *prometheus.HistogramVec
+	DispersalRetriesTotal            *prometheus.CounterVec
+	RetrievalRequestsTotal           *prometheus.CounterVec
+	RetrievalDurationSeconds         *prometheus.HistogramVec
+	VerificationStageDurationSeconds *prometheus.HistogramVec
+
+	// EigenDA V2 payment accounting metrics
+	ReservationPeriodUsageSymbols    prometheus.Gauge
+	ReservationPeriodCapacitySymbols prometheus.Gauge
+	OnDemandRemainingWei             prometheus.Gauge
+	OnDemandExhaustionSeconds        prometheus.Gauge
+
+	// multi-tenancy metrics
+	TenantRequestsTotal     *prometheus.CounterVec
+	TenantPayloadBytesTotal *prometheus.CounterVec
+
 	registry *prometheus.Registry
 	factory  metrics.Factory
 }
@@ -110,6 +188,41 @@ func NewMetrics(subsystem string) *Metrics {
 		}, []string{
 			"method", // no status on histograms because those are very expensive
 		}),
+		HTTPServerRouteRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "route_requests_total",
+			Help:      "Total requests to the HTTP server's non-cert routes (health, admin, memstore...)",
+		}, []string{
+			"method", "route", "status",
+		}),
+		HTTPServerRouteRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "route_request_duration_seconds",
+			Buckets:   prometheus.ExponentialBucketsRange(0.001, 60, 20),
+			Help:      "Histogram of the HTTP server's non-cert routes request durations",
+		}, []string{
+			"method", "route",
+		}),
+		HTTPServerRateLimitedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "rate_limited_total",
+			Help:      "Total cert requests rejected by the rate and in-flight limits, by limit",
+		}, []string{
+			"method", "limit",
+		}),
+		HTTPServerPayloadSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "payload_size_bytes",
+			// 1KiB to 32MiB, the max POST body size
+			Buckets: prometheus.ExponentialBuckets(1024, 2, 16),
+			Help:    "Histogram of the size of payloads posted (POST) or retrieved (GET)",
+		}, []string{
+			"method", "commitment_mode",
+		}),
 		SecondaryRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: secondarySubsystem,
@@ -127,6 +240,128 @@ func NewMetrics(subsystem string) *Metrics {
 		}, []string{
 			"backend_type",
 		}),
+		MemstoreEntries: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: memstoreSubsystem,
+			Name:      "entries",
+			Help:      "Number of entries currently held by the memstore",
+		}, []string{
+			"backend_type",
+		}),
+		MemstoreSizeBytes: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: memstoreSubsystem,
+			Name:      "size_bytes",
+			Help:      "Total size in bytes of the blobs currently held by the memstore",
+		}, []string{
+			"backend_type",
+		}),
+		MemstoreEvictionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: memstoreSubsystem,
+			Name:      "evictions_total",
+			Help:      "Total entries removed from the memstore, by reason (capacity or expiration)",
+		}, []string{
+			"backend_type", "reason",
+		}),
+		CertVerificationCacheLookupsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: certVerificationCacheSubsystem,
+			Name:      "lookups_total",
+			Help:      "Total lookups in the EigenDA V2 cert verification cache, by result (hit or miss)",
+		}, []string{
+			"result",
+		}),
+		CertVerificationCacheSavedRPCCalls: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: certVerificationCacheSubsystem,
+			Name:      "saved_rpc_calls_total",
+			Help:      "Total eth-calls avoided thanks to the EigenDA V2 cert verification cache",
+		}),
+		DispersalStageDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: dispersalSubsystem,
+			Name:      "stage_duration_seconds",
+			Buckets:   prometheus.ExponentialBucketsRange(0.001, 1200, 24),
+			Help:      "Histogram of the duration of each dispersal stage, per attempt",
+		}, []string{
+			"backend_type", "stage",
+		}),
+		DispersalRetriesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: dispersalSubsystem,
+			Name:      "retries_total",
+			Help:      "Total dispersal attempts retried after a failure",
+		}, []string{
+			"backend_type",
+		}),
+		RetrievalRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Name:      "requests_total",
+			Help:      "Total payload retrievals from the EigenDA network, by retriever",
+		}, []string{
+			"backend_type", "retriever", "status",
+		}),
+		RetrievalDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Name:      "duration_seconds",
+			Buckets:   prometheus.ExponentialBucketsRange(0.01, 300, 20),
+			Help:      "Histogram of payload retrieval durations from the EigenDA network, by retriever",
+		}, []string{
+			"backend_type", "retriever",
+		}),
+		VerificationStageDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: verificationSubsystem,
+			Name:      "stage_duration_seconds",
+			Buckets:   prometheus.ExponentialBucketsRange(0.001, 60, 20),
+			Help:      "Histogram of the duration of each cert verification stage",
+		}, []string{
+			"backend_type", "stage",
+		}),
+		ReservationPeriodUsageSymbols: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: paymentsSubsystem,
+			Name:      "reservation_period_usage_symbols",
+			Help:      "Symbols dispersed against the reservation during the current reservation period",
+		}),
+		ReservationPeriodCapacitySymbols: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: paymentsSubsystem,
+			Name:      "reservation_period_capacity_symbols",
+			Help:      "Symbols the reservation allows per reservation period (0 when there is no active reservation)",
+		}),
+		OnDemandRemainingWei: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: paymentsSubsystem,
+			Name:      "on_demand_remaining_wei",
+			Help:      "On-demand deposit remaining in the payment vault, in wei",
+		}),
+		OnDemandExhaustionSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: paymentsSubsystem,
+			Name:      "on_demand_exhaustion_seconds",
+			Help: "Projected seconds until the on-demand deposit is exhausted at the current spend rate " +
+				"(-1 when no projection can be made)",
+		}),
+		TenantRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: tenantSubsystem,
+			Name:      "requests_total",
+			Help:      "Total cert requests made by each tenant, including those rejected by its quota",
+		}, []string{
+			"tenant", "method", "commitment_mode", "status",
+		}),
+		TenantPayloadBytesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: tenantSubsystem,
+			Name:      "payload_bytes_total",
+			Help:      "Total payload bytes posted by each tenant",
+		}, []string{
+			"tenant", "method",
+		}),
 		registry: registry,
 		factory:  factory,
 	}
@@ -157,6 +392,21 @@ func (m *Metrics) RecordRPCServerRequest(method string) func(status, mode, ver s
 	}
 }
 
+// RecordHTTPRouteRequest records an incoming HTTP request to a non-cert route,
+// identified by its path template (e.g. /memstore/config).
+func (m *Metrics) RecordHTTPRouteRequest(method string, route string) func(status string) {
+	timer := prometheus.NewTimer(m.HTTPServerRouteRequestDurationSeconds.WithLabelValues(method, route))
+	return func(status string) {
+		m.HTTPServerRouteRequestsTotal.WithLabelValues(method, route, status).Inc()
+		timer.ObserveDuration()
+	}
+}
+
+// RecordRateLimited records a cert request rejected by one of the rate or in-flight limits.
+func (m *Metrics) RecordRateLimited(method string, limit string) {
+	m.HTTPServerRateLimitedTotal.WithLabelValues(method, limit).Inc()
+}
+
 // RecordSecondaryRequest records a secondary put/get operation.
 func (m *Metrics) RecordSecondaryRequest(bt string, method string) func(status string) {
 	timer := prometheus.NewTimer(m.SecondaryRequestDurationSec.WithLabelValues(bt))
@@ -167,6 +417,88 @@ func (m *Metrics) RecordSecondaryRequest(bt string, method string) func(status s
 	}
 }
 
+// RecordMemstoreSize sets the current number of entries and total size held by a memstore.
+func (m *Metrics) RecordMemstoreSize(bt string, entries int, sizeBytes uint64) {
+	m.MemstoreEntries.WithLabelValues(bt).Set(float64(entries))
+	m.MemstoreSizeBytes.WithLabelValues(bt).Set(float64(sizeBytes))
+}
+
+// RecordMemstoreEviction records a single entry being removed from a memstore.
+func (m *Metrics) RecordMemstoreEviction(bt string, reason string) {
+	m.MemstoreEvictionsTotal.WithLabelValues(bt, reason).Inc()
+}
+
+// RecordCertVerificationCacheLookup records a lookup in the cert verification cache,
+// along with the number of eth-calls it saved.
+func (m *Metrics) RecordCertVerificationCacheLookup(hit bool, savedRPCCalls int) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.CertVerificationCacheLookupsTotal.WithLabelValues(result).Inc()
+	m.CertVerificationCacheSavedRPCCalls.Add(float64(savedRPCCalls))
+}
+
+// RecordDispersalStage times a single dispersal stage. The returned function must be called once the stage is done.
+func (m *Metrics) RecordDispersalStage(bt string, stage string) func() {
+	timer := prometheus.NewTimer(m.DispersalStageDurationSeconds.WithLabelValues(bt, stage))
+	return func() {
+		timer.ObserveDuration()
+	}
+}
+
+// RecordDispersalRetry records a failed dispersal attempt that is about to be retried.
+func (m *Metrics) RecordDispersalRetry(bt string) {
+	m.DispersalRetriesTotal.WithLabelValues(bt).Inc()
+}
+
+// RecordRetrieval records a payload retrieval attempt from a single retriever (e.g. relay or validator).
+func (m *Metrics) RecordRetrieval(bt string, retriever string) func(status string) {
+	timer := prometheus.NewTimer(m.RetrievalDurationSeconds.WithLabelValues(bt, retriever))
+
+	return func(status string) {
+		m.RetrievalRequestsTotal.WithLabelValues(bt, retriever, status).Inc()
+		timer.ObserveDuration()
+	}
+}
+
+// RecordVerificationStage times a single cert verification stage.
+// The returned function must be called once the stage is done.
+func (m *Metrics) RecordVerificationStage(bt string, stage string) func() {
+	timer := prometheus.NewTimer(m.VerificationStageDurationSeconds.WithLabelValues(bt, stage))
+	return func() {
+		timer.ObserveDuration()
+	}
+}
+
+// RecordPayloadSize records the size of a payload posted or retrieved by a client.
+func (m *Metrics) RecordPayloadSize(method string, mode string, sizeBytes int) {
+	m.HTTPServerPayloadSizeBytes.WithLabelValues(method, mode).Observe(float64(sizeBytes))
+}
+
+// RecordReservationUsage sets the usage and capacity of the current reservation period.
+func (m *Metrics) RecordReservationUsage(usedSymbols uint64, capacitySymbols uint64) {
+	m.ReservationPeriodUsageSymbols.Set(float64(usedSymbols))
+	m.ReservationPeriodCapacitySymbols.Set(float64(capacitySymbols))
+}
+
+// RecordOnDemandBalance sets the remaining on-demand deposit and its projected exhaustion.
+// secondsUntilExhaustion is -1 when no projection can be made.
+func (m *Metrics) RecordOnDemandBalance(remainingWei float64, secondsUntilExhaustion float64) {
+	m.OnDemandRemainingWei.Set(remainingWei)
+	m.OnDemandExhaustionSeconds.Set(secondsUntilExhaustion)
+}
+
+// RecordTenantRequest records a cert request made by a tenant.
+func (m *Metrics) RecordTenantRequest(tenant string, method string, mode string, status string) {
+	m.TenantRequestsTotal.WithLabelValues(tenant, method, mode, status).Inc()
+}
+
+// RecordTenantPayloadBytes records the payload bytes posted by a tenant.
+func (m *Metrics) RecordTenantPayloadBytes(tenant string, method string, sizeBytes int) {
+	m.TenantPayloadBytesTotal.WithLabelValues(tenant, method).Add(float64(sizeBytes))
+}
+
 // StartServer starts the metrics server on the given hostname and port.
 // If port is 0, it automatically assigns an available port and returns the actual port.
 func (m *Metrics) StartServer(hostname string, port int) (*ophttp.HTTPServer, error) {
@@ -207,6 +539,52 @@ func (n *noopMetricer) RecordRPCServerRequest(string) func(status, mode, ver str
 	return func(string, string, string) {}
 }
 
+func (n *noopMetricer) RecordHTTPRouteRequest(string, string) func(status string) {
+	return func(string) {}
+}
+
+func (n *noopMetricer) RecordRateLimited(string, string) {
+}
+
 func (n *noopMetricer) RecordSecondaryRequest(string, string) func(status string) {
 	return func(string) {}
 }
+
+func (n *noopMetricer) RecordMemstoreSize(string, int, uint64) {
+}
+
+func (n *noopMetricer) RecordMemstoreEviction(string, string) {
+}
+
+func (n *noopMetricer) RecordCertVerificationCacheLookup(bool, int) {
+}
+
+func (n *noopMetricer) RecordDispersalStage(string, string) func() {
+	return func() {}
+}
+
+func (n *noopMetricer) RecordDispersalRetry(string) {
+}
+
+func (n *noopMetricer) RecordRetrieval(string, string) func(status string) {
+	return func(string) {}
+}
+
+func (n *noopMetricer) RecordVerificationStage(string, string) func() {
+	return func() {}
+}
+
+func (n *noopMetricer) RecordPayloadSize(string, string, int) {
+}
+
+func (n *noopMetricer) RecordReservationUsage(uint64, uint64) {
+}
+
+func (n *noopMetricer) RecordOnDemandBalance(float64, float64) {
+}
+
+func (n *noopMetricer) RecordTenantRequest(string, string, string, string) {
+}
+
+func (n *noopMetricer) RecordTenantPayloadBytes(string, string, int) {
+}
@@ -1,14 +1,21 @@
 package server
 
 import (
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/server/servertls"
 	"github.com/urfave/cli/v2"
 )
 
 const (
-	ListenAddrFlagName  = "addr"
-	PortFlagName        = "port"
-	APIsEnabledFlagName = "api-enabled"
-	AdminAPIType        = "admin"
+	ListenAddrFlagName        = "addr"
+	PortFlagName              = "port"
+	APIsEnabledFlagName       = "api-enabled"
+	MaxPOSTBodyBytesFlagName  = "max-post-body-bytes"
+	ReadHeaderTimeoutFlagName = "read-header-timeout"
+	WriteTimeoutFlagName      = "write-timeout"
+	PutTimeoutFlagName        = "put-timeout"
+	GetTimeoutFlagName        = "get-timeout"
+	AdminAPIType              = "admin"
 )
 
 // We don't add any _SERVER_ middlefix to the env vars like we do for other categories
@@ -41,6 +48,42 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "API_ENABLED"),
 			Category: category,
 		},
+		&cli.Int64Flag{
+			Name: MaxPOSTBodyBytesFlagName,
+			Usage: "Max size of the body of POST requests, above which a 413 is returned. " +
+				"0 derives it from the max blob size of the enabled EigenDA backends.",
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_POST_BODY_BYTES"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     ReadHeaderTimeoutFlagName,
+			Usage:    "Max duration for reading the headers of a request. A negative value disables it.",
+			Value:    defaultReadHeaderTimeout,
+			EnvVars:  withEnvPrefix(envPrefix, "READ_HEADER_TIMEOUT"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: WriteTimeoutFlagName,
+			Usage: "Max duration before timing out writes of the response. Should be larger than the time " +
+				"it takes for a blob to be finalized. A negative value disables it.",
+			Value:    defaultWriteTimeout,
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_TIMEOUT"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: PutTimeoutFlagName,
+			Usage: "Deadline of dispersal (POST /put) requests. Clients can set a shorter deadline with the " +
+				"timeout query param or the X-Request-Timeout header. 0 only applies the client's deadline.",
+			EnvVars:  withEnvPrefix(envPrefix, "PUT_TIMEOUT"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: GetTimeoutFlagName,
+			Usage: "Deadline of retrieval (GET /get) requests. Clients can set a shorter deadline with the " +
+				"timeout query param or the X-Request-Timeout header. 0 only applies the client's deadline.",
+			EnvVars:  withEnvPrefix(envPrefix, "GET_TIMEOUT"),
+			Category: category,
+		},
 	}
 
 	return flags
@@ -48,8 +91,15 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 
 func ReadConfig(ctx *cli.Context) Config {
 	return Config{
-		Host:        ctx.String(ListenAddrFlagName),
-		Port:        ctx.Int(PortFlagName),
-		EnabledAPIs: ctx.StringSlice(APIsEnabledFlagName),
+		Host:              ctx.String(ListenAddrFlagName),
+		Port:              ctx.Int(PortFlagName),
+		EnabledAPIs:       ctx.StringSlice(APIsEnabledFlagName),
+		MaxPOSTBodyBytes:  ctx.Int64(MaxPOSTBodyBytesFlagName),
+		ReadHeaderTimeout: ctx.Duration(ReadHeaderTimeoutFlagName),
+		WriteTimeout:      ctx.Duration(WriteTimeoutFlagName),
+		PutTimeout:        ctx.Duration(PutTimeoutFlagName),
+		GetTimeout:        ctx.Duration(GetTimeoutFlagName),
+		TLS:               servertls.ReadConfig(ctx),
+		RateLimit:         ratelimit.ReadConfig(ctx),
 	}
 }
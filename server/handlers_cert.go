@@ -14,15 +14,11 @@ import (
 	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigenda-proxy/server/middleware"
 	"github.com/gorilla/mux"
 )
 
-const (
-	// limit requests to only 32 MiB to mitigate potential DoS attacks
-	maxPOSTRequestBodySize int64 = 1024 * 1024 * 32
-)
-
 // =================================================================================================
 // GET ROUTES
 // =================================================================================================
@@ -42,8 +38,9 @@ func (svr *Server) handleGetOPKeccakCommitment(w http.ResponseWriter, r *http.Re
 	if err != nil {
 		return fmt.Errorf("GET keccakCommitment %v: %w", keccakCommitmentHex, err)
 	}
+	svr.m.RecordPayloadSize(r.Method, string(commitments.OptimismKeccakCommitmentMode), len(payload))
 
-	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path,
+	proxy_logging.FromContext(r.Context(), svr.log).Info("Processed request", "method", r.Method, "url", r.URL.Path,
 		"commitmentMode", commitments.OptimismKeccakCommitmentMode, "commitment", keccakCommitmentHex)
 
 	_, err = w.Write(payload)
@@ -100,9 +97,10 @@ func (svr *Server) handleGetShared(
 		return fmt.Errorf("get request failed with serializedCert (version %v) %v: %w",
 			versionedCert.Version, serializedCertHex, err)
 	}
+	svr.m.RecordPayloadSize(r.Method, string(mode), len(input))
 
-	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
-		"certVersion", versionedCert.Version, "serializedCert", serializedCertHex)
+	proxy_logging.FromContext(r.Context(), svr.log).Info("Processed request", "method", r.Method, "url", r.URL.Path,
+		"commitmentMode", mode, "certVersion", versionedCert.Version, "serializedCert", serializedCertHex)
 
 	_, err = w.Write(input)
 	if err != nil {
@@ -148,17 +146,18 @@ func (svr *Server) handlePostOPKeccakCommitment(w http.ResponseWriter, r *http.R
 		return proxyerrors.NewParsingError(
 			fmt.Errorf("failed to decode hex keccak commitment %s: %w", keccakCommitmentHex, err))
 	}
-	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPOSTRequestBodySize))
+	payload, err := svr.readPOSTBody(w, r)
 	if err != nil {
-		return proxyerrors.NewReadRequestBodyError(err, maxPOSTRequestBodySize)
+		return err
 	}
+	svr.m.RecordPayloadSize(r.Method, string(commitments.OptimismKeccakCommitmentMode), len(payload))
 
 	err = svr.sm.PutOPKeccakPairInS3(r.Context(), keccakCommitment, payload)
 	if err != nil {
 		return fmt.Errorf("keccak POST request failed for commitment %v: %w", keccakCommitmentHex, err)
 	}
 
-	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path,
+	proxy_logging.FromContext(r.Context(), svr.log).Info("Processed request", "method", r.Method, "url", r.URL.Path,
 		"commitmentMode", commitments.OptimismKeccakCommitmentMode, "commitment", keccakCommitmentHex)
 	// No need to return the keccak commitment because it's already known by the client (keccak(payload)).
 	return nil
@@ -174,16 +173,31 @@ func (svr *Server) handlePostOPGenericCommitment(w http.ResponseWriter, r *http.
 	return svr.handlePostShared(w, r, commitments.OptimismGenericCommitmentMode)
 }
 
+// readPOSTBody reads the body of a POST request, up to the configured max size.
+// Requests whose Content-Length exceeds the max size are rejected before reading the body.
+func (svr *Server) readPOSTBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	limit := svr.config.MaxPOSTBodyBytes
+	if r.ContentLength > limit {
+		return nil, proxyerrors.NewReadRequestBodyError(&http.MaxBytesError{Limit: limit}, limit)
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		return nil, proxyerrors.NewReadRequestBodyError(err, limit)
+	}
+	return payload, nil
+}
+
 // This is a shared function for handling POST requests for
 func (svr *Server) handlePostShared(
 	w http.ResponseWriter,
 	r *http.Request,
 	mode commitments.CommitmentMode,
 ) error {
-	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPOSTRequestBodySize))
+	payload, err := svr.readPOSTBody(w, r)
 	if err != nil {
-		return proxyerrors.NewReadRequestBodyError(err, maxPOSTRequestBodySize)
+		return err
 	}
+	svr.m.RecordPayloadSize(r.Method, string(mode), len(payload))
 
 	serializedCert, err := svr.sm.Put(r.Context(), mode, payload)
 	if err != nil {
@@ -207,8 +221,8 @@ func (svr *Server) handlePostShared(
 		return fmt.Errorf("failed to encode serializedCert %v: %w", serializedCert, err)
 	}
 
-	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
-		"certVersion", versionedCert.Version, "cert", hex.EncodeToString(serializedCert))
+	proxy_logging.FromContext(r.Context(), svr.log).Info("Processed request", "method", r.Method, "url", r.URL.Path,
+		"commitmentMode", mode, "certVersion", versionedCert.Version, "cert", hex.EncodeToString(serializedCert))
 
 	// We write the commitment as bytes directly instead of hex encoded.
 	// The spec https://specs.optimism.io/experimental/alt-da.html#da-server says it should be hex-encoded,
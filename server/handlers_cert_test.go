@@ -5,16 +5,21 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/server/middleware"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda-proxy/test/mocks"
 	"github.com/Layr-Labs/eigenda/api"
@@ -275,6 +280,11 @@ func TestHandlerPutErrors(t *testing.T) {
 			mockStorageMgrPutReturnedErr: proxyerrors.ErrProxyOversizedBlob,
 			expectedHTTPCode:             http.StatusBadRequest,
 		},
+		{
+			name:                         "Failure - GatewayTimeout 504",
+			mockStorageMgrPutReturnedErr: fmt.Errorf("dispersal: %w", context.DeadlineExceeded),
+			expectedHTTPCode:             http.StatusGatewayTimeout,
+		},
 	}
 
 	for _, tt := range tests {
@@ -305,6 +315,91 @@ func TestHandlerPutErrors(t *testing.T) {
 	}
 }
 
+func TestHandlerPutBodyTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V1EigenDABackend)
+	mockStorageMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]byte("cert"), nil)
+
+	cfg := testCfg
+	cfg.MaxPOSTBodyBytes = 8
+	r := mux.NewRouter()
+	NewServer(cfg, mockStorageMgr, testLogger, metrics.NoopMetrics).RegisterRoutes(r)
+
+	post := func(body io.Reader) int {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/put", body))
+		return rec.Code
+	}
+	require.Equal(t, http.StatusOK, post(strings.NewReader("12345678")))
+	// rejected from its Content-Length
+	require.Equal(t, http.StatusRequestEntityTooLarge, post(strings.NewReader("123456789")))
+	// rejected while reading the body, since its length is unknown
+	require.Equal(t, http.StatusRequestEntityTooLarge, post(io.MultiReader(strings.NewReader("123456789"))))
+}
+
+func TestNewServerTimeouts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+
+	server := NewServer(testCfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	require.Equal(t, defaultReadHeaderTimeout, server.httpServer.ReadHeaderTimeout)
+	require.Equal(t, defaultWriteTimeout, server.httpServer.WriteTimeout)
+
+	cfg := testCfg
+	cfg.ReadHeaderTimeout = time.Second
+	cfg.WriteTimeout = -1
+	server = NewServer(cfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	require.Equal(t, time.Second, server.httpServer.ReadHeaderTimeout)
+	// disabled
+	require.Negative(t, server.httpServer.WriteTimeout)
+}
+
+func TestHandlerPutTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V1EigenDABackend)
+
+	var deadlines []time.Duration
+	mockStorageMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, _ commitments.CommitmentMode, _ []byte) ([]byte, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			deadlines = append(deadlines, time.Until(deadline))
+			return []byte("cert"), nil
+		})
+
+	cfg := testCfg
+	cfg.PutTimeout = time.Minute
+	r := mux.NewRouter()
+	NewServer(cfg, mockStorageMgr, testLogger, metrics.NoopMetrics).RegisterRoutes(r)
+
+	post := func(url string, headerTimeout string) int {
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader("payload"))
+		if headerTimeout != "" {
+			req.Header.Set(middleware.RequestTimeoutHeader, headerTimeout)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusOK, post("/put", ""))
+	require.Equal(t, http.StatusOK, post("/put?timeout=10s", ""))
+	require.Equal(t, http.StatusOK, post("/put", "20s"))
+	// the route's timeout caps the one requested by the client
+	require.Equal(t, http.StatusOK, post("/put?commitment_mode=standard&timeout=1h", ""))
+	require.Equal(t, http.StatusBadRequest, post("/put?timeout=soon", ""))
+	require.Equal(t, http.StatusBadRequest, post("/put", "-1s"))
+
+	require.Len(t, deadlines, 4)
+	for i, expected := range []time.Duration{time.Minute, 10 * time.Second, 20 * time.Second, time.Minute} {
+		require.InDelta(t, expected, deadlines[i], float64(5*time.Second))
+	}
+}
+
 func TestHandlerPutKeccakErrors(t *testing.T) {
 	url := fmt.Sprintf("/put/0x00%s", testCommitStr)
 
@@ -1,11 +1,8 @@
 // handlers_misc.go contains miscellaneous handlers that do not fit into the main request flow.
-// These are all health, debug, and testing endpoints.
+// These are all health, debug, and admin endpoints.
 //
-// These handlers SHOULD NOT be wrapped in middlewares, as the middlewares are currently
-// hardcoded to log and emit cert related information (we will ideally eventually fix this).
-// Handlers in this file thus need to do their own logging and error handling.
-//
-// DO NOT FORGET to add `http.WriteHeader(http.StatusCodes)` on every error path!
+// Handlers in this file SHOULD be wrapped in the generic middlewares (see [middleware.WithMiddlewares]),
+// which take care of logging, metrics and converting returned errors to HTTP error responses.
 package server
 
 import (
@@ -26,14 +23,46 @@ const (
 	contentTypeJSON = "application/json"
 )
 
-func (svr *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+func (svr *Server) handleHealth(w http.ResponseWriter, _ *http.Request) error {
 	w.WriteHeader(http.StatusOK)
+	return nil
 }
 
-func (svr *Server) logDispersalGetError(w http.ResponseWriter, _ *http.Request) {
+func (svr *Server) logDispersalGetError(w http.ResponseWriter, _ *http.Request) error {
 	svr.log.Warn(`GET method invoked on /put/ endpoint.
 		This can occur due to 303 redirects when using incorrect slash ticks.`)
 	w.WriteHeader(http.StatusMethodNotAllowed)
+	return nil
+}
+
+// BuildInfo ... identifies the build of the proxy binary
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// VersionJSON ... is the response of GET /version
+type VersionJSON struct {
+	BuildInfo
+	EnabledBackends  []string `json:"enabledBackends"`
+	DispersalBackend string   `json:"dispersalBackend"`
+}
+
+// handleGetVersion handles the GET request for the build of the proxy and the EigenDA backends it serves.
+// The dispersal backend is the one currently used, which can be changed with the admin API.
+func (svr *Server) handleGetVersion(w http.ResponseWriter, _ *http.Request) error {
+	enabledBackends := make([]string, 0, len(svr.config.EnabledBackends))
+	for _, backend := range svr.config.EnabledBackends {
+		enabledBackends = append(enabledBackends, common.EigenDABackendToString(backend))
+	}
+
+	response := VersionJSON{
+		BuildInfo:        svr.config.BuildInfo,
+		EnabledBackends:  enabledBackends,
+		DispersalBackend: common.EigenDABackendToString(svr.sm.GetDispersalBackend()),
+	}
+	return writeJSON(w, response)
 }
 
 type EigenDADispersalBackendJSON struct {
@@ -42,82 +71,61 @@ type EigenDADispersalBackendJSON struct {
 
 // handleGetEigenDADispersalBackend handles the GET request to check the current EigenDA backend used for dispersal.
 // This endpoint returns which EigenDA backend version (v1 or v2) is currently being used for blob dispersal.
-func (svr *Server) handleGetEigenDADispersalBackend(w http.ResponseWriter, r *http.Request) {
+func (svr *Server) handleGetEigenDADispersalBackend(w http.ResponseWriter, _ *http.Request) error {
 	backend := svr.sm.GetDispersalBackend()
 	backendString := common.EigenDABackendToString(backend)
 
 	response := EigenDADispersalBackendJSON{EigenDADispersalBackend: backendString}
-	svr.writeJSON(w, r, response)
+	return writeJSON(w, response)
 }
 
 // handleSetEigenDADispersalBackend handles the PUT request to set the EigenDA backend used for dispersal.
 // This endpoint configures which EigenDA backend version (v1 or v2) will be used for blob dispersal.
-func (svr *Server) handleSetEigenDADispersalBackend(w http.ResponseWriter, r *http.Request) {
+func (svr *Server) handleSetEigenDADispersalBackend(w http.ResponseWriter, r *http.Request) error {
 	// Read request body to get the new value
 	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1024)) // Small limit since we only expect a string
 	if err != nil {
-		svr.log.Error("failed to read request body", "method", r.Method, "path", r.URL.Path, "error", err)
-		http.Error(w, proxyerrors.NewReadRequestBodyError(err, 1024).Error(), http.StatusBadRequest)
-		return
+		return proxyerrors.NewReadRequestBodyError(err, 1024)
 	}
 
 	// Parse the backend string value
 	var eigenDADispersalBackendToSet EigenDADispersalBackendJSON
 	if err := json.Unmarshal(body, &eigenDADispersalBackendToSet); err != nil {
-		err := proxyerrors.NewUnmarshalJSONError(fmt.Errorf("parsing eigenDADispersalBackend"))
-		svr.log.Error("failed to unmarshal body", "method", r.Method, "path", r.URL.Path, "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return proxyerrors.NewUnmarshalJSONError(fmt.Errorf("parsing eigenDADispersalBackend: %w", err))
 	}
 
 	// Convert the string to EigenDABackend enum
 	backend, err := common.StringToEigenDABackend(eigenDADispersalBackendToSet.EigenDADispersalBackend)
 	if err != nil {
 		// already a structured error that error middleware knows how to handle
-		svr.log.Error(
-			"failed to convert string to EigenDABackend",
-			"method",
-			r.Method,
-			"path",
-			r.URL.Path,
-			"error",
-			err,
-		)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return err
 	}
 
 	svr.SetDispersalBackend(backend)
 
-	// We return a 200 OK response because the backend was successfully set.
-	// Note that writeJSON below can fail to write the response,
-	// but we still want to return a 200 OK here to indicate the backend was set.
-	// WriteHeader can only be written once, so even if marshalling fails,
-	// the WriteHeader(http.StatusInternalServerError) will not overwrite the 200.
-	w.Header().Set(headerContentType, contentTypeJSON)
-	w.WriteHeader(http.StatusOK)
-
 	// Exact same logic as GET handler.
 	newBackend := svr.sm.GetDispersalBackend()
 	backendString := common.EigenDABackendToString(newBackend)
 
 	response := EigenDADispersalBackendJSON{EigenDADispersalBackend: backendString}
-	svr.writeJSON(w, r, response)
+	return writeJSON(w, response)
 }
 
-func (svr *Server) writeJSON(w http.ResponseWriter, r *http.Request, response interface{}) {
+// writeJSON writes response as a json body with a 200 status.
+// An error is returned if the response can't be marshalled, in which case nothing is written.
+func writeJSON(w http.ResponseWriter, response interface{}) error {
 	jsonData, err := json.Marshal(response)
 	if err != nil {
-		svr.log.Error("failed to marshal response to json", "method", r.Method, "path", r.URL.Path, "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, "failed to marshal response: %v", err)
-		return
+		return fmt.Errorf("marshal response to json: %w", err)
 	}
 
-	w.Header().Set(contentTypeJSON, "application/json")
+	w.Header().Set(headerContentType, contentTypeJSON)
 	w.WriteHeader(http.StatusOK)
 	_, err = w.Write(jsonData)
 	if err != nil {
-		svr.log.Error("failed to write response", "method", r.Method, "path", r.URL.Path, "error", err)
+		// The 200 header has already been sent, but we still return an error
+		// here so that the logging middleware can log it.
+		return fmt.Errorf("write response: %w", err)
 	}
+	return nil
 }
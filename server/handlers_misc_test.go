@@ -120,3 +120,33 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 		})
 	})
 }
+
+func TestVersionEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().Return(common.V2EigenDABackend)
+
+	cfg := testCfg
+	cfg.BuildInfo = BuildInfo{Version: "v1.2.3", Commit: "abc123", Date: "2025-01-01"}
+	cfg.EnabledBackends = []common.EigenDABackend{common.V1EigenDABackend, common.V2EigenDABackend}
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rec := httptest.NewRecorder()
+
+	r := mux.NewRouter()
+	server := NewServer(cfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response VersionJSON
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, VersionJSON{
+		BuildInfo:        cfg.BuildInfo,
+		EnabledBackends:  []string{"V1", "V2"},
+		DispersalBackend: "V2",
+	}, response)
+}
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 )
 
@@ -20,32 +25,47 @@ func withErrorHandling(
 			return nil
 		}
 
-		// TODO: should we add request specific information like GET vs POST,
-		// commitment mode, cert version, etc. to the error?
-		// Or maybe we should just add a requestID to the error, and log the request-specific information
-		// in the logging middleware, so that we can correlate the error with the request?
+		// The request ID is included in every error body, so that errors seen by clients
+		// can be correlated with the proxy's log lines for that request.
+		requestID := getRequestID(r)
 		var certVerificationFailedErr *verification.CertVerificationFailedError
 		switch {
+		// checked before 400, since oversized bodies are also read request body errors
+		case proxyerrors.Is413(err):
+			writeError(w, requestID, err, http.StatusRequestEntityTooLarge)
 		case proxyerrors.Is400(err):
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, requestID, err, http.StatusBadRequest)
+		case proxyerrors.Is401(err):
+			writeError(w, requestID, err, http.StatusUnauthorized)
+		case proxyerrors.Is403(err):
+			writeError(w, requestID, err, http.StatusForbidden)
 		// 418 TEAPOT errors don't follow the pattern proxyerrors.Is418(err),
 		// because we need to unwrap the certVerificationFailedError from any errors that have been added on top,
 		// such that we marshal the correct json body.
 		case errors.As(err, &certVerificationFailedErr):
-			_, errMarshal := json.Marshal(certVerificationFailedErr)
+			body := certVerificationFailedBody{
+				CertVerificationFailedError: certVerificationFailedErr,
+				RequestID:                   requestID,
+			}
+			_, errMarshal := json.Marshal(body)
 			if errMarshal != nil {
 				panic(fmt.Errorf("failed to marshal cert verification failed error: %w", errMarshal))
 			}
 			w.WriteHeader(http.StatusTeapot)
-			encodingErr := json.NewEncoder(w).Encode(certVerificationFailedErr)
+			encodingErr := json.NewEncoder(w).Encode(body)
 			if encodingErr != nil {
 				panic(fmt.Errorf("failed to encode cert verification failed error: %w", encodingErr))
 			}
 		case proxyerrors.Is429(err):
-			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			if retryAfter, ok := getRetryAfter(err); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			writeError(w, requestID, err, http.StatusTooManyRequests)
 		case proxyerrors.Is503(err):
 			// this tells the caller (batcher) to failover to ethda b/c eigenda is temporarily down
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			writeError(w, requestID, err, http.StatusServiceUnavailable)
+		case proxyerrors.Is504(err):
+			writeError(w, requestID, err, http.StatusGatewayTimeout)
 		default:
 			// Default to 500 for unexpected errors.
 			// Note that this includes grpc 4xx errors returned from the disperser server.
@@ -53,7 +73,7 @@ func withErrorHandling(
 			// IFFT'ing or encoding the blob, so we shouldn't return a 400 to the client.
 			// See https://github.com/Layr-Labs/eigenda/blob/bee55ed9207f16153c3fd8ebf73c219e68685def/api/errors.go#L22
 			// for the 400s returned by the disperser server (currently only INVALID_ARGUMENT).
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, requestID, err, http.StatusInternalServerError)
 		}
 
 		// forward error to the logging middleware (through the metrics middleware)
@@ -61,3 +81,34 @@ func withErrorHandling(
 		return err
 	}
 }
+
+// certVerificationFailedBody is the json body of 418 TEAPOT responses.
+// The embedded error's fields (StatusCode, Msg) are used by rollup derivation pipelines,
+// so they must stay at the top level of the body.
+type certVerificationFailedBody struct {
+	*verification.CertVerificationFailedError
+	RequestID string `json:"RequestID,omitempty"`
+}
+
+// getRetryAfter returns the delay after which the client can retry, for 429 errors returned by the proxy itself
+// (as opposed to the disperser's).
+func getRetryAfter(err error) (time.Duration, bool) {
+	var quotaErr *tenant.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return quotaErr.RetryAfter, true
+	}
+	var limitErr *ratelimit.LimitExceededError
+	if errors.As(err, &limitErr) {
+		return limitErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// writeError writes err as a plain text body, suffixed with the request ID when known.
+func writeError(w http.ResponseWriter, requestID string, err error, code int) {
+	msg := err.Error()
+	if requestID != "" {
+		msg = fmt.Sprintf("%s (request_id: %s)", msg, requestID)
+	}
+	http.Error(w, msg, code)
+}
@@ -5,13 +5,13 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
 // WithLogging is a middleware that logs information related to each request.
 // It does not write anything to the response, that is the job of the handlers.
-// Currently we cannot log the status code because go's default ResponseWriter interface does not expose it.
-// TODO: implement a ResponseWriter wrapper that saves the status code: see https://github.com/golang/go/issues/18997
+// For routes that don't deal with certs, mode is noCommitmentMode and no cert information is logged.
 func withLogging(
 	handleFn func(http.ResponseWriter, *http.Request) error,
 	log logging.Logger,
@@ -24,10 +24,16 @@ func withLogging(
 		err := handleFn(scw, r)
 
 		args := []any{
-			"method", r.Method, "url", r.URL,
-			"commitment_mode", mode, "cert_version", getCertVersion(r),
-			"status", scw.status, "duration", time.Since(start),
+			proxy_logging.RequestIDLogKey, getRequestID(r), "method", r.Method, "url", r.URL,
+			"route", getRouteTemplate(r),
 		}
+		if mode != noCommitmentMode {
+			args = append(args, "commitment_mode", mode, "cert_version", getCertVersion(r))
+		}
+		if tenant := getTenant(r); tenant != "" {
+			args = append(args, "tenant", tenant)
+		}
+		args = append(args, "status", scw.status, "duration", time.Since(start))
 
 		if err != nil {
 			args = append(args, "error", err.Error())
@@ -36,6 +42,8 @@ func withLogging(
 			} else {
 				log.Error("request completed with error", args...)
 			}
+		} else if mode == noCommitmentMode {
+			log.Debug("request completed", args...)
 		} else {
 			// This log line largely duplicates the logging in the handlers.
 			// Only difference being that we have duration here, whereas the handlers log the cert.
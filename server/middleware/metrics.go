@@ -24,6 +24,28 @@ func withMetrics(
 		certVersion := getCertVersion(r)
 		// Prob should use different metric for POST and GET errors.
 		recordDur(strconv.Itoa(scw.status), string(mode), certVersion)
+		if tenant := getTenant(r); tenant != "" {
+			m.RecordTenantRequest(tenant, r.Method, string(mode), strconv.Itoa(scw.status))
+		}
+
+		// Forward error to the logging middleware
+		return err
+	}
+}
+
+// withRouteMetrics is the equivalent of withMetrics for routes that don't deal with certs.
+// Requests are recorded with the route's path template, instead of commitment mode and cert version.
+func withRouteMetrics(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	m metrics.Metricer,
+) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		recordDur := m.RecordHTTPRouteRequest(r.Method, getRouteTemplate(r))
+
+		scw := newStatusCaptureWriter(w)
+		err := handleFn(scw, r)
+
+		recordDur(strconv.Itoa(scw.status))
 
 		// Forward error to the logging middleware
 		return err
@@ -2,35 +2,117 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
 )
 
 // Helper function to chain middlewares in the correct order
-// Context -> Logging -> Metrics -> Error Handling -> Handler
+// Context -> Tracing -> Logging -> Metrics -> Error Handling -> Tenant -> Rate Limit -> Tenant Quota -> Timeout
+// -> Handler
 //
-// This should only be used for cert POST and GET routes,
-// as the middlewares are currently not compatible with
-// other generic routes (e.g. /health, /version, etc.)
+// The tenant is resolved before the rate limit, which keys per-client limits on it, and its quota is only
+// charged for requests that weren't rate limited.
 //
-// TODO: make our middlewares compatible with all routes, if possible.
+// This should only be used for cert POST and GET routes, as it logs and emits cert related information.
+// limiter and tenants can be nil, when no limit is configured and multi-tenancy is disabled respectively.
+// timeout is the deadline of the route's requests, 0 only applying the deadline requested by the client.
+// Use [WithMiddlewares] for all other routes.
 func WithCertMiddlewares(
 	handler func(http.ResponseWriter, *http.Request) error,
 	log logging.Logger,
 	m metrics.Metricer,
+	limiter *ratelimit.Limiter,
+	tenants *tenant.Registry,
+	timeout time.Duration,
 	mode commitments.CommitmentMode,
 ) http.HandlerFunc {
 	return withRequestContext(
-		withLogging(
-			withMetrics(
-				withErrorHandling(handler),
-				m,
+		withTracing(
+			withLogging(
+				withMetrics(
+					withErrorHandling(
+						withTenant(
+							withRateLimit(
+								withTenantQuota(withRequestTimeout(handler, timeout), tenants, m, mode),
+								limiter,
+								m,
+							),
+							tenants,
+						),
+					),
+					m,
+					mode,
+				),
+				log,
 				mode,
 			),
-			log,
 			mode,
 		),
 	)
 }
+
+// WithMiddlewares chains the same middlewares as [WithCertMiddlewares], except the rate limit, tenant and
+// timeout middlewares, for routes that don't deal with certs (e.g. /health, admin and memstore routes).
+// Metrics are labeled with the route's path template.
+// Successful requests are only logged at debug level, since some of these routes are polled frequently.
+func WithMiddlewares(
+	handler func(http.ResponseWriter, *http.Request) error,
+	log logging.Logger,
+	m metrics.Metricer,
+) http.HandlerFunc {
+	return withRequestContext(
+		withTracing(
+			withLogging(
+				withRouteMetrics(
+					withErrorHandling(handler),
+					m,
+				),
+				log,
+				noCommitmentMode,
+			),
+			noCommitmentMode,
+		),
+	)
+}
+
+// MuxMiddleware wraps every route of a mux router in [WithMiddlewares].
+// It is meant for routes registered by other packages (e.g. memstore routes), whose handlers do their own
+// error handling, and is used as follows:
+//
+//	subrouter := router.NewRoute().Subrouter()
+//	subrouter.Use(middleware.MuxMiddleware(log, m))
+func MuxMiddleware(log logging.Logger, m metrics.Metricer) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return WithMiddlewares(func(w http.ResponseWriter, r *http.Request) error {
+			next.ServeHTTP(w, r)
+			return nil
+		}, log, m)
+	}
+}
+
+// noCommitmentMode is passed as commitment mode to the middlewares shared with cert routes,
+// for routes that don't deal with certs.
+const noCommitmentMode commitments.CommitmentMode = ""
+
+// unknownRoute is the route reported for requests that weren't routed by a mux router.
+const unknownRoute = "unknown"
+
+// getRouteTemplate returns the path template of the mux route matched by r (e.g. /memstore/config).
+// Unlike the url path, it has a bounded cardinality, so can be used as a metric label.
+func getRouteTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return unknownRoute
+	}
+	tmpl, err := route.GetPathTemplate()
+	if err != nil {
+		return unknownRoute
+	}
+	return tmpl
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// routeMetricer records the labels of the last RecordHTTPRouteRequest call.
+type routeMetricer struct {
+	metrics.Metricer
+	method, route, status string
+}
+
+func (m *routeMetricer) RecordHTTPRouteRequest(method string, route string) func(status string) {
+	return func(status string) {
+		m.method, m.route, m.status = method, route, status
+	}
+}
+
+func TestWithMiddlewares_RecordsRouteTemplate(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	m := &routeMetricer{Metricer: metrics.NoopMetrics}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/{key}", WithMiddlewares(func(w http.ResponseWriter, r *http.Request) error {
+		return proxyerrors.NewParsingError(errors.New("bad key"))
+	}, testLogger, m))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/some-key", nil))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code, "errors should be converted by the error middleware")
+	require.NotEmpty(t, rec.Header().Get(RequestIDHeader))
+	require.Equal(t, http.MethodPut, m.method)
+	require.Equal(t, "/admin/{key}", m.route)
+	require.Equal(t, "400", m.status)
+}
+
+func TestMuxMiddleware_WrapsSubrouterRoutes(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	m := &routeMetricer{Metricer: metrics.NoopMetrics}
+
+	router := mux.NewRouter()
+	subrouter := router.NewRoute().Subrouter()
+	subrouter.Use(MuxMiddleware(testLogger, m))
+	subrouter.HandleFunc("/memstore/events", func(w http.ResponseWriter, r *http.Request) {
+		// streaming handlers rely on the ResponseWriter being a Flusher
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		w.WriteHeader(http.StatusAccepted)
+		flusher.Flush()
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/memstore/events", nil))
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.True(t, rec.Flushed)
+	require.Equal(t, "/memstore/events", m.route)
+	require.Equal(t, "202", m.status)
+}
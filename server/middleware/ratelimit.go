@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+)
+
+// withRateLimit admits cert requests according to the rate and in-flight limits of the limiter.
+// It is placed inside the tenant middleware, which resolves the tenant used as client ID, and inside the error
+// handling middleware, which converts its errors to 429 responses,
+// and is a noop when no limit is configured.
+func withRateLimit(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	limiter *ratelimit.Limiter,
+	m metrics.Metricer,
+) func(http.ResponseWriter, *http.Request) error {
+	if limiter == nil {
+		return handleFn
+	}
+	return func(w http.ResponseWriter, r *http.Request) error {
+		release, err := limiter.Acquire(r.Method, getClientID(r))
+		if err != nil {
+			var limitErr *ratelimit.LimitExceededError
+			if errors.As(err, &limitErr) {
+				m.RecordRateLimited(r.Method, limitErr.Limit)
+			}
+			return err
+		}
+		defer release()
+		return handleFn(w, r)
+	}
+}
+
+// getClientID identifies the client making the request for per-client limits: by its tenant if the request was
+// attributed to one by its API key, and by its IP address otherwise. Unknown API keys aren't used, so that clients
+// can't get a fresh limit by sending a new key with each request. Note that behind a reverse proxy, all clients
+// without a tenant share the reverse proxy's IP address.
+func getClientID(r *http.Request) string {
+	if t := tenant.FromContext(r.Context()); t != nil {
+		return "tenant:" + t.Name
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitMetricer records the limits of the RecordRateLimited calls.
+type rateLimitMetricer struct {
+	metrics.Metricer
+	limits []string
+}
+
+func (m *rateLimitMetricer) RecordRateLimited(_ string, limit string) {
+	m.limits = append(m.limits, limit)
+}
+
+func TestWithCertMiddlewares_RateLimit(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	limiter := ratelimit.NewLimiter(ratelimit.Config{
+		Put: ratelimit.MethodConfig{PerClientRate: 0.5, PerClientBurst: 1},
+	})
+	m := &rateLimitMetricer{Metricer: metrics.NoopMetrics}
+	mw := WithCertMiddlewares(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	}, testLogger, m, limiter, nil, 0, commitments.OptimismGenericCommitmentMode)
+
+	request := func(method string, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/put", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		mw(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "10.0.0.1:1234").Code)
+	rec := request(http.MethodPost, "10.0.0.1:5678")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code, "clients are identified by their IP, not port")
+	require.Equal(t, "2", rec.Header().Get("Retry-After"))
+	require.Equal(t, []string{ratelimit.LimitPerClientRate}, m.limits)
+
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "10.0.0.2:1234").Code)
+	require.Equal(t, http.StatusOK, request(http.MethodGet, "10.0.0.1:1234").Code, "GET requests aren't limited")
+}
+
+func TestWithCertMiddlewares_RateLimitPerTenant(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	limiter := ratelimit.NewLimiter(ratelimit.Config{
+		Put: ratelimit.MethodConfig{PerClientRate: 0.5, PerClientBurst: 1},
+	})
+	keyHash := sha256.Sum256([]byte("key-a"))
+	tenants := tenant.NewRegistry(tenant.Config{
+		Tenants:        []tenant.Tenant{{Name: "rollup-a", APIKeySHA256: hex.EncodeToString(keyHash[:])}},
+		AllowAnonymous: true,
+	})
+	handler := func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	}
+	request := func(mw http.HandlerFunc, remoteAddr string, apiKey string) int {
+		req := httptest.NewRequest(http.MethodPost, "/put", nil)
+		req.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			req.Header.Set(APIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		mw(rec, req)
+		return rec.Code
+	}
+
+	t.Run("rotating keys without multi-tenancy", func(t *testing.T) {
+		mw := WithCertMiddlewares(handler, testLogger, metrics.NoopMetrics, limiter, nil, 0,
+			commitments.OptimismGenericCommitmentMode)
+		require.Equal(t, http.StatusOK, request(mw, "10.0.0.1:1234", "random-key-1"))
+		require.Equal(t, http.StatusTooManyRequests, request(mw, "10.0.0.1:1234", "random-key-2"),
+			"unvalidated API keys don't identify clients")
+	})
+
+	t.Run("tenants", func(t *testing.T) {
+		mw := WithCertMiddlewares(handler, testLogger, metrics.NoopMetrics, limiter, tenants, 0,
+			commitments.OptimismGenericCommitmentMode)
+		require.Equal(t, http.StatusOK, request(mw, "10.0.1.1:1234", "key-a"))
+		require.Equal(t, http.StatusTooManyRequests, request(mw, "10.0.1.2:1234", "key-a"),
+			"the requests of a tenant share its limits across IPs")
+		require.Equal(t, http.StatusUnauthorized, request(mw, "10.0.1.3:1234", "random-key"),
+			"unknown API keys are rejected before being rate limited")
+		require.Equal(t, http.StatusOK, request(mw, "10.0.1.3:1234", ""))
+		require.Equal(t, http.StatusTooManyRequests, request(mw, "10.0.1.3:1234", ""))
+	})
+}
@@ -3,22 +3,43 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"regexp"
+
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
+	"github.com/google/uuid"
 )
 
+// RequestIDHeader is the header from which the request ID is read, if sent by the client,
+// and in which it is returned in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID restricts client-provided request IDs, which end up in logs and error bodies.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
 // withRequestContext initializes the request context (outermost middleware)
 func withRequestContext(
 	handleFn func(http.ResponseWriter, *http.Request),
 ) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
 		requestContext := &RequestContext{
+			RequestID: requestID,
 			// CertVersion is only known and set after parsing the request,
 			// so we initialize it to a default value.
 			// TODO: should this flow via some other means..?
 			CertVersion: "unknown",
 		}
 
-		// Add context to request
-		rWithRequestContext := r.WithContext(context.WithValue(r.Context(), RequestContextKey, requestContext))
+		// Add context to request. The request ID is also stored on its own,
+		// such that code outside of the server (e.g. stores) can log it via [proxy_logging.FromContext].
+		ctx := context.WithValue(r.Context(), RequestContextKey, requestContext)
+		ctx = proxy_logging.ContextWithRequestID(ctx, requestID)
+		rWithRequestContext := r.WithContext(ctx)
 
 		handleFn(w, rWithRequestContext)
 
@@ -29,7 +50,11 @@ func withRequestContext(
 
 // RequestContext holds request-specific data that middlewares need to share
 type RequestContext struct {
+	RequestID   string
 	CertVersion string
+	// Tenant is the name of the tenant making the request, if multi-tenancy is enabled.
+	// It is set by the tenant middleware once the request's API key is resolved.
+	Tenant string
 }
 
 // ContextKey is used to store CertVersion in the request context
@@ -61,3 +86,27 @@ func getCertVersion(r *http.Request) string {
 	}
 	return "unknown"
 }
+
+// setTenant is private because the tenant is resolved by the tenant middleware.
+func setTenant(r *http.Request, tenant string) {
+	if ctx := getRequestContext(r); ctx != nil {
+		ctx.Tenant = tenant
+	}
+}
+
+// getTenant returns the name of the tenant making the request, or "" for anonymous requests.
+func getTenant(r *http.Request) string {
+	if ctx := getRequestContext(r); ctx != nil {
+		return ctx.Tenant
+	}
+	return ""
+}
+
+// getRequestID is private because it is only used by the middlewares.
+// Handlers and stores should use [proxy_logging.RequestIDFromContext] instead.
+func getRequestID(r *http.Request) string {
+	if ctx := getRequestContext(r); ctx != nil {
+		return ctx.RequestID
+	}
+	return ""
+}
@@ -1,12 +1,14 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/stretchr/testify/require"
@@ -32,6 +34,9 @@ func TestRequestContext_CertVersionCanBeReadFromMetricsMiddleware(t *testing.T)
 		handler,
 		testLogger,
 		mockMetrics,
+		nil,
+		nil,
+		0,
 		commitments.OptimismGenericCommitmentMode,
 	)
 
@@ -44,6 +49,49 @@ func TestRequestContext_CertVersionCanBeReadFromMetricsMiddleware(t *testing.T)
 		"The cert version should be captured in the metrics middleware")
 }
 
+func TestRequestContext_RequestIDPropagation(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+	tests := []struct {
+		name            string
+		headerRequestID string
+		expectGenerated bool
+	}{
+		{name: "generated when missing", headerRequestID: "", expectGenerated: true},
+		{name: "taken from header", headerRequestID: "batcher-1234", expectGenerated: false},
+		{name: "invalid header is replaced", headerRequestID: "bad id\nwith newline", expectGenerated: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var handlerRequestID string
+			handler := func(w http.ResponseWriter, r *http.Request) error {
+				handlerRequestID = proxy_logging.RequestIDFromContext(r.Context())
+				return errors.New("unexpected error")
+			}
+			mw := WithCertMiddlewares(handler, testLogger, &MockMetricer{}, nil, nil, 0, commitments.StandardCommitmentMode)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tc.headerRequestID != "" {
+				req.Header.Set(RequestIDHeader, tc.headerRequestID)
+			}
+			rec := httptest.NewRecorder()
+			mw(rec, req)
+
+			requestID := rec.Header().Get(RequestIDHeader)
+			require.NotEmpty(t, requestID)
+			if tc.expectGenerated {
+				require.NotEqual(t, tc.headerRequestID, requestID)
+			} else {
+				require.Equal(t, tc.headerRequestID, requestID)
+			}
+			require.Equal(t, requestID, handlerRequestID, "request ID should be available to the handler's context")
+			require.Equal(t, http.StatusInternalServerError, rec.Code)
+			require.Contains(t, rec.Body.String(), requestID, "request ID should be part of the error body")
+		})
+	}
+}
+
 // Mock implementation of the Metricer interface.
 // Only used to make sure that the call to recordDur(strconv.Itoa(scw.status), string(mode), certVersion)
 // in the metrics middleware contains the correct cert version.
@@ -61,9 +109,28 @@ func (m *MockMetricer) RecordRPCServerRequest(method string) func(status string,
 		m.recordDurCertVersion = ver // Capture the cert version
 	}
 }
+func (m *MockMetricer) RecordHTTPRouteRequest(method string, route string) func(status string) {
+	return func(status string) {}
+}
+func (m *MockMetricer) RecordRateLimited(method string, limit string) {}
 func (m *MockMetricer) RecordSecondaryRequest(bt string, method string) func(status string) {
 	return func(status string) {}
 }
+func (m *MockMetricer) RecordMemstoreSize(bt string, entries int, sizeBytes uint64)   {}
+func (m *MockMetricer) RecordMemstoreEviction(bt string, reason string)               {}
+func (m *MockMetricer) RecordCertVerificationCacheLookup(hit bool, savedRPCCalls int) {}
+func (m *MockMetricer) RecordDispersalStage(bt string, stage string) func()           { return func() {} }
+func (m *MockMetricer) RecordDispersalRetry(bt string)                                {}
+func (m *MockMetricer) RecordRetrieval(bt string, retriever string) func(status string) {
+	return func(status string) {}
+}
+func (m *MockMetricer) RecordVerificationStage(bt string, stage string) func()                     { return func() {} }
+func (m *MockMetricer) RecordPayloadSize(method string, mode string, sizeBytes int)                {}
+func (m *MockMetricer) RecordReservationUsage(usedSymbols uint64, capacitySymbols uint64)          {}
+func (m *MockMetricer) RecordOnDemandBalance(remainingWei float64, secondsUntilExhaustion float64) {}
+func (m *MockMetricer) RecordTenantRequest(tenant string, method string, mode string, status string) {
+}
+func (m *MockMetricer) RecordTenantPayloadBytes(tenant string, method string, sizeBytes int) {}
 func (m *MockMetricer) Document() []opmetrics.DocumentedMetric {
 	return nil
 }
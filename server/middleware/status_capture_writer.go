@@ -30,3 +30,16 @@ func newStatusCaptureWriter(w http.ResponseWriter) *statusCaptureWriter {
 		status: http.StatusOK,
 	}
 }
+
+// Flush implements [http.Flusher], such that streaming handlers (e.g. server-sent events)
+// keep working when wrapped in middlewares.
+func (scw *statusCaptureWriter) Flush() {
+	if flusher, ok := scw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped ResponseWriter, which is used by [http.ResponseController].
+func (scw *statusCaptureWriter) Unwrap() http.ResponseWriter {
+	return scw.ResponseWriter
+}
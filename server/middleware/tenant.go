@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+)
+
+// APIKeyHeader is the header from which the API key of the tenant is read.
+// The API key can also be sent as a bearer token in the Authorization header.
+const APIKeyHeader = "X-API-Key"
+
+// withTenant resolves the tenant of the request from its API key, and adds it to the request's context, so that
+// the rate limit and quota middlewares and the stores can use it.
+// It is placed inside the error handling middleware, which converts its errors to 401 responses,
+// and is a noop when multi-tenancy is disabled.
+func withTenant(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	tenants *tenant.Registry,
+) func(http.ResponseWriter, *http.Request) error {
+	if !tenants.Enabled() {
+		return handleFn
+	}
+	return func(w http.ResponseWriter, r *http.Request) error {
+		t, err := tenants.Resolve(getAPIKey(r))
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			// anonymous request
+			return handleFn(w, r)
+		}
+		setTenant(r, t.Name)
+		return handleFn(w, r.WithContext(tenant.ContextWithTenant(r.Context(), t)))
+	}
+}
+
+// withTenantQuota admits the requests of the tenant resolved by withTenant according to the tenant's allowed
+// commitment modes and quota. It is placed inside the rate limit middleware, so that rate limited requests
+// don't count against the quota.
+// It is placed inside the error handling middleware, which converts its errors to 403 and 429 responses,
+// and is a noop when multi-tenancy is disabled.
+func withTenantQuota(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	tenants *tenant.Registry,
+	m metrics.Metricer,
+	mode commitments.CommitmentMode,
+) func(http.ResponseWriter, *http.Request) error {
+	if !tenants.Enabled() {
+		return handleFn
+	}
+	return func(w http.ResponseWriter, r *http.Request) error {
+		t := tenant.FromContext(r.Context())
+		if t == nil {
+			// anonymous request
+			return handleFn(w, r)
+		}
+
+		var payloadBytes int64
+		if r.Method == http.MethodPost && r.ContentLength > 0 {
+			payloadBytes = r.ContentLength
+		}
+		err := tenants.Admit(t, mode, payloadBytes)
+		if err != nil {
+			return err
+		}
+
+		if r.Method == http.MethodPost && r.Body != nil {
+			body := &countingReadCloser{ReadCloser: r.Body}
+			r.Body = body
+			defer func() {
+				tenants.RecordBytes(t, body.n)
+				m.RecordTenantPayloadBytes(t.Name, r.Method, int(body.n))
+			}()
+		}
+		return handleFn(w, r)
+	}
+}
+
+// getAPIKey returns the API key sent in the X-API-Key header, or as a bearer token.
+func getAPIKey(r *http.Request) string {
+	if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+		return apiKey
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+// countingReadCloser counts the bytes read from the request body, which is the size of the posted payload.
+type countingReadCloser struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantMetricer records the labels of the tenant metrics calls.
+type tenantMetricer struct {
+	metrics.Metricer
+	statuses     []string
+	payloadBytes int
+}
+
+func (m *tenantMetricer) RecordTenantRequest(_ string, _ string, _ string, status string) {
+	m.statuses = append(m.statuses, status)
+}
+
+func (m *tenantMetricer) RecordTenantPayloadBytes(_ string, _ string, sizeBytes int) {
+	m.payloadBytes += sizeBytes
+}
+
+func TestWithCertMiddlewares_Tenants(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	keyHash := sha256.Sum256([]byte("key-a"))
+	tenants := tenant.NewRegistry(tenant.Config{Tenants: []tenant.Tenant{{
+		Name:                   "rollup-a",
+		APIKeySHA256:           hex.EncodeToString(keyHash[:]),
+		AllowedCommitmentModes: []commitments.CommitmentMode{commitments.OptimismGenericCommitmentMode},
+		Quota:                  tenant.Quota{Window: time.Hour, MaxRequests: 2},
+	}}})
+	m := &tenantMetricer{Metricer: metrics.NoopMetrics}
+
+	var handledTenant *tenant.Tenant
+	handler := func(w http.ResponseWriter, r *http.Request) error {
+		handledTenant = tenant.FromContext(r.Context())
+		_, err := io.ReadAll(r.Body)
+		return err
+	}
+	generic := WithCertMiddlewares(handler, testLogger, m, nil, tenants, 0, commitments.OptimismGenericCommitmentMode)
+	standard := WithCertMiddlewares(handler, testLogger, m, nil, tenants, 0, commitments.StandardCommitmentMode)
+
+	post := func(mw http.HandlerFunc, header string, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/put", strings.NewReader("payload"))
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		mw(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusUnauthorized, post(generic, "", "").Code)
+	require.Equal(t, http.StatusUnauthorized, post(generic, APIKeyHeader, "key-b").Code)
+	require.Nil(t, handledTenant)
+
+	require.Equal(t, http.StatusOK, post(generic, APIKeyHeader, "key-a").Code)
+	require.NotNil(t, handledTenant)
+	require.Equal(t, "rollup-a", handledTenant.Name)
+	require.Equal(t, len("payload"), m.payloadBytes)
+
+	require.Equal(t, http.StatusForbidden, post(standard, "Authorization", "Bearer key-a").Code)
+
+	require.Equal(t, http.StatusOK, post(generic, "Authorization", "Bearer key-a").Code)
+	rec := post(generic, APIKeyHeader, "key-a")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	require.Equal(t, []string{"200", "403", "200", "429"}, m.statuses)
+}
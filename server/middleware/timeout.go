@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+)
+
+const (
+	// RequestTimeoutHeader is the header with which clients can request a deadline for their request,
+	// as a duration string (e.g. "30s"). The timeout query param takes precedence over it.
+	RequestTimeoutHeader = "X-Request-Timeout"
+	// requestTimeoutQueryParam is the query param with which clients can request a deadline for their request.
+	requestTimeoutQueryParam = "timeout"
+)
+
+// withRequestTimeout sets a deadline on the request's context, which is propagated to the storage backends
+// (e.g. the EigenDA disperser and relays). The deadline is the one requested by the client,
+// capped by maxTimeout when it is set. Expired deadlines are converted to 504 responses by the error handling
+// middleware.
+func withRequestTimeout(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	maxTimeout time.Duration,
+) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		timeout, err := getRequestTimeout(r)
+		if err != nil {
+			return err
+		}
+		if maxTimeout > 0 && (timeout == 0 || timeout > maxTimeout) {
+			timeout = maxTimeout
+		}
+		if timeout == 0 {
+			return handleFn(w, r)
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		return handleFn(w, r.WithContext(ctx))
+	}
+}
+
+// getRequestTimeout returns the timeout requested by the client, or 0 if it didn't request any.
+func getRequestTimeout(r *http.Request) (time.Duration, error) {
+	timeoutStr := r.URL.Query().Get(requestTimeoutQueryParam)
+	if timeoutStr == "" {
+		timeoutStr = r.Header.Get(RequestTimeoutHeader)
+	}
+	if timeoutStr == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		return 0, proxyerrors.NewParsingError(fmt.Errorf("invalid request timeout %q: %w", timeoutStr, err))
+	}
+	if timeout <= 0 {
+		return 0, proxyerrors.NewParsingError(fmt.Errorf("request timeout must be positive, got %s", timeout))
+	}
+	return timeout, nil
+}
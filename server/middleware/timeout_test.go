@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCertMiddlewares_Timeout(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+	var remaining time.Duration
+	handler := func(w http.ResponseWriter, r *http.Request) error {
+		deadline, ok := r.Context().Deadline()
+		if !ok {
+			remaining = 0
+			return nil
+		}
+		remaining = time.Until(deadline)
+		if r.URL.Query().Has("expire") {
+			<-r.Context().Done()
+			return r.Context().Err()
+		}
+		return nil
+	}
+	request := func(timeout time.Duration, url string, header string) int {
+		mw := WithCertMiddlewares(handler, testLogger, metrics.NoopMetrics, nil, nil, timeout,
+			commitments.OptimismGenericCommitmentMode)
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if header != "" {
+			req.Header.Set(RequestTimeoutHeader, header)
+		}
+		rec := httptest.NewRecorder()
+		mw(rec, req)
+		return rec.Code
+	}
+
+	tests := []struct {
+		name         string
+		routeTimeout time.Duration
+		url          string
+		header       string
+		expectedCode int
+		expected     time.Duration
+	}{
+		{name: "no deadline", url: "/get", expectedCode: http.StatusOK},
+		{name: "route deadline", routeTimeout: time.Minute, url: "/get", expectedCode: http.StatusOK,
+			expected: time.Minute},
+		{name: "query param", url: "/get?timeout=10s", expectedCode: http.StatusOK, expected: 10 * time.Second},
+		{name: "header", url: "/get", header: "20s", expectedCode: http.StatusOK, expected: 20 * time.Second},
+		{name: "query param takes precedence", url: "/get?timeout=10s", header: "20s", expectedCode: http.StatusOK,
+			expected: 10 * time.Second},
+		{name: "capped by route deadline", routeTimeout: time.Minute, url: "/get?timeout=1h",
+			expectedCode: http.StatusOK, expected: time.Minute},
+		{name: "invalid", url: "/get?timeout=soon", expectedCode: http.StatusBadRequest},
+		{name: "negative", url: "/get", header: "-1s", expectedCode: http.StatusBadRequest},
+		{name: "expired", url: "/get?timeout=1ms&expire", expectedCode: http.StatusGatewayTimeout,
+			expected: time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining = 0
+			require.Equal(t, tt.expectedCode, request(tt.routeTimeout, tt.url, tt.header))
+			require.InDelta(t, tt.expected, remaining, float64(time.Second))
+		})
+	}
+}
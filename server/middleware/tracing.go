@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withTracing is a middleware that starts the root server span of each request, which all spans created
+// further down the stack (store manager, secondary stores, EigenDA backends) are children of.
+// A W3C traceparent header sent by the client is honored, making the span a child of the client's span.
+func withTracing(
+	handleFn func(http.ResponseWriter, *http.Request),
+	mode commitments.CommitmentMode,
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		route := getRouteTemplate(r)
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+		if mode != noCommitmentMode {
+			span.SetAttributes(attribute.String("commitment_mode", string(mode)))
+		}
+
+		scw := newStatusCaptureWriter(w)
+		handleFn(scw, r.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.response.status_code", scw.status))
+		if mode != noCommitmentMode {
+			span.SetAttributes(attribute.String("cert_version", getCertVersion(r)))
+		}
+		if scw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(scw.status))
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTracing_ContinuesTraceFromTraceparentHeader(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	const (
+		clientTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		clientSpanID  = "00f067aa0ba902b7"
+	)
+
+	// the handler creates a child span, as the store manager would
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, span := tracing.Start(r.Context(), "child")
+		span.End()
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/put/{commitment}", withTracing(handler, commitments.StandardCommitmentMode))
+
+	req := httptest.NewRequest(http.MethodPost, "/put/0x1234", nil)
+	req.Header.Set("traceparent", "00-"+clientTraceID+"-"+clientSpanID+"-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, server := spans[0], spans[1]
+
+	require.Equal(t, "POST /put/{commitment}", server.Name(), "span name should use the route template")
+	require.Equal(t, trace.SpanKindServer, server.SpanKind())
+	require.Equal(t, clientTraceID, server.SpanContext().TraceID().String())
+	require.Equal(t, clientSpanID, server.Parent().SpanID().String())
+	require.Equal(t, codes.Error, server.Status().Code)
+
+	require.Equal(t, clientTraceID, child.SpanContext().TraceID().String())
+	require.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+}
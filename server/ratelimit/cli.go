@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	putPrefix = "put"
+	getPrefix = "get"
+)
+
+func withFlagPrefix(method, s string) string {
+	return "ratelimit." + method + "." + s
+}
+
+func withEnvPrefix(envPrefix, method, s string) []string {
+	return []string{envPrefix + "_RATELIMIT_" + strings.ToUpper(method) + "_" + s}
+}
+
+// CLIFlags ... used for configuring the limits applied to cert requests
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	var flags []cli.Flag
+	for _, method := range []string{putPrefix, getPrefix} {
+		routes := "dispersal (POST /put)"
+		if method == getPrefix {
+			routes = "retrieval (GET /get)"
+		}
+		flags = append(flags,
+			&cli.Float64Flag{
+				Name:     withFlagPrefix(method, "rate"),
+				Usage:    fmt.Sprintf("Requests per second admitted across all clients for %s routes. 0 disables.", routes),
+				EnvVars:  withEnvPrefix(envPrefix, method, "RATE"),
+				Category: category,
+			},
+			&cli.IntFlag{
+				Name:     withFlagPrefix(method, "burst"),
+				Usage:    fmt.Sprintf("Burst of requests admitted across all clients for %s routes.", routes),
+				Value:    1,
+				EnvVars:  withEnvPrefix(envPrefix, method, "BURST"),
+				Category: category,
+			},
+			&cli.Float64Flag{
+				Name: withFlagPrefix(method, "per-client-rate"),
+				Usage: fmt.Sprintf("Requests per second admitted per client for %s routes. Clients are identified "+
+					"by their tenant when their API key is one of a tenant, and by their IP address otherwise. 0 disables.",
+					routes),
+				EnvVars:  withEnvPrefix(envPrefix, method, "PER_CLIENT_RATE"),
+				Category: category,
+			},
+			&cli.IntFlag{
+				Name:     withFlagPrefix(method, "per-client-burst"),
+				Usage:    fmt.Sprintf("Burst of requests admitted per client for %s routes.", routes),
+				Value:    1,
+				EnvVars:  withEnvPrefix(envPrefix, method, "PER_CLIENT_BURST"),
+				Category: category,
+			},
+			&cli.IntFlag{
+				Name:     withFlagPrefix(method, "max-in-flight"),
+				Usage:    fmt.Sprintf("Max concurrent requests across all clients for %s routes. 0 disables.", routes),
+				EnvVars:  withEnvPrefix(envPrefix, method, "MAX_IN_FLIGHT"),
+				Category: category,
+			},
+			&cli.IntFlag{
+				Name:     withFlagPrefix(method, "per-client-max-in-flight"),
+				Usage:    fmt.Sprintf("Max concurrent requests per client for %s routes. 0 disables.", routes),
+				EnvVars:  withEnvPrefix(envPrefix, method, "PER_CLIENT_MAX_IN_FLIGHT"),
+				Category: category,
+			},
+		)
+	}
+	return flags
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Put: readMethodConfig(ctx, putPrefix),
+		Get: readMethodConfig(ctx, getPrefix),
+	}
+}
+
+func readMethodConfig(ctx *cli.Context, method string) MethodConfig {
+	return MethodConfig{
+		Rate:                 ctx.Float64(withFlagPrefix(method, "rate")),
+		Burst:                ctx.Int(withFlagPrefix(method, "burst")),
+		PerClientRate:        ctx.Float64(withFlagPrefix(method, "per-client-rate")),
+		PerClientBurst:       ctx.Int(withFlagPrefix(method, "per-client-burst")),
+		MaxInFlight:          ctx.Int(withFlagPrefix(method, "max-in-flight")),
+		PerClientMaxInFlight: ctx.Int(withFlagPrefix(method, "per-client-max-in-flight")),
+	}
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import "fmt"
+
+// Config ... configures the limits applied to cert requests, separately for dispersals (POST /put)
+// and retrievals (GET /get). All limits are disabled by default.
+type Config struct {
+	Put MethodConfig
+	Get MethodConfig
+}
+
+// MethodConfig ... configures the limits of one type of cert request. A zero value disables a limit.
+type MethodConfig struct {
+	// Rate is the number of requests per second admitted across all clients, with bursts of up to Burst requests.
+	Rate  float64
+	Burst int
+	// PerClientRate is the number of requests per second admitted per client, with bursts of up to PerClientBurst
+	// requests. Clients are identified by their tenant when their API key is one of a tenant, and by their IP
+	// address otherwise.
+	PerClientRate  float64
+	PerClientBurst int
+	// MaxInFlight is the number of requests processed concurrently across all clients.
+	MaxInFlight int
+	// PerClientMaxInFlight is the number of requests processed concurrently per client.
+	PerClientMaxInFlight int
+}
+
+// Enabled returns true if any limit is set
+func (c MethodConfig) Enabled() bool {
+	return c.Rate > 0 || c.PerClientRate > 0 || c.MaxInFlight > 0 || c.PerClientMaxInFlight > 0
+}
+
+// Check checks config invariants, and returns an error if there is a problem with the config struct
+func (c Config) Check() error {
+	if err := c.Put.check(); err != nil {
+		return fmt.Errorf("put limits: %w", err)
+	}
+	if err := c.Get.check(); err != nil {
+		return fmt.Errorf("get limits: %w", err)
+	}
+	return nil
+}
+
+func (c MethodConfig) check() error {
+	if c.Rate < 0 || c.PerClientRate < 0 {
+		return fmt.Errorf("rates must be >= 0")
+	}
+	if c.MaxInFlight < 0 || c.PerClientMaxInFlight < 0 {
+		return fmt.Errorf("max in-flight requests must be >= 0")
+	}
+	if c.Rate > 0 && c.Burst < 1 {
+		return fmt.Errorf("burst must be >= 1 when rate is set, got %d", c.Burst)
+	}
+	if c.PerClientRate > 0 && c.PerClientBurst < 1 {
+		return fmt.Errorf("per-client burst must be >= 1 when per-client rate is set, got %d", c.PerClientBurst)
+	}
+	return nil
+}
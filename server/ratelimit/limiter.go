@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limits reported by [LimitExceededError], and used as label of the rate limiting metrics.
+const (
+	LimitRate                 = "rate"
+	LimitPerClientRate        = "per_client_rate"
+	LimitMaxInFlight          = "max_in_flight"
+	LimitPerClientMaxInFlight = "per_client_max_in_flight"
+)
+
+// inFlightRetryAfter is the delay after which clients rejected by an in-flight limit are told to retry.
+// Unlike for rates, there is no way to know when an in-flight request will complete.
+const inFlightRetryAfter = time.Second
+
+// sweepInterval is the interval at which the state of idle clients is dropped.
+const sweepInterval = time.Minute
+
+// LimitExceededError is returned when a request is rejected by one of the limits.
+type LimitExceededError struct {
+	Limit string
+	// RetryAfter is the delay after which the request would be admitted, if no other request is made in between
+	RetryAfter time.Duration
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s limit exceeded, retry in %s", e.Limit, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Limiter ... admits cert requests according to the configured rates and in-flight limits.
+// A nil Limiter is valid and admits every request.
+type Limiter struct {
+	put *methodLimiter
+	get *methodLimiter
+}
+
+// NewLimiter ... constructor. The limiter admits every request while no limit is configured,
+// and can be reconfigured with [Limiter.Update].
+func NewLimiter(cfg Config) *Limiter {
+	return &Limiter{
+		put: newMethodLimiter(cfg.Put, time.Now),
+		get: newMethodLimiter(cfg.Get, time.Now),
+	}
+}
+
+// Acquire admits a request made with the http method by the client, or returns a [LimitExceededError].
+// The returned release function must be called once the request completes.
+func (l *Limiter) Acquire(method string, clientID string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	switch method {
+	case http.MethodPost, http.MethodPut:
+		return l.put.acquire(clientID)
+	case http.MethodGet:
+		return l.get.acquire(clientID)
+	default:
+		return func() {}, nil
+	}
+}
+
+// Update replaces the limits of the limiter. Requests in flight keep counting towards the new in-flight limits,
+// while the rate limits start over with full buckets.
+func (l *Limiter) Update(cfg Config) {
+	l.put.update(cfg.Put)
+	l.get.update(cfg.Get)
+}
+
+// methodLimiter ... enforces the limits of one type of cert request
+type methodLimiter struct {
+	cfg MethodConfig
+	now func() time.Time
+
+	mu sync.Mutex
+	// bucket is nil when Rate is 0
+	bucket   *tokenBucket
+	clients  map[string]*clientState
+	inFlight int
+
+	lastSweep time.Time
+}
+
+// clientState ... holds the per-client limits state of a client
+type clientState struct {
+	// bucket is nil when PerClientRate is 0
+	bucket   *tokenBucket
+	inFlight int
+}
+
+func newMethodLimiter(cfg MethodConfig, now func() time.Time) *methodLimiter {
+	l := &methodLimiter{
+		cfg:       cfg,
+		now:       now,
+		clients:   make(map[string]*clientState),
+		lastSweep: now(),
+	}
+	if cfg.Rate > 0 {
+		l.bucket = newTokenBucket(cfg.Rate, cfg.Burst, now())
+	}
+	return l
+}
+
+func (l *methodLimiter) acquire(clientID string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.cfg.Enabled() {
+		return func() {}, nil
+	}
+	now := l.now()
+	l.sweep(now)
+
+	client := l.clients[clientID]
+	if client == nil {
+		client = &clientState{}
+		if l.cfg.PerClientRate > 0 {
+			client.bucket = newTokenBucket(l.cfg.PerClientRate, l.cfg.PerClientBurst, now)
+		}
+		l.clients[clientID] = client
+	}
+
+	// in-flight limits are checked first, so that rejected requests don't consume tokens
+	if l.cfg.MaxInFlight > 0 && l.inFlight >= l.cfg.MaxInFlight {
+		return nil, &LimitExceededError{Limit: LimitMaxInFlight, RetryAfter: inFlightRetryAfter}
+	}
+	if l.cfg.PerClientMaxInFlight > 0 && client.inFlight >= l.cfg.PerClientMaxInFlight {
+		return nil, &LimitExceededError{Limit: LimitPerClientMaxInFlight, RetryAfter: inFlightRetryAfter}
+	}
+	// the per-client bucket is checked before the global one, so that a client exceeding its own rate
+	// doesn't consume the tokens of other clients
+	if client.bucket != nil {
+		if wait := client.bucket.wait(now); wait > 0 {
+			return nil, &LimitExceededError{Limit: LimitPerClientRate, RetryAfter: wait}
+		}
+	}
+	if l.bucket != nil {
+		if wait := l.bucket.wait(now); wait > 0 {
+			return nil, &LimitExceededError{Limit: LimitRate, RetryAfter: wait}
+		}
+		l.bucket.take()
+	}
+	if client.bucket != nil {
+		client.bucket.take()
+	}
+
+	l.inFlight++
+	client.inFlight++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.inFlight--
+			client.inFlight--
+		})
+	}, nil
+}
+
+func (l *methodLimiter) update(cfg MethodConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.cfg = cfg
+	l.bucket = nil
+	if cfg.Rate > 0 {
+		l.bucket = newTokenBucket(cfg.Rate, cfg.Burst, now)
+	}
+	for _, client := range l.clients {
+		client.bucket = nil
+		if cfg.PerClientRate > 0 {
+			client.bucket = newTokenBucket(cfg.PerClientRate, cfg.PerClientBurst, now)
+		}
+	}
+}
+
+// sweep drops the state of the clients without requests in flight and whose bucket is full,
+// which is equivalent to a fresh state. This bounds the memory used by clients that stopped making requests.
+// Must be called with mu held.
+func (l *methodLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for id, client := range l.clients {
+		if client.inFlight == 0 && (client.bucket == nil || client.bucket.full(now)) {
+			delete(l.clients, id)
+		}
+	}
+}
+
+// tokenBucket ... holds up to burst tokens, refilled at rate tokens per second.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// wait returns the delay until a token is available, 0 if one is available now.
+func (b *tokenBucket) wait(now time.Time) time.Duration {
+	b.refill(now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// take consumes a token. [tokenBucket.wait] must have returned 0 first.
+func (b *tokenBucket) take() {
+	b.tokens--
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
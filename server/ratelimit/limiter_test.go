@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireLimitExceeded(t *testing.T, err error, limit string) *LimitExceededError {
+	t.Helper()
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, limit, limitErr.Limit)
+	return limitErr
+}
+
+func TestMethodLimiter_Rates(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	l := newMethodLimiter(MethodConfig{Rate: 2, Burst: 3, PerClientRate: 1, PerClientBurst: 2},
+		func() time.Time { return now })
+
+	// client a exhausts its own burst, without exhausting the global one
+	for range 2 {
+		_, err := l.acquire("a")
+		require.NoError(t, err)
+	}
+	_, err := l.acquire("a")
+	limitErr := requireLimitExceeded(t, err, LimitPerClientRate)
+	require.Equal(t, time.Second, limitErr.RetryAfter)
+
+	// client b takes the last global token
+	_, err = l.acquire("b")
+	require.NoError(t, err)
+	_, err = l.acquire("b")
+	limitErr = requireLimitExceeded(t, err, LimitRate)
+	require.Equal(t, 500*time.Millisecond, limitErr.RetryAfter)
+
+	// tokens are refilled over time
+	now = now.Add(500 * time.Millisecond)
+	_, err = l.acquire("b")
+	require.NoError(t, err)
+}
+
+func TestMethodLimiter_InFlight(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	l := newMethodLimiter(MethodConfig{MaxInFlight: 2, PerClientMaxInFlight: 1}, func() time.Time { return now })
+
+	releaseA, err := l.acquire("a")
+	require.NoError(t, err)
+	_, err = l.acquire("a")
+	requireLimitExceeded(t, err, LimitPerClientMaxInFlight)
+
+	releaseB, err := l.acquire("b")
+	require.NoError(t, err)
+	_, err = l.acquire("c")
+	requireLimitExceeded(t, err, LimitMaxInFlight)
+
+	releaseA()
+	releaseA() // releasing twice is a noop
+	_, err = l.acquire("c")
+	require.NoError(t, err)
+	_, err = l.acquire("a")
+	requireLimitExceeded(t, err, LimitMaxInFlight)
+	releaseB()
+}
+
+func TestMethodLimiter_SweepsIdleClients(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	l := newMethodLimiter(MethodConfig{PerClientRate: 1, PerClientBurst: 1}, func() time.Time { return now })
+
+	release, err := l.acquire("a")
+	require.NoError(t, err)
+	release()
+	_, err = l.acquire("b")
+	require.NoError(t, err)
+	require.Len(t, l.clients, 2)
+
+	now = now.Add(sweepInterval)
+	_, err = l.acquire("b")
+	require.NoError(t, err)
+	require.Len(t, l.clients, 1, "client a has no request in flight and a full bucket")
+}
+
+func TestLimiter_Methods(t *testing.T) {
+	release, err := (*Limiter)(nil).Acquire(http.MethodPost, "a")
+	require.NoError(t, err)
+	release()
+	release, err = NewLimiter(Config{}).Acquire(http.MethodPost, "a")
+	require.NoError(t, err, "no limit configured")
+	release()
+
+	l := NewLimiter(Config{Put: MethodConfig{MaxInFlight: 1}})
+	_, err = l.Acquire(http.MethodPost, "a")
+	require.NoError(t, err)
+	_, err = l.Acquire(http.MethodPost, "b")
+	requireLimitExceeded(t, err, LimitMaxInFlight)
+	_, err = l.Acquire(http.MethodGet, "a")
+	require.NoError(t, err, "GET requests have their own limits")
+}
+
+func TestLimiter_Update(t *testing.T) {
+	l := NewLimiter(Config{})
+	release, err := l.Acquire(http.MethodPost, "a")
+	require.NoError(t, err)
+	release()
+
+	l.Update(Config{Put: MethodConfig{MaxInFlight: 1}})
+	release, err = l.Acquire(http.MethodPost, "a")
+	require.NoError(t, err)
+	_, err = l.Acquire(http.MethodPost, "b")
+	requireLimitExceeded(t, err, LimitMaxInFlight)
+
+	l.Update(Config{Put: MethodConfig{MaxInFlight: 2}})
+	_, err = l.Acquire(http.MethodPost, "b")
+	require.NoError(t, err, "the request in flight counts towards the new limit")
+	_, err = l.Acquire(http.MethodPost, "c")
+	requireLimitExceeded(t, err, LimitMaxInFlight)
+
+	release()
+	l.Update(Config{})
+	_, err = l.Acquire(http.MethodPost, "c")
+	require.NoError(t, err, "limits removed")
+}
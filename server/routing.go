@@ -24,7 +24,15 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		"{optional_prefix:(?:0x)?}"+ // commitments can be prefixed with 0x
 		"{"+routingVarNameVersionByteHex+":[0-9a-fA-F]{2}}"+ // should always be 0x00 for now but we let others through to return a 404
 		"{"+routingVarNamePayloadHex+":[0-9a-fA-F]*}",
-		middleware.WithCertMiddlewares(svr.handleGetStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
+		middleware.WithCertMiddlewares(
+			svr.handleGetStdCommitment,
+			svr.log,
+			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.GetTimeout,
+			commitments.StandardCommitmentMode,
+		),
 	).Queries("commitment_mode", "standard")
 	// op keccak256 commitments (write to S3)
 	subrouterGET.HandleFunc(
@@ -36,6 +44,9 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.handleGetOPKeccakCommitment,
 			svr.log,
 			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.GetTimeout,
 			commitments.OptimismKeccakCommitmentMode,
 		),
 	)
@@ -51,6 +62,9 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.handleGetOPGenericCommitment,
 			svr.log,
 			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.GetTimeout,
 			commitments.OptimismGenericCommitmentMode,
 		),
 	)
@@ -72,7 +86,15 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 	subrouterPOST := r.Methods("POST").PathPrefix("/put").Subrouter()
 	// std commitments (for nitro)
 	subrouterPOST.HandleFunc("", // commitment is calculated by the server using the body data
-		middleware.WithCertMiddlewares(svr.handlePostStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
+		middleware.WithCertMiddlewares(
+			svr.handlePostStdCommitment,
+			svr.log,
+			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.PutTimeout,
+			commitments.StandardCommitmentMode,
+		),
 	).Queries("commitment_mode", "standard")
 	// op keccak256 commitments (write to S3)
 	subrouterPOST.HandleFunc(
@@ -84,6 +106,9 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.handlePostOPKeccakCommitment,
 			svr.log,
 			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.PutTimeout,
 			commitments.OptimismKeccakCommitmentMode,
 		),
 	)
@@ -94,6 +119,9 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.handlePostOPGenericCommitment,
 			svr.log,
 			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.PutTimeout,
 			commitments.OptimismGenericCommitmentMode,
 		),
 	)
@@ -103,16 +131,18 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.handlePostOPGenericCommitment,
 			svr.log,
 			svr.m,
+			svr.limiter,
+			svr.config.Tenants,
+			svr.config.PutTimeout,
 			commitments.OptimismGenericCommitmentMode,
 		),
 	)
 
-	// TODO: should prob setup metrics middlewares to also work for the below routes...
-	// right now they only work for the main GET/POST routes.
-	r.HandleFunc("/health", svr.handleHealth).Methods("GET")
+	r.HandleFunc("/health", middleware.WithMiddlewares(svr.handleHealth, svr.log, svr.m)).Methods("GET")
+	r.HandleFunc("/version", middleware.WithMiddlewares(svr.handleGetVersion, svr.log, svr.m)).Methods("GET")
 
 	// this is done to explicitly log capture potential redirect errors
-	r.HandleFunc("/put", svr.logDispersalGetError).Methods("GET")
+	r.HandleFunc("/put", middleware.WithMiddlewares(svr.logDispersalGetError, svr.log, svr.m)).Methods("GET")
 
 	// Only register admin endpoints if explicitly enabled in configuration
 	//
@@ -122,11 +152,22 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 	if svr.config.IsAPIEnabled(AdminAPIType) {
 		svr.log.Warn("Admin API endpoints are enabled")
 		// Admin endpoints to check and set EigenDA backend used for dispersal
-		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleGetEigenDADispersalBackend).Methods("GET")
-		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleSetEigenDADispersalBackend).Methods("PUT")
+		r.HandleFunc("/admin/eigenda-dispersal-backend",
+			middleware.WithMiddlewares(svr.handleGetEigenDADispersalBackend, svr.log, svr.m)).Methods("GET")
+		r.HandleFunc("/admin/eigenda-dispersal-backend",
+			middleware.WithMiddlewares(svr.handleSetEigenDADispersalBackend, svr.log, svr.m)).Methods("PUT")
 	}
 }
 
+// RegisterExternalRoutes calls register to add routes to r, wrapping all of them in the generic middlewares
+// (see [middleware.WithMiddlewares]). This is used for routes whose handlers live outside of the server package,
+// such as the memstore routes.
+func (svr *Server) RegisterExternalRoutes(r *mux.Router, register func(*mux.Router)) {
+	subrouter := r.NewRoute().Subrouter()
+	subrouter.Use(middleware.MuxMiddleware(svr.log, svr.m))
+	register(subrouter)
+}
+
 func notCommitmentModeStandard(r *http.Request, _ *mux.RouteMatch) bool {
 	commitmentMode := r.URL.Query().Get("commitment_mode")
 	return commitmentMode == "" || commitmentMode != "standard"
@@ -13,7 +13,10 @@ import (
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/server/ratelimit"
+	"github.com/Layr-Labs/eigenda-proxy/server/servertls"
 	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
 )
@@ -27,6 +30,54 @@ type Config struct {
 	// Example: If it contains "admin", administrative endpoints like
 	// /admin/eigenda-dispersal-backend will be available.
 	EnabledAPIs []string
+	// MaxPOSTBodyBytes is the max size of the body of POST requests, above which a 413 is returned.
+	// 0 uses defaultMaxPOSTBodyBytes. When read from flags, it defaults to the max blob size of the enabled backends.
+	MaxPOSTBodyBytes int64
+	// ReadHeaderTimeout and WriteTimeout are passed to the underlying http.Server. 0 uses defaultReadHeaderTimeout
+	// and defaultWriteTimeout respectively, while a negative value disables them.
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	// PutTimeout and GetTimeout are the deadlines of dispersal and retrieval requests respectively.
+	// Clients can request shorter deadlines. 0 only applies the deadline requested by the client, if any.
+	PutTimeout time.Duration
+	GetTimeout time.Duration
+	// TLS configures TLS and mutual TLS. It is disabled by default.
+	TLS servertls.Config
+	// RateLimit configures the rate and in-flight limits of cert requests
+	RateLimit ratelimit.Config
+	// Tenants resolves the tenant of cert requests from their API key. Nil disables multi-tenancy.
+	Tenants *tenant.Registry `json:"-"`
+	// BuildInfo and EnabledBackends are reported by GET /version
+	BuildInfo       BuildInfo               `json:"-"`
+	EnabledBackends []common.EigenDABackend `json:"-"`
+}
+
+// defaultMaxPOSTBodyBytes limits requests to 32 MiB to mitigate potential DoS attacks,
+// when no limit is configured.
+const defaultMaxPOSTBodyBytes int64 = 1024 * 1024 * 32
+
+// defaultReadHeaderTimeout and defaultWriteTimeout apply when no timeout is configured. The write timeout is
+// aligned with existing blob finalization times.
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultWriteTimeout      = 40 * time.Minute
+)
+
+// Check checks config invariants, and returns an error if there is a problem with the config struct
+func (c *Config) Check() error {
+	if c.MaxPOSTBodyBytes < 0 {
+		return fmt.Errorf("max POST body bytes must be >= 0, got %d", c.MaxPOSTBodyBytes)
+	}
+	if c.PutTimeout < 0 || c.GetTimeout < 0 {
+		return fmt.Errorf("put and get timeouts must be >= 0")
+	}
+	if err := c.TLS.Check(); err != nil {
+		return fmt.Errorf("check TLS config: %w", err)
+	}
+	if err := c.RateLimit.Check(); err != nil {
+		return fmt.Errorf("check rate limit config: %w", err)
+	}
+	return nil
 }
 
 // IsAPIEnabled checks if a specific API type is enabled
@@ -42,6 +93,7 @@ type Server struct {
 	httpServer *http.Server
 	listener   net.Listener
 	config     Config
+	limiter    *ratelimit.Limiter
 }
 
 func NewServer(
@@ -51,23 +103,39 @@ func NewServer(
 	m metrics.Metricer,
 ) *Server {
 	endpoint := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	if cfg.MaxPOSTBodyBytes == 0 {
+		cfg.MaxPOSTBodyBytes = defaultMaxPOSTBodyBytes
+	}
+	if cfg.ReadHeaderTimeout == 0 {
+		cfg.ReadHeaderTimeout = defaultReadHeaderTimeout
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = defaultWriteTimeout
+	}
 	return &Server{
 		m:        m,
 		log:      log,
 		endpoint: endpoint,
 		sm:       sm,
 		config:   cfg,
+		limiter:  ratelimit.NewLimiter(cfg.RateLimit),
 		httpServer: &http.Server{
 			Addr:              endpoint,
-			ReadHeaderTimeout: 10 * time.Second,
-			// aligned with existing blob finalization times
-			WriteTimeout: 40 * time.Minute,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
 		},
 	}
 }
 
 func (svr *Server) Start(r *mux.Router) error {
 	svr.httpServer.Handler = r
+	if svr.config.TLS.Enabled() {
+		tlsConfig, err := servertls.NewTLSConfig(svr.config.TLS, svr.log)
+		if err != nil {
+			return fmt.Errorf("failed to create TLS config: %w", err)
+		}
+		svr.httpServer.TLSConfig = tlsConfig
+	}
 
 	listener, err := net.Listen("tcp", svr.endpoint)
 	if err != nil {
@@ -77,11 +145,19 @@ func (svr *Server) Start(r *mux.Router) error {
 
 	svr.endpoint = listener.Addr().String()
 
-	svr.log.Info("Starting DA server", "endpoint", svr.endpoint)
+	svr.log.Info("Starting DA server", "endpoint", svr.endpoint,
+		"tls", svr.config.TLS.Enabled(), "mutualTLS", svr.config.TLS.MutualTLSEnabled())
 	errCh := make(chan error, 1)
 	go func() {
-		if err := svr.httpServer.Serve(svr.listener); err != nil {
-			errCh <- err
+		var serveErr error
+		if svr.httpServer.TLSConfig != nil {
+			// the certificate and key are provided by the TLS config, which reloads them on change
+			serveErr = svr.httpServer.ServeTLS(svr.listener, "", "")
+		} else {
+			serveErr = svr.httpServer.Serve(svr.listener)
+		}
+		if serveErr != nil {
+			errCh <- serveErr
 		}
 	}()
 
@@ -116,6 +192,11 @@ func (svr *Server) SetDispersalBackend(backend common.EigenDABackend) {
 	svr.sm.SetDispersalBackend(backend)
 }
 
+// SetRateLimits replaces the rate and in-flight limits applied to cert requests
+func (svr *Server) SetRateLimits(cfg ratelimit.Config) {
+	svr.limiter.Update(cfg)
+}
+
 func (svr *Server) Port() int {
 	// read from listener
 	_, portStr, _ := net.SplitHostPort(svr.listener.Addr().String())
@@ -0,0 +1,74 @@
+package servertls
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	CertFileFlagName           = withFlagPrefix("cert-file")
+	KeyFileFlagName            = withFlagPrefix("key-file")
+	ClientCAFileFlagName       = withFlagPrefix("client-ca-file")
+	AllowedClientNamesFlagName = withFlagPrefix("allowed-client-names")
+	ReloadIntervalFlagName     = withFlagPrefix("reload-interval")
+)
+
+func withFlagPrefix(s string) string {
+	return "tls." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_TLS_" + s}
+}
+
+// CLIFlags ... used for configuring TLS on the proxy HTTP server
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     CertFileFlagName,
+			Usage:    "Path to the PEM encoded TLS certificate (chain) of the server. Empty disables TLS.",
+			EnvVars:  withEnvPrefix(envPrefix, "CERT_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     KeyFileFlagName,
+			Usage:    "Path to the PEM encoded private key of the server's TLS certificate.",
+			EnvVars:  withEnvPrefix(envPrefix, "KEY_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: ClientCAFileFlagName,
+			Usage: "Path to the PEM encoded CA certificates used to verify client certificates. " +
+				"When set, clients must present a certificate signed by one of these CAs (mutual TLS).",
+			EnvVars:  withEnvPrefix(envPrefix, "CLIENT_CA_FILE"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name: AllowedClientNamesFlagName,
+			Usage: "Subject common names or DNS SANs of the client certificates accepted with mutual TLS. " +
+				"Empty accepts any certificate signed by the client CAs.",
+			EnvVars:  withEnvPrefix(envPrefix, "ALLOWED_CLIENT_NAMES"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: ReloadIntervalFlagName,
+			Usage: "Min interval at which the certificate, key and client CA files are checked for changes, " +
+				"in which case they are reloaded without restarting the server. 0 disables reloading.",
+			Value:    time.Minute,
+			EnvVars:  withEnvPrefix(envPrefix, "RELOAD_INTERVAL"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		CertFile:           ctx.String(CertFileFlagName),
+		KeyFile:            ctx.String(KeyFileFlagName),
+		ClientCAFile:       ctx.String(ClientCAFileFlagName),
+		AllowedClientNames: ctx.StringSlice(AllowedClientNamesFlagName),
+		ReloadInterval:     ctx.Duration(ReloadIntervalFlagName),
+	}
+}
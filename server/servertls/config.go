@@ -0,0 +1,52 @@
+package servertls
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config ... configures TLS for the proxy HTTP server. TLS is disabled when no certificate is configured.
+type Config struct {
+	// CertFile and KeyFile are the paths to the PEM encoded certificate (chain) and private key of the server.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the path to the PEM encoded CA certificates used to verify client certificates.
+	// When set, clients must present a certificate signed by one of these CAs (mutual TLS).
+	ClientCAFile string
+	// AllowedClientNames restricts the client certificates accepted with mutual TLS to those whose
+	// subject common name or one of whose DNS SANs is in the list. Empty accepts any certificate signed by the CAs.
+	AllowedClientNames []string
+	// ReloadInterval is the min interval at which the files are checked for changes, in which case they are
+	// reloaded without restarting the server. 0 disables reloading.
+	ReloadInterval time.Duration
+}
+
+// Enabled returns true if the server should serve TLS
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// MutualTLSEnabled returns true if clients must present a certificate
+func (c Config) MutualTLSEnabled() bool {
+	return c.ClientCAFile != ""
+}
+
+// Check checks config invariants, and returns an error if there is a problem with the config struct
+func (c Config) Check() error {
+	if !c.Enabled() {
+		if c.MutualTLSEnabled() || len(c.AllowedClientNames) > 0 {
+			return fmt.Errorf("mutual TLS requires TLS to be enabled, with a certificate and key")
+		}
+		return nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("both a certificate and a key file are required to enable TLS")
+	}
+	if len(c.AllowedClientNames) > 0 && !c.MutualTLSEnabled() {
+		return fmt.Errorf("allowed client names require a client CA file")
+	}
+	if c.ReloadInterval < 0 {
+		return fmt.Errorf("reload interval must be >= 0, got %s", c.ReloadInterval)
+	}
+	return nil
+}
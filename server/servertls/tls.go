@@ -0,0 +1,175 @@
+package servertls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// NewTLSConfig ... creates the TLS config of the proxy HTTP server. The certificate, key and client CA files
+// are loaded upfront, and reloaded when they change (see [Config.ReloadInterval]).
+func NewTLSConfig(cfg Config, log logging.Logger) (*tls.Config, error) {
+	loader, err := newCertLoader(cfg, log, time.Now)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := loader.current()
+			return cert, nil
+		},
+	}
+	if cfg.MutualTLSEnabled() {
+		// Client certificates are verified against the current client CAs in VerifyConnection, rather than by
+		// setting ClientCAs, so that the CAs can be reloaded. VerifyConnection is also called on resumed sessions.
+		tlsConfig.ClientAuth = tls.RequireAnyClientCert
+		tlsConfig.VerifyConnection = loader.verifyClient
+	}
+	return tlsConfig, nil
+}
+
+// certLoader ... holds the server certificate and client CAs, and reloads them when their files change.
+type certLoader struct {
+	cfg Config
+	log logging.Logger
+	now func() time.Time
+
+	mu   sync.Mutex
+	cert *tls.Certificate
+	// clientCAs is nil when mutual TLS is disabled
+	clientCAs *x509.CertPool
+	// modTimes holds the modification time of the files when they were last loaded
+	modTimes  map[string]time.Time
+	lastCheck time.Time
+}
+
+func newCertLoader(cfg Config, log logging.Logger, now func() time.Time) (*certLoader, error) {
+	l := &certLoader{cfg: cfg, log: log, now: now, lastCheck: now()}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *certLoader) files() []string {
+	files := []string{l.cfg.CertFile, l.cfg.KeyFile}
+	if l.cfg.MutualTLSEnabled() {
+		files = append(files, l.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// load reads the files, only replacing the current certificate and client CAs if all of them are valid.
+// Must be called with mu held, or before the loader is shared.
+func (l *certLoader) load() error {
+	modTimes, err := l.statFiles()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(l.cfg.CertFile, l.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS key pair: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if l.cfg.MutualTLSEnabled() {
+		caPEM, readErr := os.ReadFile(l.cfg.ClientCAFile)
+		if readErr != nil {
+			return fmt.Errorf("read client CA file: %w", readErr)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("no PEM encoded certificate found in client CA file %s", l.cfg.ClientCAFile)
+		}
+	}
+	l.cert = &cert
+	l.clientCAs = clientCAs
+	l.modTimes = modTimes
+	return nil
+}
+
+func (l *certLoader) statFiles() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range l.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("stat TLS file: %w", err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// current returns the current certificate and client CAs, after reloading them if their files changed.
+// Reload errors are logged, and the previous certificate and client CAs are kept, so that a partially written
+// certificate rotation doesn't take the server down. The reload is retried at the next check.
+func (l *certLoader) current() (*tls.Certificate, *x509.CertPool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if l.cfg.ReloadInterval > 0 && now.Sub(l.lastCheck) >= l.cfg.ReloadInterval {
+		l.lastCheck = now
+		if l.changed() {
+			if err := l.load(); err != nil {
+				l.log.Error("Failed to reload TLS files, keeping the previous ones", "err", err)
+			} else {
+				l.log.Info("Reloaded TLS files", "certFile", l.cfg.CertFile, "clientCAFile", l.cfg.ClientCAFile)
+			}
+		}
+	}
+	return l.cert, l.clientCAs
+}
+
+// changed returns true if any of the files was modified since it was last loaded. Must be called with mu held.
+func (l *certLoader) changed() bool {
+	modTimes, err := l.statFiles()
+	if err != nil {
+		// the file may be in the middle of being replaced: it is retried at the next check
+		l.log.Warn("Failed to check TLS files for changes", "err", err)
+		return false
+	}
+	for file, modTime := range modTimes {
+		if !modTime.Equal(l.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyClient verifies the certificate chain presented by the client against the current client CAs,
+// and checks that the certificate's names are allowed.
+func (l *certLoader) verifyClient(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("client certificate required")
+	}
+	_, clientCAs := l.current()
+	leaf := cs.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("verify client certificate: %w", err)
+	}
+	if len(l.cfg.AllowedClientNames) == 0 {
+		return nil
+	}
+	names := append([]string{leaf.Subject.CommonName}, leaf.DNSNames...)
+	for _, name := range names {
+		if name != "" && slices.Contains(l.cfg.AllowedClientNames, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("client certificate %q is not in the allowed client names", leaf.Subject.CommonName)
+}
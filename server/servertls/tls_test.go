@@ -0,0 +1,189 @@
+package servertls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// testCA ... issues certificates for the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM encoded certificate and key of a leaf certificate signed by the CA
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// serve starts an HTTPS server with the TLS config, and returns its url
+func serve(t *testing.T, tlsConfig *tls.Config) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := &http.Server{
+		Handler:           http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return "https://" + listener.Addr().String()
+}
+
+func get(url string, serverCA *testCA, clientCertPEM []byte, clientKeyPEM []byte) error {
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCA.cert)
+	clientTLSConfig := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	if clientCertPEM != nil {
+		cert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+		if err != nil {
+			return err
+		}
+		clientTLSConfig.Certificates = []tls.Certificate{cert}
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}, Timeout: 5 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestConfig_Check(t *testing.T) {
+	require.NoError(t, Config{}.Check())
+	require.NoError(t, Config{CertFile: "c", KeyFile: "k", ClientCAFile: "ca", AllowedClientNames: []string{"a"}}.Check())
+	require.Error(t, Config{CertFile: "c"}.Check())
+	require.Error(t, Config{ClientCAFile: "ca"}.Check())
+	require.Error(t, Config{CertFile: "c", KeyFile: "k", AllowedClientNames: []string{"a"}}.Check())
+	require.Error(t, Config{CertFile: "c", KeyFile: "k", ReloadInterval: -time.Second}.Check())
+}
+
+func TestNewTLSConfig_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newTestCA(t, "server-ca")
+	clientCA := newTestCA(t, "client-ca")
+	serverCert, serverKey := serverCA.issue(t, "proxy", x509.ExtKeyUsageServerAuth)
+	cfg := Config{
+		CertFile:           filepath.Join(dir, "server.crt"),
+		KeyFile:            filepath.Join(dir, "server.key"),
+		ClientCAFile:       filepath.Join(dir, "client-ca.crt"),
+		AllowedClientNames: []string{"batcher"},
+	}
+	writeFile(t, cfg.CertFile, serverCert)
+	writeFile(t, cfg.KeyFile, serverKey)
+	writeFile(t, cfg.ClientCAFile, clientCA.pem)
+
+	tlsConfig, err := NewTLSConfig(cfg, testLogger)
+	require.NoError(t, err)
+	url := serve(t, tlsConfig)
+
+	batcherCert, batcherKey := clientCA.issue(t, "batcher", x509.ExtKeyUsageClientAuth)
+	require.NoError(t, get(url, serverCA, batcherCert, batcherKey))
+
+	require.Error(t, get(url, serverCA, nil, nil), "client certificate is required")
+	otherCert, otherKey := clientCA.issue(t, "other", x509.ExtKeyUsageClientAuth)
+	require.Error(t, get(url, serverCA, otherCert, otherKey), "client name is not allowed")
+	untrustedCert, untrustedKey := serverCA.issue(t, "batcher", x509.ExtKeyUsageClientAuth)
+	require.Error(t, get(url, serverCA, untrustedCert, untrustedKey), "client CA is not trusted")
+}
+
+func TestCertLoader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "ca")
+	cfg := Config{
+		CertFile:       filepath.Join(dir, "server.crt"),
+		KeyFile:        filepath.Join(dir, "server.key"),
+		ReloadInterval: time.Minute,
+	}
+	certPEM, keyPEM := ca.issue(t, "proxy-1", x509.ExtKeyUsageServerAuth)
+	writeFile(t, cfg.CertFile, certPEM)
+	writeFile(t, cfg.KeyFile, keyPEM)
+
+	now := time.Now()
+	loader, err := newCertLoader(cfg, testLogger, func() time.Time { return now })
+	require.NoError(t, err)
+	commonName := func() string {
+		cert, _ := loader.current()
+		leaf, parseErr := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, parseErr)
+		return leaf.Subject.CommonName
+	}
+	// the files' modification times are set explicitly, since rewrites can happen within the fs time granularity
+	touch := func(modTime time.Time, files ...string) {
+		for _, file := range files {
+			require.NoError(t, os.Chtimes(file, modTime, modTime))
+		}
+	}
+
+	// a rotation with a mismatched key pair keeps the previous certificate
+	certPEM, keyPEM = ca.issue(t, "proxy-2", x509.ExtKeyUsageServerAuth)
+	writeFile(t, cfg.CertFile, certPEM)
+	touch(now.Add(time.Hour), cfg.CertFile)
+	now = now.Add(time.Minute)
+	require.Equal(t, "proxy-1", commonName())
+
+	// the rotation is picked up at the next check once complete, but not before the reload interval
+	writeFile(t, cfg.KeyFile, keyPEM)
+	touch(now.Add(time.Hour), cfg.KeyFile)
+	now = now.Add(30 * time.Second)
+	require.Equal(t, "proxy-1", commonName())
+	now = now.Add(30 * time.Second)
+	require.Equal(t, "proxy-2", commonName())
+}
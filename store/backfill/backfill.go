@@ -0,0 +1,285 @@
+// Package backfill copies the payloads of existing commitments to secondary storage backends, e.g. when a fallback
+// target is added while the historical payloads only exist on EigenDA, which may expire them.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// Getter fetches the payloads of certs, and verifies them against the certs. It is implemented by the
+// store.Manager.
+type Getter interface {
+	Get(ctx context.Context, versionedCert certs.VersionedCert,
+		cm commitments.CommitmentMode, verifyOpts common.CertVerificationOpts) ([]byte, error)
+}
+
+// Report summarizes a backfill
+type Report struct {
+	Source  string   `json:"source"`
+	Targets []string `json:"targets"`
+	// ResumedFrom is the position the backfill was resumed from, if it was resumed from a checkpoint
+	ResumedFrom uint64 `json:"resumed_from,omitempty"`
+	// Next is the position the backfill can be resumed from
+	Next uint64 `json:"next"`
+
+	// Commitments is the number of commitments processed, which were either backfilled, already present in all
+	// the targets, or failed.
+	Commitments    int       `json:"commitments"`
+	Backfilled     int       `json:"backfilled"`
+	AlreadyPresent int       `json:"already_present"`
+	Failed         int       `json:"failed"`
+	Failures       []Failure `json:"failures,omitempty"`
+	Duration       string    `json:"duration"`
+}
+
+// Failure is a commitment which couldn't be backfilled to all the targets
+type Failure struct {
+	Position uint64 `json:"position"`
+	// Commitment is hex encoded, so that failed commitments can be listed in a commitments file to be retried
+	Commitment string `json:"commitment"`
+	Error      string `json:"error"`
+}
+
+// progressLogInterval is the number of commitments processed between progress logs
+const progressLogInterval = 100
+
+type result int
+
+const (
+	backfilled result = iota
+	alreadyPresent
+	failed
+)
+
+// Backfiller fetches the payloads of commitments through a [Getter], and writes them to secondary storage targets
+type Backfiller struct {
+	log     logging.Logger
+	getter  Getter
+	targets []common.SecondaryStore
+	cfg     Config
+}
+
+func NewBackfiller(log logging.Logger, getter Getter, targets []common.SecondaryStore, cfg Config) *Backfiller {
+	return &Backfiller{
+		log:     log,
+		getter:  getter,
+		targets: targets,
+		cfg:     cfg,
+	}
+}
+
+// Run backfills the commitments of source, resuming from the checkpoint file if it exists. The returned report
+// covers the commitments processed by this run, including when an error is returned.
+// Entries are written under the secondary prefix of the tenant of ctx, if any.
+func (b *Backfiller) Run(ctx context.Context, source Source) (*Report, error) {
+	start := time.Now()
+	report := &Report{Source: source.Name()}
+	for _, target := range b.targets {
+		report.Targets = append(report.Targets, target.BackendType().String())
+	}
+
+	var from uint64
+	if b.cfg.CheckpointFile != "" {
+		cp, err := loadCheckpoint(b.cfg.CheckpointFile)
+		if err != nil {
+			return report, err
+		}
+		if cp != nil {
+			if cp.Source != source.Name() {
+				return report, fmt.Errorf("checkpoint file %s is for source %s, not %s",
+					b.cfg.CheckpointFile, cp.Source, source.Name())
+			}
+			if cp.Done {
+				b.log.Info("Backfill already completed according to the checkpoint file",
+					"checkpoint", b.cfg.CheckpointFile)
+				report.Next = cp.Next
+				report.Duration = time.Since(start).String()
+				return report, nil
+			}
+			from = cp.Next
+			report.ResumedFrom = from
+			b.log.Info("Resuming backfill from checkpoint", "checkpoint", b.cfg.CheckpointFile, "position", from)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	progress := newProgress(from)
+
+	// the source is read until it is exhausted or ctx is done
+	commitmentsCh := make(chan Commitment, b.cfg.Concurrency)
+	var sourceErr error
+	go func() {
+		defer close(commitmentsCh)
+		sourceErr = source.Commitments(ctx, from, commitmentsCh)
+	}()
+
+	type job struct {
+		seq        uint64
+		commitment Commitment
+	}
+	jobs := make(chan job)
+	var reportLock sync.Mutex
+	var wg sync.WaitGroup
+	for range b.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				res, err := b.backfill(ctx, j.commitment)
+				if ctx.Err() != nil {
+					// the commitment is left pending, so that it is processed again when resuming
+					continue
+				}
+				reportLock.Lock()
+				report.record(j.commitment, res, err)
+				if report.Commitments%progressLogInterval == 0 {
+					b.log.Info("Backfill progress", "commitments", report.Commitments,
+						"backfilled", report.Backfilled, "failed", report.Failed, "position", j.commitment.Position)
+				}
+				reportLock.Unlock()
+				progress.finish(j.seq)
+			}
+		}()
+	}
+
+	checkpointsDone := make(chan struct{})
+	if b.cfg.CheckpointFile != "" {
+		go func() {
+			defer close(checkpointsDone)
+			b.saveCheckpoints(ctx, source.Name(), progress)
+		}()
+	} else {
+		close(checkpointsDone)
+	}
+
+	for commitment := range commitmentsCh {
+		if ctx.Err() != nil {
+			continue
+		}
+		seq := progress.start(commitment.Position)
+		select {
+		case jobs <- job{seq: seq, commitment: commitment}:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// ctx is only done at this point if the parent ctx is
+	var err error
+	switch {
+	case ctx.Err() != nil:
+		err = fmt.Errorf("backfill interrupted: %w", ctx.Err())
+	case sourceErr != nil:
+		err = fmt.Errorf("read commitments: %w", sourceErr)
+	}
+	cancel()
+	<-checkpointsDone
+
+	report.Next = progress.resumePosition()
+	report.Duration = time.Since(start).String()
+	if b.cfg.CheckpointFile != "" {
+		cp := checkpoint{Source: source.Name(), Next: report.Next, Done: err == nil}
+		if saveErr := cp.save(b.cfg.CheckpointFile); saveErr != nil {
+			b.log.Error("Failed to save checkpoint", "err", saveErr)
+		}
+	}
+	return report, err
+}
+
+// saveCheckpoints saves the progress of the backfill to the checkpoint file periodically, until ctx is done
+func (b *Backfiller) saveCheckpoints(ctx context.Context, sourceName string, progress *progress) {
+	ticker := time.NewTicker(b.cfg.CheckpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			cp := checkpoint{Source: sourceName, Next: progress.resumePosition()}
+			if err := cp.save(b.cfg.CheckpointFile); err != nil {
+				b.log.Warn("Failed to save checkpoint", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// backfill writes the payload of a commitment to the targets which don't have it yet, or to all of them when
+// overwriting.
+func (b *Backfiller) backfill(ctx context.Context, commitment Commitment) (result, error) {
+	key := secondary.EntryKey(ctx, commitment.VersionedCert.SerializedCert)
+
+	targets := b.targets
+	if !b.cfg.Overwrite {
+		targets = b.missingTargets(ctx, key)
+		if len(targets) == 0 {
+			b.log.Debug("Commitment already present in all targets", "position", commitment.Position)
+			return alreadyPresent, nil
+		}
+	}
+
+	payload, err := b.getter.Get(ctx, commitment.VersionedCert, commitment.Mode,
+		common.CertVerificationOpts{L1InclusionBlockNum: commitment.L1InclusionBlockNum})
+	if err != nil {
+		return failed, fmt.Errorf("get payload: %w", err)
+	}
+
+	var failedTargets []string
+	for _, target := range targets {
+		// retried the same way as the writes to secondary storage of the proxy
+		_, err = retry.Do[any](ctx, 5, retry.Exponential(), func() (any, error) {
+			return nil, target.Put(ctx, key, payload)
+		})
+		if err != nil {
+			b.log.Warn("Failed to write to backfill target",
+				"backend", target.BackendType(), "position", commitment.Position, "err", err)
+			failedTargets = append(failedTargets, target.BackendType().String())
+		}
+	}
+	if len(failedTargets) > 0 {
+		return failed, fmt.Errorf("write to targets %v failed", failedTargets)
+	}
+	b.log.Debug("Backfilled commitment", "position", commitment.Position, "targets", len(targets))
+	return backfilled, nil
+}
+
+// missingTargets returns the targets which have no entry for key. Targets which fail to be read are assumed to
+// have none.
+func (b *Backfiller) missingTargets(ctx context.Context, key []byte) []common.SecondaryStore {
+	var missing []common.SecondaryStore
+	for _, target := range b.targets {
+		data, err := target.Get(ctx, key)
+		if err != nil || data == nil {
+			missing = append(missing, target)
+		}
+	}
+	return missing
+}
+
+func (r *Report) record(commitment Commitment, res result, err error) {
+	r.Commitments++
+	switch res {
+	case backfilled:
+		r.Backfilled++
+	case alreadyPresent:
+		r.AlreadyPresent++
+	case failed:
+		r.Failed++
+		r.Failures = append(r.Failures, Failure{
+			Position:   commitment.Position,
+			Commitment: commitment.Encode(),
+			Error:      err.Error(),
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// fakeGetter returns the payload "payload-<cert>" of certs, and fails for the certs listed in failing
+type fakeGetter struct {
+	failing map[string]bool
+}
+
+func (g *fakeGetter) Get(_ context.Context, versionedCert certs.VersionedCert,
+	_ commitments.CommitmentMode, _ common.CertVerificationOpts) ([]byte, error) {
+	cert := string(versionedCert.SerializedCert)
+	if g.failing[cert] {
+		return nil, errors.New("blob expired")
+	}
+	return []byte("payload-" + cert), nil
+}
+
+type memSecondaryStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	puts    int
+}
+
+func newMemSecondaryStore() *memSecondaryStore {
+	return &memSecondaryStore{entries: make(map[string][]byte)}
+}
+
+func (s *memSecondaryStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[string(key)], nil
+}
+
+func (s *memSecondaryStore) Put(_ context.Context, key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.puts++
+	s.entries[string(key)] = value
+	return nil
+}
+
+func (s *memSecondaryStore) Verify(_ context.Context, _ []byte, _ []byte) error {
+	return nil
+}
+
+func (s *memSecondaryStore) BackendType() common.BackendType {
+	return common.S3BackendType
+}
+
+func (s *memSecondaryStore) payload(cert string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[string(crypto.Keccak256([]byte(cert)))]
+}
+
+// writeCommitmentsFile writes the standard commitments of V2 certs with the given contents
+func writeCommitmentsFile(t *testing.T, certContents ...string) string {
+	lines := []string{"# commitments to backfill", ""}
+	for _, cert := range certContents {
+		lines = append(lines, fmt.Sprintf("0x%02x%x", byte(certs.V2VersionByte), cert))
+	}
+	path := filepath.Join(t.TempDir(), "commitments.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func testConfig(t *testing.T) Config {
+	return Config{
+		Concurrency:        3,
+		CheckpointFile:     filepath.Join(t.TempDir(), "checkpoint.json"),
+		CheckpointInterval: time.Hour,
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	path := writeCommitmentsFile(t, "cert-a", "cert-b", "cert-c", "cert-d")
+	target := newMemSecondaryStore()
+	// cert-b is already present, and must not be fetched or written again
+	require.NoError(t, target.Put(context.Background(), crypto.Keccak256([]byte("cert-b")), []byte("payload-cert-b")))
+	getter := &fakeGetter{failing: map[string]bool{"cert-c": true}}
+	cfg := testConfig(t)
+
+	backfiller := NewBackfiller(testLogger, getter, []common.SecondaryStore{target}, cfg)
+	report, err := backfiller.Run(context.Background(), NewFileSource(path, commitments.StandardCommitmentMode))
+	require.NoError(t, err)
+
+	require.Equal(t, 4, report.Commitments)
+	require.Equal(t, 2, report.Backfilled)
+	require.Equal(t, 1, report.AlreadyPresent)
+	require.Equal(t, 1, report.Failed)
+	require.Len(t, report.Failures, 1)
+	require.Equal(t, uint64(5), report.Failures[0].Position)
+	require.Equal(t, fmt.Sprintf("0x02%x", "cert-c"), report.Failures[0].Commitment)
+	require.Contains(t, report.Failures[0].Error, "blob expired")
+
+	require.Equal(t, []byte("payload-cert-a"), target.payload("cert-a"))
+	require.Equal(t, []byte("payload-cert-d"), target.payload("cert-d"))
+	require.Nil(t, target.payload("cert-c"))
+	// the put of cert-b by the test, and those of cert-a and cert-d
+	require.Equal(t, 3, target.puts)
+
+	// the backfill completed, so running it again is a no-op
+	cp, err := loadCheckpoint(cfg.CheckpointFile)
+	require.NoError(t, err)
+	require.True(t, cp.Done)
+	report, err = backfiller.Run(context.Background(), NewFileSource(path, commitments.StandardCommitmentMode))
+	require.NoError(t, err)
+	require.Equal(t, 0, report.Commitments)
+	require.Equal(t, 3, target.puts)
+}
+
+func TestBackfillOverwrite(t *testing.T) {
+	path := writeCommitmentsFile(t, "cert-a")
+	target := newMemSecondaryStore()
+	require.NoError(t, target.Put(context.Background(), crypto.Keccak256([]byte("cert-a")), []byte("corrupted")))
+	cfg := testConfig(t)
+	cfg.Overwrite = true
+
+	backfiller := NewBackfiller(testLogger, &fakeGetter{}, []common.SecondaryStore{target}, cfg)
+	report, err := backfiller.Run(context.Background(), NewFileSource(path, commitments.StandardCommitmentMode))
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Backfilled)
+	require.Equal(t, []byte("payload-cert-a"), target.payload("cert-a"))
+}
+
+func TestBackfillResumesFromCheckpoint(t *testing.T) {
+	// lines 1 and 2 are the header of the file, so cert-c is on line 5
+	path := writeCommitmentsFile(t, "cert-a", "cert-b", "cert-c", "cert-d")
+	source := NewFileSource(path, commitments.StandardCommitmentMode)
+	cfg := testConfig(t)
+	require.NoError(t, checkpoint{Source: source.Name(), Next: 5}.save(cfg.CheckpointFile))
+	target := newMemSecondaryStore()
+
+	backfiller := NewBackfiller(testLogger, &fakeGetter{}, []common.SecondaryStore{target}, cfg)
+	report, err := backfiller.Run(context.Background(), source)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), report.ResumedFrom)
+	require.Equal(t, 2, report.Backfilled)
+	require.Nil(t, target.payload("cert-a"))
+	require.Nil(t, target.payload("cert-b"))
+	require.NotNil(t, target.payload("cert-c"))
+	require.NotNil(t, target.payload("cert-d"))
+}
+
+func TestBackfillRejectsCheckpointOfOtherSource(t *testing.T) {
+	cfg := testConfig(t)
+	require.NoError(t, checkpoint{Source: "file:other.txt", Next: 3}.save(cfg.CheckpointFile))
+
+	backfiller := NewBackfiller(testLogger, &fakeGetter{}, nil, cfg)
+	_, err := backfiller.Run(context.Background(),
+		NewFileSource(writeCommitmentsFile(t, "cert-a"), commitments.StandardCommitmentMode))
+	require.ErrorContains(t, err, "checkpoint file")
+}
+
+func TestProgress(t *testing.T) {
+	p := newProgress(3)
+	require.Equal(t, uint64(3), p.resumePosition())
+
+	first := p.start(10)
+	second := p.start(10)
+	third := p.start(12)
+	require.Equal(t, uint64(10), p.resumePosition())
+
+	// commitments completing out of order don't move the resume position past the pending ones
+	p.finish(third)
+	p.finish(first)
+	require.Equal(t, uint64(10), p.resumePosition())
+	p.finish(second)
+	// more commitments of the last position started may follow
+	require.Equal(t, uint64(12), p.resumePosition())
+}
@@ -0,0 +1,107 @@
+package backfill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// checkpoint is the progress of a backfill, saved to the checkpoint file
+type checkpoint struct {
+	// Source is the name of the source of the commitments, see [Source.Name]
+	Source string `json:"source"`
+	// Next is the position the backfill resumes from. The commitments before it were all processed.
+	Next uint64 `json:"next"`
+	// Done is set once all the commitments of the source were processed
+	Done bool `json:"done"`
+}
+
+// loadCheckpoint reads the checkpoint file at path. It returns nil if the file doesn't exist.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint file: %w", err)
+	}
+	var cp checkpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint file: %w", err)
+	}
+	return &cp, nil
+}
+
+// save writes the checkpoint to path. The file is replaced atomically, so that it isn't left corrupted
+// if the backfill is killed while saving.
+func (cp checkpoint) save(path string) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// progress tracks the commitments being processed, to compute the position a backfill can be resumed from.
+// Commitments are processed concurrently, so they can complete out of order.
+type progress struct {
+	mu sync.Mutex
+	// pending maps the sequence numbers of the commitments being processed to their position
+	pending map[uint64]uint64
+	nextSeq uint64
+	// next is the position to resume from when no commitment is pending
+	next uint64
+}
+
+func newProgress(from uint64) *progress {
+	return &progress{pending: make(map[uint64]uint64), next: from}
+}
+
+// start registers a commitment being processed, and returns its sequence number
+func (p *progress) start(position uint64) uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	seq := p.nextSeq
+	p.nextSeq++
+	p.pending[seq] = position
+	// commitments sharing the position of the last one started (e.g. of the same L1 block) may be yet to come,
+	// so the position is only past once a commitment at a later position is started
+	p.next = position
+	return seq
+}
+
+// finish marks the commitment with the sequence number seq as processed
+func (p *progress) finish(seq uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, seq)
+}
+
+// resumePosition returns the position from which all the commitments which weren't processed can be read again
+func (p *progress) resumePosition() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	next := p.next
+	for _, position := range p.pending {
+		next = min(next, position)
+	}
+	return next
+}
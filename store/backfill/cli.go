@@ -0,0 +1,152 @@
+package backfill
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	TargetsFlagName            = withFlagPrefix("targets")
+	CommitmentsFileFlagName    = withFlagPrefix("commitments-file")
+	CommitmentModeFlagName     = withFlagPrefix("commitment-mode")
+	L1EthRPCFlagName           = withFlagPrefix("l1-eth-rpc")
+	BatchInboxAddressFlagName  = withFlagPrefix("batch-inbox-address")
+	BatcherAddressFlagName     = withFlagPrefix("batcher-address")
+	FromBlockFlagName          = withFlagPrefix("from-block")
+	ToBlockFlagName            = withFlagPrefix("to-block")
+	ConcurrencyFlagName        = withFlagPrefix("concurrency")
+	OverwriteFlagName          = withFlagPrefix("overwrite")
+	CheckpointFileFlagName     = withFlagPrefix("checkpoint-file")
+	CheckpointIntervalFlagName = withFlagPrefix("checkpoint-interval")
+	ReportFileFlagName         = withFlagPrefix("report-file")
+	TenantFlagName             = withFlagPrefix("tenant")
+)
+
+func withFlagPrefix(s string) string {
+	return "backfill." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_BACKFILL_" + s}
+}
+
+// CLIFlags ... used for the backfill command
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: TargetsFlagName,
+			Usage: "Comma separated list of the secondary storage backends to write payloads to (e.g. s3,redis). " +
+				"They are configured by the same flags as the cache and fallback targets.",
+			EnvVars:  withEnvPrefix(envPrefix, "TARGETS"),
+			Category: category,
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name: CommitmentsFileFlagName,
+			Usage: "File listing the commitments to backfill, one hex encoded commitment per line. " +
+				"Empty lines and lines starting with # are ignored.",
+			EnvVars:  withEnvPrefix(envPrefix, "COMMITMENTS_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     CommitmentModeFlagName,
+			Usage:    "Commitment mode of the commitments file (standard or optimism_generic).",
+			Value:    string(commitments.StandardCommitmentMode),
+			EnvVars:  withEnvPrefix(envPrefix, "COMMITMENT_MODE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: L1EthRPCFlagName,
+			Usage: "L1 ETH RPC endpoint. When set, the commitments to backfill are the altda commitments posted " +
+				"to the batch inbox between the from and to blocks, instead of those of the commitments file.",
+			EnvVars:  withEnvPrefix(envPrefix, "L1_ETH_RPC"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     BatchInboxAddressFlagName,
+			Usage:    "Address of the batch inbox of the rollup, scanned for commitments.",
+			EnvVars:  withEnvPrefix(envPrefix, "BATCH_INBOX_ADDRESS"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     BatcherAddressFlagName,
+			Usage:    "Address of the batcher of the rollup. When set, transactions sent by other addresses are ignored.",
+			EnvVars:  withEnvPrefix(envPrefix, "BATCHER_ADDRESS"),
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     FromBlockFlagName,
+			Usage:    "First L1 block scanned for commitments.",
+			EnvVars:  withEnvPrefix(envPrefix, "FROM_BLOCK"),
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     ToBlockFlagName,
+			Usage:    "Last L1 block scanned for commitments (inclusive).",
+			EnvVars:  withEnvPrefix(envPrefix, "TO_BLOCK"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     ConcurrencyFlagName,
+			Usage:    "Number of commitments backfilled concurrently.",
+			Value:    8,
+			EnvVars:  withEnvPrefix(envPrefix, "CONCURRENCY"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     OverwriteFlagName,
+			Usage:    "Write payloads to the targets which already hold an entry for their commitment.",
+			EnvVars:  withEnvPrefix(envPrefix, "OVERWRITE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: CheckpointFileFlagName,
+			Usage: "File the progress of the backfill is saved to. When it exists, the backfill resumes from it. " +
+				"Progress isn't saved when empty.",
+			EnvVars:  withEnvPrefix(envPrefix, "CHECKPOINT_FILE"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     CheckpointIntervalFlagName,
+			Usage:    "Interval at which the progress of the backfill is saved to the checkpoint file.",
+			Value:    10 * time.Second,
+			EnvVars:  withEnvPrefix(envPrefix, "CHECKPOINT_INTERVAL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ReportFileFlagName,
+			Usage:    "File the JSON summary report is written to. It is printed to stdout when empty.",
+			EnvVars:  withEnvPrefix(envPrefix, "REPORT_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: TenantFlagName,
+			Usage: "Name of the tenant whose entries are backfilled. Payloads are written under the secondary " +
+				"prefix of the tenant.",
+			EnvVars:  withEnvPrefix(envPrefix, "TENANT"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Targets:            ctx.StringSlice(TargetsFlagName),
+		CommitmentsFile:    ctx.String(CommitmentsFileFlagName),
+		CommitmentMode:     commitments.CommitmentMode(ctx.String(CommitmentModeFlagName)),
+		L1EthRPC:           ctx.String(L1EthRPCFlagName),
+		BatchInboxAddress:  ctx.String(BatchInboxAddressFlagName),
+		BatcherAddress:     ctx.String(BatcherAddressFlagName),
+		FromBlock:          ctx.Uint64(FromBlockFlagName),
+		ToBlock:            ctx.Uint64(ToBlockFlagName),
+		Concurrency:        ctx.Int(ConcurrencyFlagName),
+		Overwrite:          ctx.Bool(OverwriteFlagName),
+		CheckpointFile:     ctx.String(CheckpointFileFlagName),
+		CheckpointInterval: ctx.Duration(CheckpointIntervalFlagName),
+		ReportFile:         ctx.String(ReportFileFlagName),
+		Tenant:             ctx.String(TenantFlagName),
+	}
+}
@@ -0,0 +1,92 @@
+package backfill
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+type Config struct {
+	// Targets are the secondary storage backends (e.g. s3, redis) the payloads are written to
+	Targets []string
+
+	// CommitmentsFile lists the commitments to backfill, which are of CommitmentMode
+	CommitmentsFile string
+	CommitmentMode  commitments.CommitmentMode
+
+	// When L1EthRPC is set, the commitments to backfill are the altda commitments posted to BatchInboxAddress
+	// (by BatcherAddress, if set) between FromBlock and ToBlock, rather than those of CommitmentsFile.
+	L1EthRPC          string
+	BatchInboxAddress string
+	BatcherAddress    string
+	FromBlock         uint64
+	ToBlock           uint64
+
+	Concurrency int
+	// Overwrite writes payloads to the targets which already hold an entry for their commitment
+	Overwrite bool
+
+	// CheckpointFile is where progress is saved every CheckpointInterval. Progress isn't saved when empty.
+	CheckpointFile     string
+	CheckpointInterval time.Duration
+	// ReportFile is where the summary report is written. It is printed to stdout when empty.
+	ReportFile string
+	// Tenant is the name of the tenant whose entries are backfilled. Empty for anonymous entries.
+	Tenant string
+}
+
+// ScansL1 returns whether the commitments to backfill are read from L1, rather than from the commitments file
+func (cfg *Config) ScansL1() bool {
+	return cfg.L1EthRPC != ""
+}
+
+// Check ... verifies that configuration values are adequately set
+func (cfg *Config) Check() error {
+	if len(cfg.Targets) == 0 {
+		return fmt.Errorf("no backfill targets provided")
+	}
+	if common.ContainsDuplicates(cfg.Targets) {
+		return fmt.Errorf("duplicate backfill targets provided: %+v", cfg.Targets)
+	}
+	for _, t := range cfg.Targets {
+		switch common.StringToBackendType(t) {
+		case common.S3BackendType, common.RedisBackendType, common.GCSBackendType:
+		default:
+			return fmt.Errorf("backfill target %s is not a secondary storage backend", t)
+		}
+	}
+
+	switch {
+	case cfg.ScansL1() && cfg.CommitmentsFile != "":
+		return fmt.Errorf("only one of the commitments file and the L1 ETH RPC can be provided")
+	case cfg.ScansL1():
+		if !geth_common.IsHexAddress(cfg.BatchInboxAddress) {
+			return fmt.Errorf("invalid batch inbox address: %q", cfg.BatchInboxAddress)
+		}
+		if cfg.BatcherAddress != "" && !geth_common.IsHexAddress(cfg.BatcherAddress) {
+			return fmt.Errorf("invalid batcher address: %q", cfg.BatcherAddress)
+		}
+		if cfg.ToBlock < cfg.FromBlock {
+			return fmt.Errorf("to block %d is before from block %d", cfg.ToBlock, cfg.FromBlock)
+		}
+	case cfg.CommitmentsFile != "":
+		if cfg.CommitmentMode != commitments.StandardCommitmentMode &&
+			cfg.CommitmentMode != commitments.OptimismGenericCommitmentMode {
+			return fmt.Errorf("commitment mode %q can't be backfilled, expected %s or %s", cfg.CommitmentMode,
+				commitments.StandardCommitmentMode, commitments.OptimismGenericCommitmentMode)
+		}
+	default:
+		return fmt.Errorf("either the commitments file or the L1 ETH RPC is required")
+	}
+
+	if cfg.Concurrency <= 0 {
+		return fmt.Errorf("concurrency must be positive, got %d", cfg.Concurrency)
+	}
+	if cfg.CheckpointFile != "" && cfg.CheckpointInterval <= 0 {
+		return fmt.Errorf("checkpoint interval must be positive, got %s", cfg.CheckpointInterval)
+	}
+	return nil
+}
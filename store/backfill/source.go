@@ -0,0 +1,223 @@
+package backfill
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Commitment is a commitment to backfill
+type Commitment struct {
+	// Position of the commitment in its source, from which a backfill can be resumed: the line number of the
+	// commitment in the commitments file, or the number of the L1 block which included it.
+	Position      uint64
+	Mode          commitments.CommitmentMode
+	VersionedCert certs.VersionedCert
+	// L1InclusionBlockNum is the L1 block which included the commitment when scanning L1, and 0 otherwise,
+	// which skips the RBN recency check of the cert.
+	L1InclusionBlockNum uint64
+}
+
+// Encode returns the commitment as returned by the proxy, to be reported
+func (c Commitment) Encode() string {
+	commitment, err := commitments.EncodeCommitment(c.VersionedCert, c.Mode)
+	if err != nil {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(commitment)
+}
+
+// Source lists the commitments to backfill
+type Source interface {
+	// Name identifies the source in checkpoints, so that a checkpoint isn't resumed against another source
+	Name() string
+	// Commitments sends the commitments whose position is at least from to out, in increasing position order.
+	// It returns once all of them are sent, or ctx is done.
+	Commitments(ctx context.Context, from uint64, out chan<- Commitment) error
+}
+
+// maxCommitmentLineSize bounds the lines of commitments files, which hold hex encoded certs of a few KiB
+const maxCommitmentLineSize = 1024 * 1024
+
+// FileSource reads the commitments of a file, one hex encoded commitment per line
+type FileSource struct {
+	path string
+	mode commitments.CommitmentMode
+}
+
+var _ Source = (*FileSource)(nil)
+
+func NewFileSource(path string, mode commitments.CommitmentMode) *FileSource {
+	return &FileSource{path: path, mode: mode}
+}
+
+func (s *FileSource) Name() string {
+	return "file:" + s.path
+}
+
+func (s *FileSource) Commitments(ctx context.Context, from uint64, out chan<- Commitment) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("open commitments file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxCommitmentLineSize)
+	var line uint64
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if line < from || text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		versionedCert, decodeErr := decodeHexCommitment(text, s.mode)
+		if decodeErr != nil {
+			return fmt.Errorf("line %d: %w", line, decodeErr)
+		}
+		select {
+		case out <- Commitment{Position: line, Mode: s.mode, VersionedCert: versionedCert}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("read commitments file: %w", err)
+	}
+	return nil
+}
+
+func decodeHexCommitment(text string, mode commitments.CommitmentMode) (certs.VersionedCert, error) {
+	commitment, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+	if err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("decode hex commitment: %w", err)
+	}
+	versionedCert, err := commitments.DecodeCommitment(commitment, mode)
+	if err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("decode commitment: %w", err)
+	}
+	return versionedCert, nil
+}
+
+// L1Client is the subset of the L1 ETH client used to scan batch inboxes
+type L1Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// altDADerivationVersion is the version byte prefixing the altda commitments posted to batch inboxes.
+// See https://specs.optimism.io/experimental/alt-da.html#input-commitment-submission
+const altDADerivationVersion = 0x01
+
+// L1Source reads the EigenDA altda commitments posted to the batch inbox of an OP stack rollup, in a range of L1
+// blocks. Other transactions (such as frames of batches posted to ethereum, or keccak commitments) are skipped.
+type L1Source struct {
+	log        logging.Logger
+	client     L1Client
+	batchInbox geth_common.Address
+	// batcher is the only sender whose transactions are read. The zero address means any sender.
+	batcher   geth_common.Address
+	fromBlock uint64
+	toBlock   uint64
+}
+
+var _ Source = (*L1Source)(nil)
+
+func NewL1Source(
+	log logging.Logger,
+	client L1Client,
+	batchInbox geth_common.Address,
+	batcher geth_common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) *L1Source {
+	return &L1Source{
+		log:        log,
+		client:     client,
+		batchInbox: batchInbox,
+		batcher:    batcher,
+		fromBlock:  fromBlock,
+		toBlock:    toBlock,
+	}
+}
+
+func (s *L1Source) Name() string {
+	return fmt.Sprintf("l1:%s:%d-%d", s.batchInbox.Hex(), s.fromBlock, s.toBlock)
+}
+
+func (s *L1Source) Commitments(ctx context.Context, from uint64, out chan<- Commitment) error {
+	chainID, err := s.client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("get chain ID: %w", err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+
+	for number := max(from, s.fromBlock); number <= s.toBlock; number++ {
+		block, blockErr := s.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if blockErr != nil {
+			return fmt.Errorf("get block %d: %w", number, blockErr)
+		}
+
+		for _, tx := range block.Transactions() {
+			if tx.To() == nil || *tx.To() != s.batchInbox {
+				continue
+			}
+			if s.batcher != (geth_common.Address{}) {
+				sender, senderErr := types.Sender(signer, tx)
+				if senderErr != nil || sender != s.batcher {
+					continue
+				}
+			}
+
+			versionedCert, ok, decodeErr := commitmentFromTxData(tx.Data())
+			if decodeErr != nil {
+				s.log.Warn("Skipping undecodable altda commitment",
+					"block", number, "tx", tx.Hash(), "err", decodeErr)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			commitment := Commitment{
+				Position:            number,
+				Mode:                commitments.OptimismGenericCommitmentMode,
+				VersionedCert:       versionedCert,
+				L1InclusionBlockNum: number,
+			}
+			select {
+			case out <- commitment:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// commitmentFromTxData returns the cert of the EigenDA altda commitment of the data of a batch inbox transaction.
+// It returns false for transactions which don't hold such a commitment.
+func commitmentFromTxData(data []byte) (certs.VersionedCert, bool, error) {
+	if len(data) < 2 || data[0] != altDADerivationVersion {
+		return certs.VersionedCert{}, false, nil
+	}
+	// keccak commitments are stored in S3 by their preimage, and have no cert to fetch from EigenDA
+	if data[1] != byte(commitments.OPGenericCommitmentByte) {
+		return certs.VersionedCert{}, false, nil
+	}
+	versionedCert, err := commitments.DecodeCommitment(data[1:], commitments.OptimismGenericCommitmentMode)
+	if err != nil {
+		return certs.VersionedCert{}, false, err
+	}
+	return versionedCert, true, nil
+}
@@ -0,0 +1,113 @@
+package backfill
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainID = big.NewInt(1)
+
+type fakeL1Client struct {
+	blocks map[uint64]*types.Block
+}
+
+func (c *fakeL1Client) ChainID(_ context.Context) (*big.Int, error) {
+	return testChainID, nil
+}
+
+func (c *fakeL1Client) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if block, ok := c.blocks[number.Uint64()]; ok {
+		return block, nil
+	}
+	return types.NewBlockWithHeader(&types.Header{Number: number}), nil
+}
+
+func TestL1Source(t *testing.T) {
+	batcherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	batchInbox := geth_common.HexToAddress("0xff00000000000000000000000000000000000420")
+	otherAddress := geth_common.HexToAddress("0x1234")
+	signer := types.LatestSignerForChainID(testChainID)
+
+	var nonce uint64
+	tx := func(key *ecdsa.PrivateKey, to geth_common.Address, data []byte) *types.Transaction {
+		nonce++
+		signed, signErr := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID: testChainID,
+			Nonce:   nonce,
+			To:      &to,
+			Data:    data,
+		})
+		require.NoError(t, signErr)
+		return signed
+	}
+	genericCommitment := func(cert string) []byte {
+		// altda derivation version, op generic commitment byte, EigenDA layer byte, cert version byte
+		return append([]byte{0x01, 0x01, 0x00, byte(certs.V2VersionByte)}, cert...)
+	}
+
+	block := func(number uint64, txs ...*types.Transaction) *types.Block {
+		header := &types.Header{Number: new(big.Int).SetUint64(number)}
+		return types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+	}
+	client := &fakeL1Client{blocks: map[uint64]*types.Block{
+		10: block(10,
+			tx(batcherKey, batchInbox, genericCommitment("cert-a")),
+			// not sent to the batch inbox
+			tx(batcherKey, otherAddress, genericCommitment("cert-x")),
+			// not sent by the batcher
+			tx(otherKey, batchInbox, genericCommitment("cert-y")),
+		),
+		11: block(11,
+			// keccak commitment
+			tx(batcherKey, batchInbox, append([]byte{0x01, 0x00}, crypto.Keccak256([]byte("payload"))...)),
+			// frames of a batch posted to ethereum
+			tx(batcherKey, batchInbox, []byte{0x00, 0xaa, 0xbb}),
+			tx(batcherKey, batchInbox, genericCommitment("cert-b")),
+		),
+		13: block(13, tx(batcherKey, batchInbox, genericCommitment("cert-c"))),
+		// out of the scanned range
+		15: block(15, tx(batcherKey, batchInbox, genericCommitment("cert-z"))),
+	}}
+
+	source := NewL1Source(testLogger, client, batchInbox, crypto.PubkeyToAddress(batcherKey.PublicKey), 10, 14)
+	read := func(from uint64) []Commitment {
+		out := make(chan Commitment, 10)
+		require.NoError(t, source.Commitments(context.Background(), from, out))
+		close(out)
+		var read []Commitment
+		for commitment := range out {
+			read = append(read, commitment)
+		}
+		return read
+	}
+
+	commitmentsRead := read(0)
+	require.Len(t, commitmentsRead, 3)
+	for i, expected := range []struct {
+		block uint64
+		cert  string
+	}{{10, "cert-a"}, {11, "cert-b"}, {13, "cert-c"}} {
+		require.Equal(t, expected.block, commitmentsRead[i].Position)
+		require.Equal(t, expected.block, commitmentsRead[i].L1InclusionBlockNum)
+		require.Equal(t, commitments.OptimismGenericCommitmentMode, commitmentsRead[i].Mode)
+		require.Equal(t, certs.NewVersionedCert([]byte(expected.cert), certs.V2VersionByte),
+			commitmentsRead[i].VersionedCert)
+	}
+
+	// resuming skips the blocks before the resume position
+	commitmentsRead = read(11)
+	require.Len(t, commitmentsRead, 2)
+	require.Equal(t, uint64(11), commitmentsRead[0].Position)
+}
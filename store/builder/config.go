@@ -11,9 +11,14 @@ import (
 	"github.com/Layr-Labs/eigenda-proxy/store"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/recording"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2/payments"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/gcs"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli/v2"
 )
@@ -28,13 +33,30 @@ type Config struct {
 	VerifierConfigV1 verify.Config
 	KzgConfig        kzg.KzgConfig
 	ClientConfigV2   common.ClientConfigV2
+	// payment accounting of the EigenDA V2 signer account
+	PaymentsConfig payments.Config
+	// PaymentsTracker holds the latest payment state polled from the disperser. Can be nil.
+	PaymentsTracker *payments.Tracker `json:"-"`
 
 	MemstoreConfig  *memconfig.SafeConfig
 	MemstoreEnabled bool
+	// MemstoreExpiryNotifier receives the blobs pruned from memstore due to expiration. Can be nil.
+	MemstoreExpiryNotifier   *expiry.Notifier `json:"-"`
+	MemstoreExpiryWebhookURL string
+
+	// record-and-replay of the EigenDA backends
+	RecordingConfig recording.Config
 
 	// secondary storage cfgs
 	RedisConfig redis.Config
 	S3Config    s3.Config
+	GCSConfig   gcs.Config
+
+	// tenants of the proxy, with their own signer, quota and secondary storage prefix
+	TenantsConfig tenant.Config
+
+	// Reloader applies reloaded configs to the stores built from this config. Can be nil.
+	Reloader *Reloader `json:"-"`
 }
 
 // ReadConfig ... parses the Config from the provided flags or environment variables.
@@ -56,11 +78,17 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 	}
 
 	var clientConfigV2 common.ClientConfigV2
+	var paymentsConfig payments.Config
 	if slices.Contains(storeConfig.BackendsToEnable, common.V2EigenDABackend) {
 		clientConfigV2, err = eigendaflags_v2.ReadClientConfigV2(ctx)
 		if err != nil {
 			return Config{}, fmt.Errorf("read client config v2: %w", err)
 		}
+
+		paymentsConfig, err = payments.ReadConfig(ctx)
+		if err != nil {
+			return Config{}, fmt.Errorf("read payments config: %w", err)
+		}
 	}
 
 	var maxBlobSizeBytes uint64
@@ -74,42 +102,90 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 			common.EigenDABackendToString(storeConfig.DispersalBackend))
 	}
 
+	tenantsConfig, err := tenant.ReadConfig(ctx)
+	if err != nil {
+		return Config{}, fmt.Errorf("read tenants config: %w", err)
+	}
+
 	memstoreConfig, err := memstore.ReadConfig(ctx, maxBlobSizeBytes)
 	if err != nil {
 		return Config{}, fmt.Errorf("read memstore config: %w", err)
 	}
 
 	cfg := Config{
-		StoreConfig:      storeConfig,
-		ClientConfigV1:   clientConfigV1,
-		VerifierConfigV1: verifierConfigV1,
-		KzgConfig:        verify.ReadKzgConfig(ctx, maxBlobSizeBytes),
-		ClientConfigV2:   clientConfigV2,
-		MemstoreConfig:   memstoreConfig,
-		MemstoreEnabled:  ctx.Bool(memstore.EnabledFlagName),
-		RedisConfig:      redis.ReadConfig(ctx),
-		S3Config:         s3.ReadConfig(ctx),
+		StoreConfig:              storeConfig,
+		ClientConfigV1:           clientConfigV1,
+		VerifierConfigV1:         verifierConfigV1,
+		KzgConfig:                verify.ReadKzgConfig(ctx, maxBlobSizeBytes),
+		ClientConfigV2:           clientConfigV2,
+		PaymentsConfig:           paymentsConfig,
+		PaymentsTracker:          payments.NewTracker(),
+		MemstoreConfig:           memstoreConfig,
+		MemstoreEnabled:          ctx.Bool(memstore.EnabledFlagName),
+		MemstoreExpiryNotifier:   expiry.NewNotifier(),
+		MemstoreExpiryWebhookURL: ctx.String(memstore.ExpiryWebhookURLFlagName),
+		RecordingConfig:          recording.ReadConfig(ctx),
+		RedisConfig:              redis.ReadConfig(ctx),
+		S3Config:                 s3.ReadConfig(ctx),
+		GCSConfig:                gcs.ReadConfig(ctx),
+		TenantsConfig:            tenantsConfig,
+		Reloader:                 NewReloader(),
 	}
 
 	return cfg, nil
 }
 
+// MaxBlobSizeBytes returns the largest max blob size of the enabled EigenDA backends,
+// since the dispersal backend can be switched at runtime. Returns 0 when no EigenDA backend is enabled.
+func (cfg *Config) MaxBlobSizeBytes() uint64 {
+	var maxBlobSizeBytes uint64
+	if slices.Contains(cfg.StoreConfig.BackendsToEnable, common.V1EigenDABackend) {
+		maxBlobSizeBytes = max(maxBlobSizeBytes, cfg.ClientConfigV1.MaxBlobSizeBytes)
+	}
+	if slices.Contains(cfg.StoreConfig.BackendsToEnable, common.V2EigenDABackend) {
+		maxBlobSizeBytes = max(maxBlobSizeBytes, cfg.ClientConfigV2.MaxBlobSizeBytes)
+	}
+	return maxBlobSizeBytes
+}
+
 // Check ... verifies that configuration values are adequately set
 func (cfg *Config) Check() error {
+	err := cfg.RecordingConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check recording config: %w", err)
+	}
+	if cfg.RecordingConfig.ReplayEnabled() && cfg.MemstoreEnabled {
+		return fmt.Errorf("cannot replay recordings (--%s) when memstore is enabled", recording.ReplayPathFlagName)
+	}
+
 	v1Enabled := slices.Contains(cfg.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
 	if v1Enabled {
-		err := cfg.checkV1Config()
+		err = cfg.checkV1Config()
 		if err != nil {
 			return fmt.Errorf("check v1 config: %w", err)
 		}
 	}
 
 	v2Enabled := slices.Contains(cfg.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
-	if v2Enabled && !cfg.MemstoreEnabled {
-		err := cfg.ClientConfigV2.Check()
+	if v2Enabled && !cfg.MemstoreEnabled && !cfg.RecordingConfig.ReplayEnabled() {
+		err = cfg.ClientConfigV2.Check()
 		if err != nil {
 			return fmt.Errorf("check v2 config: %w", err)
 		}
+		err = cfg.PaymentsConfig.Check()
+		if err != nil {
+			return fmt.Errorf("check payments config: %w", err)
+		}
+	}
+
+	err = cfg.TenantsConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check tenants config: %w", err)
+	}
+	hasTenantSigner := slices.ContainsFunc(cfg.TenantsConfig.Tenants,
+		func(t tenant.Tenant) bool { return t.Signer != nil })
+	if hasTenantSigner && !v2Enabled {
+		return fmt.Errorf("tenant signers are only used for EigenDA V2 dispersals, but the V2 backend is not enabled")
 	}
 
 	if cfg.S3Config.CredentialType == s3.CredentialTypeUnknown && cfg.S3Config.Endpoint != "" {
@@ -121,14 +197,63 @@ func (cfg *Config) Check() error {
 		}
 	}
 
+	if cfg.GCSConfig.CredentialType == gcs.CredentialTypeUnknown && cfg.GCSConfig.Bucket != "" {
+		return fmt.Errorf("gcs credential type must be set")
+	}
+	if cfg.GCSConfig.CredentialType == gcs.CredentialTypeServiceAccount && cfg.GCSConfig.Bucket != "" &&
+		cfg.GCSConfig.CredentialsFile == "" && cfg.GCSConfig.CredentialsJSON == "" {
+		return fmt.Errorf("gcs bucket is set with service-account credentials, but neither credentials file nor " +
+			"credentials json is set")
+	}
+
 	if cfg.RedisConfig.Endpoint == "" && cfg.RedisConfig.Password != "" {
 		return fmt.Errorf("redis password is set, but endpoint is not")
 	}
 
-	return cfg.StoreConfig.Check()
+	err = cfg.StoreConfig.Check()
+	if err != nil {
+		return err
+	}
+	err = cfg.checkTargetsConfigured("fallback", cfg.StoreConfig.FallbackTargets)
+	if err != nil {
+		return err
+	}
+	return cfg.checkTargetsConfigured("cache", cfg.StoreConfig.CacheTargets)
+}
+
+// checkTargetsConfigured checks that the secondary storage backends of targets are configured, which would
+// otherwise only be found out when building the store manager
+func (cfg *Config) checkTargetsConfigured(kind string, targets []string) error {
+	for _, target := range targets {
+		if !cfg.secondaryConfigured(common.StringToBackendType(target)) {
+			return fmt.Errorf("%s target %s is not a configured secondary storage backend", kind, target)
+		}
+	}
+	return nil
+}
+
+// secondaryConfigured returns true if the secondary storage backend of backendType is configured
+func (cfg *Config) secondaryConfigured(backendType common.BackendType) bool {
+	//nolint:exhaustive // only the secondary storage backends can be configured
+	switch backendType {
+	case common.S3BackendType:
+		return cfg.S3Config.Bucket != ""
+	case common.RedisBackendType:
+		return cfg.RedisConfig.Endpoint != ""
+	case common.GCSBackendType:
+		return cfg.GCSConfig.Bucket != ""
+	default:
+		return false
+	}
 }
 
 func (cfg *Config) checkV1Config() error {
+	if cfg.RecordingConfig.ReplayEnabled() {
+		// the EigenDA network is never contacted when replaying recordings,
+		// so none of the client or verifier values are needed.
+		return nil
+	}
+
 	if cfg.MemstoreEnabled {
 		// provide dummy values to eigenda client config. Since the client won't be called in this
 		// mode it doesn't matter.
@@ -157,6 +282,9 @@ func (cfg *Config) checkV1Config() error {
 		if cfg.VerifierConfigV1.RPCURL == "" {
 			return fmt.Errorf("cert verification enabled but eth rpc is not set")
 		}
+		if err := cfg.VerifierConfigV1.EthRPC.Check(len(cfg.VerifierConfigV1.RPCURLs())); err != nil {
+			return fmt.Errorf("check eth rpc config: %w", err)
+		}
 		if cfg.ClientConfigV1.EdaClientCfg.SvcManagerAddr == "" || cfg.VerifierConfigV1.SvcManagerAddr == "" {
 			return fmt.Errorf("cert verification enabled but svc manager address is not set")
 		}
@@ -166,6 +294,16 @@ func (cfg *Config) checkV1Config() error {
 }
 
 func (cfg *Config) ToString() (string, error) {
+	configJSON, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return string(configJSON), nil
+}
+
+// Redacted returns a copy of the config whose secrets are replaced with "******"
+func (cfg *Config) Redacted() Config {
 	redacted := "******"
 
 	// create a copy, otherwise the original values being redacted will be lost
@@ -178,6 +316,9 @@ func (cfg *Config) ToString() (string, error) {
 		// hiding as RPC providers typically use sensitive API keys within
 		configCopy.ClientConfigV1.EdaClientCfg.EthRpcUrl = redacted
 	}
+	if len(configCopy.ClientConfigV1.EthRPCFallbackURLs) > 0 {
+		configCopy.ClientConfigV1.EthRPCFallbackURLs = []string{redacted}
+	}
 	if configCopy.RedisConfig.Password != "" {
 		configCopy.RedisConfig.Password = redacted
 	}
@@ -187,11 +328,18 @@ func (cfg *Config) ToString() (string, error) {
 	if configCopy.S3Config.AccessKeyID != "" {
 		configCopy.S3Config.AccessKeyID = redacted
 	}
-
-	configJSON, err := json.MarshalIndent(configCopy, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal config: %w", err)
+	if configCopy.GCSConfig.CredentialsJSON != "" {
+		configCopy.GCSConfig.CredentialsJSON = redacted
+	}
+	if len(configCopy.TenantsConfig.Tenants) > 0 {
+		// the hashes are unsalted, so low entropy API keys could be brute-forced from them
+		configCopy.TenantsConfig.Tenants = slices.Clone(configCopy.TenantsConfig.Tenants)
+		for i := range configCopy.TenantsConfig.Tenants {
+			if configCopy.TenantsConfig.Tenants[i].APIKeySHA256 != "" {
+				configCopy.TenantsConfig.Tenants[i].APIKeySHA256 = redacted
+			}
+		}
 	}
 
-	return string(configJSON), nil
+	return configCopy
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda-proxy/store"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/gcs"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/api/clients"
@@ -202,5 +203,42 @@ func TestConfigVerification(t *testing.T) {
 			err := cfg.Check()
 			require.Error(t, err)
 		})
+
+		t.Run("MissingGCSServiceAccountKey", func(t *testing.T) {
+			cfg := validCfg()
+
+			cfg.GCSConfig.Bucket = "test-bucket"
+			cfg.GCSConfig.CredentialType = gcs.CredentialTypeServiceAccount
+
+			err := cfg.Check()
+			require.Error(t, err)
+
+			cfg.GCSConfig.CredentialsFile = "/secrets/gcs-key.json"
+			require.NoError(t, cfg.Check())
+		})
+
+		t.Run("MissingGCSCredential", func(t *testing.T) {
+			cfg := validCfg()
+
+			cfg.GCSConfig.Bucket = "test-bucket"
+			cfg.GCSConfig.CredentialType = gcs.CredentialTypeUnknown
+
+			err := cfg.Check()
+			require.Error(t, err)
+		})
+
+		t.Run("UnconfiguredTargets", func(t *testing.T) {
+			cfg := validCfg()
+			cfg.StoreConfig.CacheTargets = []string{"redis"}
+			cfg.StoreConfig.FallbackTargets = []string{"s3"}
+			require.NoError(t, cfg.Check())
+
+			cfg.RedisConfig = redis.Config{}
+			require.ErrorContains(t, cfg.Check(), "cache target redis is not a configured")
+
+			cfg = validCfg()
+			cfg.StoreConfig.FallbackTargets = []string{"gcs"}
+			require.ErrorContains(t, cfg.Check(), "fallback target gcs is not a configured")
+		})
 	})
 }
@@ -0,0 +1,56 @@
+package builder
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
+	common_eigenda "github.com/Layr-Labs/eigenda/common"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// failoverEthClient is an EthClient whose eth_calls and chain queries fail over between multiple ETH RPC endpoints.
+// All other methods (which proxy uses rarely, if at all) are served by the embedded primary client.
+type failoverEthClient struct {
+	common_eigenda.EthClient
+	rpc *ethrpc.Client
+	// caller serves eth_calls. It is either rpc itself, or a quorum caller for cert verification.
+	caller bind.ContractCaller
+}
+
+func newFailoverEthClient(primary common_eigenda.EthClient, rpc *ethrpc.Client) *failoverEthClient {
+	return &failoverEthClient{
+		EthClient: primary,
+		rpc:       rpc,
+		caller:    rpc,
+	}
+}
+
+// verifying returns a copy of the client whose eth_calls are subject to quorum reads, if enabled.
+func (c *failoverEthClient) verifying() *failoverEthClient {
+	return &failoverEthClient{
+		EthClient: c.EthClient,
+		rpc:       c.rpc,
+		caller:    c.rpc.QuorumCaller(),
+	}
+}
+
+func (c *failoverEthClient) CallContract(
+	ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.caller.CallContract(ctx, msg, blockNumber)
+}
+
+func (c *failoverEthClient) CodeAt(
+	ctx context.Context, account geth_common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.caller.CodeAt(ctx, account, blockNumber)
+}
+
+func (c *failoverEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.rpc.BlockNumber(ctx)
+}
+
+func (c *failoverEthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.rpc.ChainID(ctx)
+}
@@ -0,0 +1,53 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// checkNetworkContracts logs which V2 addresses and endpoints were derived from the EigenDA network preset and which
+// were explicitly configured, and checks that a contract is deployed at each of the configured contract addresses.
+// This catches addresses of another network, which would otherwise only fail on the first cert verification.
+func checkNetworkContracts(
+	ctx context.Context,
+	log logging.Logger,
+	cfg common.ClientConfigV2,
+	ethClient bind.ContractCaller,
+) error {
+	for _, value := range cfg.NetworkValues() {
+		log.Info("EigenDA V2 network value",
+			"name", value.Name, "value", value.Value, "source", value.Source, "network", cfg.EigenDANetwork)
+		if value.Name == common.DisperserAddressName || value.Source == common.ConfigSourceUnset {
+			continue
+		}
+
+		address := geth_common.HexToAddress(value.Value)
+		code, err := ethClient.CodeAt(ctx, address, nil)
+		if err != nil {
+			return fmt.Errorf("get code at %s %s: %w", value.Name, address.Hex(), err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("no contract is deployed at %s %s (source: %s)", value.Name, address.Hex(), value.Source)
+		}
+	}
+	return nil
+}
+
+// checkRelayRegistry checks that the configured relay registry, if any, is the one registered in the service manager
+func checkRelayRegistry(cfg common.ClientConfigV2, serviceManagerRelayRegistry geth_common.Address) error {
+	if cfg.RelayRegistryAddr == "" {
+		return nil
+	}
+	relayRegistry := geth_common.HexToAddress(cfg.RelayRegistryAddr)
+	if relayRegistry != serviceManagerRelayRegistry {
+		return fmt.Errorf("%s %s doesn't match the relay registry %s of service manager %s",
+			common.RelayRegistryAddressName, relayRegistry.Hex(),
+			serviceManagerRelayRegistry.Hex(), cfg.EigenDAServiceManagerAddr)
+	}
+	return nil
+}
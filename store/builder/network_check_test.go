@@ -0,0 +1,70 @@
+package builder
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// codeCaller is a bind.ContractCaller with contracts deployed at a fixed set of addresses
+type codeCaller map[geth_common.Address]bool
+
+func (c codeCaller) CodeAt(_ context.Context, contract geth_common.Address, _ *big.Int) ([]byte, error) {
+	if c[contract] {
+		return []byte{0x60, 0x80}, nil
+	}
+	return nil, nil
+}
+
+func (c codeCaller) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func TestCheckNetworkContracts(t *testing.T) {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	router := "0x0000000000000000000000000000000000000001"
+	serviceManager := "0x0000000000000000000000000000000000000002"
+	cfg := common.ClientConfigV2{
+		EigenDACertVerifierOrRouterAddress: router,
+		EigenDAServiceManagerAddr:          serviceManager,
+		Sources: map[string]common.ConfigSource{
+			common.CertVerifierRouterAddressName: common.ConfigSourceExplicit,
+			common.ServiceManagerAddressName:     common.ConfigSourceNetworkPreset,
+		},
+	}
+
+	t.Run("AllDeployed", func(t *testing.T) {
+		caller := codeCaller{
+			geth_common.HexToAddress(router):         true,
+			geth_common.HexToAddress(serviceManager): true,
+		}
+		require.NoError(t, checkNetworkContracts(context.Background(), testLogger, cfg, caller))
+	})
+
+	t.Run("NotDeployed", func(t *testing.T) {
+		caller := codeCaller{geth_common.HexToAddress(router): true}
+		err := checkNetworkContracts(context.Background(), testLogger, cfg, caller)
+		require.ErrorContains(t, err, common.ServiceManagerAddressName)
+		require.ErrorContains(t, err, string(common.ConfigSourceNetworkPreset))
+	})
+}
+
+func TestCheckRelayRegistry(t *testing.T) {
+	relayRegistry := "0x0000000000000000000000000000000000000003"
+
+	cfg := common.ClientConfigV2{}
+	require.NoError(t, checkRelayRegistry(cfg, geth_common.HexToAddress(relayRegistry)))
+
+	cfg.RelayRegistryAddr = relayRegistry
+	require.NoError(t, checkRelayRegistry(cfg, geth_common.HexToAddress(relayRegistry)))
+
+	cfg.RelayRegistryAddr = "0x0000000000000000000000000000000000000004"
+	require.Error(t, checkRelayRegistry(cfg, geth_common.HexToAddress(relayRegistry)))
+}
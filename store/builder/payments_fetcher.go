@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2/payments"
+	clients_v2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+)
+
+// disperserPaymentFetcher ... fetches the payment state of the signer account from the EigenDA V2 disperser,
+// which tracks both the on-chain payment vault state and the usage it has metered.
+type disperserPaymentFetcher struct {
+	client *clients_v2.DisperserClient
+}
+
+var _ payments.Fetcher = (*disperserPaymentFetcher)(nil)
+
+func (f *disperserPaymentFetcher) FetchPaymentState(ctx context.Context) (payments.Snapshot, error) {
+	reply, err := f.client.GetPaymentState(ctx)
+	if err != nil {
+		return payments.Snapshot{}, fmt.Errorf("get payment state from disperser: %w", err)
+	}
+
+	snapshot := payments.Snapshot{
+		ReservationWindow: time.Duration(
+			reply.GetPaymentGlobalParams().GetReservationWindow()) * time.Second, // #nosec G115
+		PeriodUsage:          make(map[uint64]uint64, len(reply.GetPeriodRecords())),
+		OnchainDepositWei:    new(big.Int).SetBytes(reply.GetOnchainCumulativePayment()),
+		CumulativePaymentWei: new(big.Int).SetBytes(reply.GetCumulativePayment()),
+	}
+	if reservation := reply.GetReservation(); reservation != nil {
+		snapshot.ReservationSymbolsPerSecond = reservation.GetSymbolsPerSecond()
+		snapshot.ReservationStart = time.Unix(int64(reservation.GetStartTimestamp()), 0)
+		snapshot.ReservationEnd = time.Unix(int64(reservation.GetEndTimestamp()), 0)
+	}
+	for _, record := range reply.GetPeriodRecords() {
+		if record == nil {
+			continue
+		}
+		snapshot.PeriodUsage[uint64(record.GetIndex())] = record.GetUsage()
+	}
+
+	return snapshot, nil
+}
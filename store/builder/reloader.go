@@ -0,0 +1,68 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	eigenda_v2 "github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary"
+)
+
+// Reloader ... applies the settings of a reloaded Config which can be changed at runtime to the stores built by
+// BuildStoreManager: the secondary targets and their order, WriteOnCacheMiss, the redis eviction time and
+// the RBN recency window. The memstore config is shared with the stores, and is updated by the config reloader.
+type Reloader struct {
+	mu sync.Mutex
+
+	secondary secondary.ISecondary
+	backends  secondaryBackends
+	// v2Store is nil when the EigenDA V2 backend is disabled or replaced by memstore or a recording
+	v2Store *eigenda_v2.Store
+}
+
+// NewReloader ... constructor
+func NewReloader() *Reloader {
+	return &Reloader{}
+}
+
+func (r *Reloader) register(secondary secondary.ISecondary, backends secondaryBackends) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secondary = secondary
+	r.backends = backends
+}
+
+func (r *Reloader) registerV2Store(v2Store *eigenda_v2.Store) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.v2Store = v2Store
+}
+
+// Reload applies the runtime settings of cfg. The other settings of cfg are ignored. Returns an error without
+// applying anything if cfg targets a secondary backend which was not configured when the stores were built.
+func (r *Reloader) Reload(cfg Config) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.secondary == nil {
+		return errors.New("stores not built")
+	}
+
+	fallbacks, err := buildSecondaries(cfg.StoreConfig.FallbackTargets, r.backends)
+	if err != nil {
+		return fmt.Errorf("build fallback targets: %w", err)
+	}
+	caches, err := buildSecondaries(cfg.StoreConfig.CacheTargets, r.backends)
+	if err != nil {
+		return fmt.Errorf("build cache targets: %w", err)
+	}
+
+	r.secondary.SetTargets(caches, fallbacks, cfg.StoreConfig.WriteOnCacheMiss)
+	if r.backends.redis != nil {
+		r.backends.redis.SetEviction(cfg.RedisConfig.Eviction)
+	}
+	if r.v2Store != nil {
+		r.v2Store.SetRBNRecencyWindowSize(cfg.ClientConfigV2.RBNRecencyWindowSize)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	core_v2 "github.com/Layr-Labs/eigenda/core/v2"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// remoteBlobRequestSigner ... signs dispersal payments with a [signer.RemoteSigner].
+// It computes the same digests as the local blob request signer, and only delegates their signing,
+// so that the disperser can't tell both apart.
+type remoteBlobRequestSigner struct {
+	remote *signer.RemoteSigner
+}
+
+var _ core_v2.BlobRequestSigner = (*remoteBlobRequestSigner)(nil)
+
+func (s *remoteBlobRequestSigner) SignBlobRequest(header *core_v2.BlobHeader) ([]byte, error) {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("get blob key: %w", err)
+	}
+	// the signer interface doesn't take a context: requests are bounded by the remote signer timeout instead
+	sig, err := s.remote.SignDigest(context.Background(), blobKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign blob key: %w", err)
+	}
+	return sig, nil
+}
+
+func (s *remoteBlobRequestSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
+	requestHash, err := hashing.HashGetPaymentStateRequest(s.remote.Address(), timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("hash payment state request: %w", err)
+	}
+	sig, err := s.remote.SignDigest(context.Background(), requestHash)
+	if err != nil {
+		return nil, fmt.Errorf("sign payment state request: %w", err)
+	}
+	return sig, nil
+}
+
+func (s *remoteBlobRequestSigner) GetAccountID() (geth_common.Address, error) {
+	return s.remote.Address(), nil
+}
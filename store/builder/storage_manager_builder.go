@@ -2,6 +2,7 @@ package builder
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -10,16 +11,23 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	memstore_v2 "github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/v2"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/recording"
 	eigenda_v2 "github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/v2/payments"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/gcs"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/Layr-Labs/eigenda/api/clients"
 	clients_v2 "github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
@@ -54,26 +62,13 @@ func BuildStoreManager(
 	config Config,
 	secrets common.SecretConfigV2,
 ) (*store.Manager, error) {
-	var err error
-	var s3Store *s3.Store
-	var redisStore *redis.Store
 	var eigenDAV1Store common.EigenDAV1Store
 	var eigenDAV2Store common.EigenDAV2Store
 
-	if config.S3Config.Bucket != "" {
-		log.Info("Using S3 storage backend")
-		s3Store, err = s3.NewStore(config.S3Config)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if config.RedisConfig.Endpoint != "" {
-		log.Info("Using Redis storage backend")
-		redisStore, err = redis.NewStore(&config.RedisConfig)
-		if err != nil {
-			return nil, err
-		}
+	// all the configured secondary backends are built, so that the targets using them can be enabled by a reload
+	backends, err := buildSecondaryBackends(ctx, log, config, func(common.BackendType) bool { return true })
+	if err != nil {
+		return nil, err
 	}
 
 	v1Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
@@ -85,6 +80,96 @@ func BuildStoreManager(
 		return nil, fmt.Errorf("dispersal backend is set to V1, but V1 backend is not enabled")
 	}
 
+	if config.RecordingConfig.ReplayEnabled() {
+		eigenDAV1Store, eigenDAV2Store, err = buildReplayBackends(log, config, v1Enabled, v2Enabled)
+		if err != nil {
+			return nil, fmt.Errorf("build replay backends: %w", err)
+		}
+	} else {
+		eigenDAV1Store, eigenDAV2Store, err = buildEigenDABackends(
+			ctx, log, metrics, config, secrets, v1Enabled, v2Enabled)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// wrap the EigenDA backends so that every call is traced
+	if eigenDAV1Store != nil {
+		eigenDAV1Store = tracing.NewTracedV1Store(eigenDAV1Store)
+	}
+	if eigenDAV2Store != nil {
+		eigenDAV2Store = tracing.NewTracedV2Store(eigenDAV2Store)
+	}
+
+	if config.MemstoreEnabled && config.MemstoreExpiryWebhookURL != "" && config.MemstoreExpiryNotifier != nil {
+		log.Info("Posting memstore expiry events to webhook", "url", config.MemstoreExpiryWebhookURL)
+		go expiry.RunWebhook(ctx, log, config.MemstoreExpiryNotifier, config.MemstoreExpiryWebhookURL)
+	}
+
+	fallbacks, err := buildSecondaries(config.StoreConfig.FallbackTargets, backends)
+	if err != nil {
+		return nil, fmt.Errorf("build fallback targets: %w", err)
+	}
+	caches, err := buildSecondaries(config.StoreConfig.CacheTargets, backends)
+	if err != nil {
+		return nil, fmt.Errorf("build cache targets: %w", err)
+	}
+	secondary := secondary.NewSecondaryManager(log, metrics, caches, fallbacks, config.StoreConfig.WriteOnCacheMiss)
+
+	if config.Reloader != nil {
+		config.Reloader.register(secondary, backends)
+	}
+
+	// only spin-up go routines if a secondary storage backend is configured. The targets using it can be
+	// enabled later by a config reload.
+	if backends.configured() {
+		log.Info("Starting secondary write loop(s)", "count", config.StoreConfig.AsyncPutWorkers)
+
+		for i := 0; i < config.StoreConfig.AsyncPutWorkers; i++ {
+			go secondary.WriteSubscriptionLoop(ctx)
+		}
+	}
+
+	log.Info(
+		"Created storage backends",
+		"eigenda_v1", eigenDAV1Store != nil,
+		"eigenda_v2", eigenDAV2Store != nil,
+		"s3", backends.s3 != nil,
+		"redis", backends.redis != nil,
+		"gcs", backends.gcs != nil,
+		"read_fallback", len(fallbacks) > 0,
+		"caching", len(caches) > 0,
+		"async_secondary_writes", (secondary.Enabled() && config.StoreConfig.AsyncPutWorkers > 0),
+		"verify_v1_certs", config.VerifierConfigV1.VerifyCerts,
+		"recording", config.RecordingConfig.RecordEnabled(),
+		"replaying", config.RecordingConfig.ReplayEnabled(),
+	)
+
+	return store.NewManager(
+		eigenDAV1Store,
+		eigenDAV2Store,
+		backends.s3,
+		log,
+		secondary,
+		config.StoreConfig.DispersalBackend,
+	)
+}
+
+// buildEigenDABackends ... Builds the enabled EigenDA V1 and V2 storage backends,
+// wrapping them with recording stores when recording is enabled.
+func buildEigenDABackends(
+	ctx context.Context,
+	log logging.Logger,
+	metrics metrics.Metricer,
+	config Config,
+	secrets common.SecretConfigV2,
+	v1Enabled bool,
+	v2Enabled bool,
+) (common.EigenDAV1Store, common.EigenDAV2Store, error) {
+	var err error
+	var eigenDAV1Store common.EigenDAV1Store
+	var eigenDAV2Store common.EigenDAV2Store
+
 	var kzgVerifier *kzgverifier.Verifier
 	// there are two cases in which we need to construct the kzgVerifier:
 	// 1. V1
@@ -100,88 +185,199 @@ func BuildStoreManager(
 
 		kzgVerifier, err = kzgverifier.NewVerifier(&kzgConfig, nil)
 		if err != nil {
-			return nil, fmt.Errorf("new kzg verifier: %w", err)
+			return nil, nil, fmt.Errorf("new kzg verifier: %w", err)
 		}
 	}
 
 	if v1Enabled {
 		log.Info("Building EigenDA v1 storage backend")
-		eigenDAV1Store, err = buildEigenDAV1Backend(ctx, log, config, kzgVerifier)
+		eigenDAV1Store, err = buildEigenDAV1Backend(ctx, log, metrics, config, kzgVerifier)
 		if err != nil {
-			return nil, fmt.Errorf("build v1 backend: %w", err)
+			return nil, nil, fmt.Errorf("build v1 backend: %w", err)
 		}
 	}
 
 	if v2Enabled {
 		log.Info("Building EigenDA v2 storage backend")
-		eigenDAV2Store, err = buildEigenDAV2Backend(ctx, log, config, secrets, kzgVerifier)
+		eigenDAV2Store, err = buildEigenDAV2Backend(ctx, log, metrics, config, secrets, kzgVerifier)
 		if err != nil {
-			return nil, fmt.Errorf("build v2 backend: %w", err)
+			return nil, nil, fmt.Errorf("build v2 backend: %w", err)
 		}
 	}
 
-	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, redisStore)
-	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, redisStore)
-	secondary := secondary.NewSecondaryManager(log, metrics, caches, fallbacks, config.StoreConfig.WriteOnCacheMiss)
-
-	if secondary.Enabled() { // only spin-up go routines if secondary storage is enabled
-		log.Info("Starting secondary write loop(s)", "count", config.StoreConfig.AsyncPutWorkers)
+	if !config.RecordingConfig.RecordEnabled() {
+		return eigenDAV1Store, eigenDAV2Store, nil
+	}
 
-		for i := 0; i < config.StoreConfig.AsyncPutWorkers; i++ {
-			go secondary.WriteSubscriptionLoop(ctx)
+	log.Info("Recording EigenDA requests", "path", config.RecordingConfig.RecordPath)
+	writer, err := recording.NewWriter(config.RecordingConfig.RecordPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new recording writer: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		if closeErr := writer.Close(); closeErr != nil {
+			log.Error("failed to close recording file", "err", closeErr)
 		}
+	}()
+
+	if eigenDAV1Store != nil {
+		eigenDAV1Store = recording.NewRecordingV1Store(eigenDAV1Store, writer, log)
+	}
+	if eigenDAV2Store != nil {
+		eigenDAV2Store = recording.NewRecordingV2Store(eigenDAV2Store, writer, log)
 	}
 
-	log.Info(
-		"Created storage backends",
-		"eigenda_v1", eigenDAV1Store != nil,
-		"eigenda_v2", eigenDAV2Store != nil,
-		"s3", s3Store != nil,
-		"redis", redisStore != nil,
-		"read_fallback", len(fallbacks) > 0,
-		"caching", len(caches) > 0,
-		"async_secondary_writes", (secondary.Enabled() && config.StoreConfig.AsyncPutWorkers > 0),
-		"verify_v1_certs", config.VerifierConfigV1.VerifyCerts,
-	)
+	return eigenDAV1Store, eigenDAV2Store, nil
+}
 
-	return store.NewManager(
-		eigenDAV1Store,
-		eigenDAV2Store,
-		s3Store,
-		log,
-		secondary,
-		config.StoreConfig.DispersalBackend,
-	)
+// buildReplayBackends ... Builds EigenDA V1 and V2 backends which serve requests from a recording file,
+// without any network access.
+func buildReplayBackends(
+	log logging.Logger,
+	config Config,
+	v1Enabled bool,
+	v2Enabled bool,
+) (common.EigenDAV1Store, common.EigenDAV2Store, error) {
+	log.Info("Replaying EigenDA requests", "path", config.RecordingConfig.ReplayPath)
+	records, err := recording.ReadRecords(config.RecordingConfig.ReplayPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read recordings: %w", err)
+	}
+
+	var eigenDAV1Store common.EigenDAV1Store
+	var eigenDAV2Store common.EigenDAV2Store
+	if v1Enabled {
+		eigenDAV1Store = recording.NewReplayV1Store(log, records, config.RecordingConfig.ReplaySimulateLatency)
+	}
+	if v2Enabled {
+		eigenDAV2Store = recording.NewReplayV2Store(log, records, config.RecordingConfig.ReplaySimulateLatency)
+	}
+
+	return eigenDAV1Store, eigenDAV2Store, nil
+}
+
+// secondaryBackends ... the configured secondary storage backends, which are nil when not configured
+type secondaryBackends struct {
+	s3    *s3.Store
+	redis *redis.Store
+	gcs   *gcs.Store
+}
+
+func (b secondaryBackends) configured() bool {
+	return b.s3 != nil || b.redis != nil || b.gcs != nil
+}
+
+// get returns the backend of target, or an error if it isn't a secondary backend or isn't configured
+func (b secondaryBackends) get(target string) (common.ListableSecondaryStore, error) {
+	//nolint:exhaustive // only the secondary storage backends can be targeted
+	switch common.StringToBackendType(target) {
+	case common.RedisBackendType:
+		if b.redis == nil {
+			return nil, fmt.Errorf("redis backend not configured: %s", target)
+		}
+		return b.redis, nil
+	case common.S3BackendType:
+		if b.s3 == nil {
+			return nil, fmt.Errorf("s3 backend not configured: %s", target)
+		}
+		return b.s3, nil
+	case common.GCSBackendType:
+		if b.gcs == nil {
+			return nil, fmt.Errorf("gcs backend not configured: %s", target)
+		}
+		return b.gcs, nil
+	default:
+		return nil, fmt.Errorf("invalid backend target: %s", target)
+	}
 }
 
 // buildSecondaries ... Creates a slice of secondary targets used for either read
 // failover or caching
-func buildSecondaries(
-	targets []string,
-	s3Store common.SecondaryStore,
-	redisStore *redis.Store,
-) []common.SecondaryStore {
+func buildSecondaries(targets []string, backends secondaryBackends) ([]common.SecondaryStore, error) {
 	stores := make([]common.SecondaryStore, len(targets))
 
 	for i, target := range targets {
-		//nolint:exhaustive // TODO: implement additional secondaries
-		switch common.StringToBackendType(target) {
-		case common.RedisBackendType:
-			if redisStore == nil {
-				panic(fmt.Sprintf("Redis backend not configured: %s", target))
-			}
-			stores[i] = redisStore
-		case common.S3BackendType:
-			if s3Store == nil {
-				panic(fmt.Sprintf("S3 backend not configured: %s", target))
-			}
-			stores[i] = s3Store
+		store, err := backends.get(target)
+		if err != nil {
+			return nil, err
+		}
+		stores[i] = tracing.NewTracedSecondaryStore(store)
+	}
+	return stores, nil
+}
 
-		default:
-			panic(fmt.Sprintf("Invalid backend target: %s", target))
+// BuildSecondaryTargets builds the secondary storage backends of targets (e.g. s3, redis, gcs), configured the same
+// way as the cache and fallback targets of the proxy. It is used by tools writing to secondary storage directly.
+func BuildSecondaryTargets(
+	ctx context.Context, log logging.Logger, config Config, targets []string,
+) ([]common.SecondaryStore, error) {
+	backends, err := buildSecondaryBackends(ctx, log, config, listedIn(targets))
+	if err != nil {
+		return nil, err
+	}
+	return buildSecondaries(targets, backends)
+}
+
+// BuildListableSecondaries builds the secondary storage backends of backends the same way as
+// BuildSecondaryTargets, for tools which also need to list their keys. The backends aren't traced.
+func BuildListableSecondaries(
+	ctx context.Context, log logging.Logger, config Config, backends []string,
+) ([]common.ListableSecondaryStore, error) {
+	built, err := buildSecondaryBackends(ctx, log, config, listedIn(backends))
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]common.ListableSecondaryStore, len(backends))
+	for i, backend := range backends {
+		if stores[i], err = built.get(backend); err != nil {
+			return nil, err
 		}
 	}
-	return stores
+	return stores, nil
+}
+
+// listedIn returns whether a backend type is one of backends
+func listedIn(backends []string) func(common.BackendType) bool {
+	return func(backendType common.BackendType) bool {
+		return slices.ContainsFunc(backends, func(backend string) bool {
+			return common.StringToBackendType(backend) == backendType
+		})
+	}
+}
+
+// buildSecondaryBackends builds the configured secondary storage backends for which wanted returns true
+func buildSecondaryBackends(
+	ctx context.Context, log logging.Logger, config Config, wanted func(common.BackendType) bool,
+) (secondaryBackends, error) {
+	var err error
+	var backends secondaryBackends
+
+	if config.secondaryConfigured(common.S3BackendType) && wanted(common.S3BackendType) {
+		log.Info("Using S3 storage backend")
+		backends.s3, err = s3.NewStore(config.S3Config)
+		if err != nil {
+			return secondaryBackends{}, err
+		}
+	}
+
+	if config.secondaryConfigured(common.RedisBackendType) && wanted(common.RedisBackendType) {
+		log.Info("Using Redis storage backend")
+		backends.redis, err = redis.NewStore(&config.RedisConfig)
+		if err != nil {
+			return secondaryBackends{}, err
+		}
+	}
+
+	if config.secondaryConfigured(common.GCSBackendType) && wanted(common.GCSBackendType) {
+		log.Info("Using GCS storage backend")
+		backends.gcs, err = gcs.NewStore(ctx, config.GCSConfig)
+		if err != nil {
+			return secondaryBackends{}, err
+		}
+	}
+	return backends, nil
 }
 
 // A regexp matching "execution reverted" errors returned from the parent chain RPC.
@@ -206,6 +402,7 @@ func isExecutionReverted(err error) bool {
 func buildEigenDAV2Backend(
 	ctx context.Context,
 	log logging.Logger,
+	metrics metrics.Metricer,
 	config Config,
 	secrets common.SecretConfigV2,
 	kzgVerifier *kzgverifier.Verifier,
@@ -223,64 +420,34 @@ func buildEigenDAV2Backend(
 	}
 
 	if config.MemstoreEnabled {
-		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgProver.Srs.G1)
+		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgProver.Srs.G1, metrics, config.MemstoreExpiryNotifier)
 	}
 
-	ethClient, err := buildEthClient(ctx, log, secrets, config.ClientConfigV2.EigenDANetwork)
+	ethClient, err := buildEthClient(
+		ctx, log, secrets, config.ClientConfigV2.EthRPC, config.ClientConfigV2.EigenDANetwork)
 	if err != nil {
 		return nil, fmt.Errorf("build eth client: %w", err)
 	}
-
-	routerOrImmutableVerifierAddr := geth_common.HexToAddress(config.ClientConfigV2.EigenDACertVerifierOrRouterAddress)
-	caller, err := binding.NewContractEigenDACertVerifierRouterCaller(routerOrImmutableVerifierAddr, ethClient)
+	err = checkNetworkContracts(ctx, log, config.ClientConfigV2, ethClient)
 	if err != nil {
-		return nil, fmt.Errorf("new cert verifier router caller: %w", err)
-	}
-
-	isRouter := true
-	// Check if the router address is actually a router. if method `getCertVerifierAt` fails, it means that the
-	// address is not a router, and we should treat it as an immutable cert verifier instead
-	_, err = caller.GetCertVerifierAt(&bind.CallOpts{Context: ctx}, 0)
-	switch {
-	case err != nil && isExecutionReverted(err):
-		log.Warnf("EigenDA cert verifier router address was detected to not be a router at address (%s), "+
-			"using it as an immutable cert verifier instead", routerOrImmutableVerifierAddr.Hex())
-		isRouter = false
-	case err != nil:
-		return nil, fmt.Errorf("failed to determine whether cert verifier is immutable or "+
-			"deployed behind a router at address (%s) : %w", routerOrImmutableVerifierAddr.Hex(), err)
-	default:
-		log.Infof("EigenDA cert verifier address was detected as an EigenDACertVerifierRouter "+
-			"at address (%s), using it as such", routerOrImmutableVerifierAddr.Hex())
+		return nil, fmt.Errorf("check EigenDA network contracts: %w", err)
 	}
+	// eth_calls made to determine the validity of certs are subject to quorum reads, when enabled
+	verifyingEthClient := ethClient.verifying()
 
-	var provider clients_v2.CertVerifierAddressProvider
-	if !isRouter {
-		provider = verification.NewStaticCertVerifierAddressProvider(
-			routerOrImmutableVerifierAddr)
-	} else {
-		provider, err = verification.BuildRouterAddressProvider(
-			routerOrImmutableVerifierAddr,
-			ethClient,
-			log,
-		)
-
-		if err != nil {
-			return nil, fmt.Errorf("build router address provider: %w", err)
-		}
+	certVerifier, provider, err := BuildCertVerifier(ctx, log, verifyingEthClient,
+		geth_common.HexToAddress(config.ClientConfigV2.EigenDACertVerifierOrRouterAddress))
+	if err != nil {
+		return nil, fmt.Errorf("build cert verifier: %w", err)
 	}
 
 	ethReader, err := buildEthReader(log, config.ClientConfigV2, ethClient)
 	if err != nil {
 		return nil, fmt.Errorf("build eth reader: %w", err)
 	}
-	certVerifier, err := verification.NewCertVerifier(
-		log,
-		ethClient,
-		provider,
-	)
+	err = checkRelayRegistry(config.ClientConfigV2, ethReader.GetRelayRegistryAddress())
 	if err != nil {
-		return nil, fmt.Errorf("new cert verifier: %w", err)
+		return nil, fmt.Errorf("check relay registry: %w", err)
 	}
 
 	var retrievers []clients_v2.PayloadRetriever
@@ -312,10 +479,11 @@ func buildEigenDAV2Backend(
 		return nil, fmt.Errorf("no payload retrievers enabled, please enable at least one retriever type")
 	}
 
-	payloadDisperser, err := buildPayloadDisperser(
+	payloadDisperser, tenantDispersers, err := buildPayloadDisperser(
 		ctx,
 		log,
-		config.ClientConfigV2,
+		metrics,
+		config,
 		secrets,
 		ethClient,
 		kzgProver,
@@ -328,15 +496,25 @@ func buildEigenDAV2Backend(
 
 	eigenDAV2Store, err := eigenda_v2.NewStore(
 		log,
+		metrics,
 		config.ClientConfigV2.PutTries,
 		config.ClientConfigV2.RBNRecencyWindowSize,
 		payloadDisperser,
+		tenantDispersers,
 		retrievers,
 		certVerifier,
+		provider,
+		eigenda_v2.NewCertVerificationCache(
+			config.ClientConfigV2.CertVerificationCacheSize,
+			config.ClientConfigV2.CertVerificationCacheTTL,
+		),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create v2 store: %w", err)
 	}
+	if config.Reloader != nil {
+		config.Reloader.registerV2Store(eigenDAV2Store)
+	}
 
 	return eigenDAV2Store, nil
 }
@@ -345,6 +523,7 @@ func buildEigenDAV2Backend(
 func buildEigenDAV1Backend(
 	ctx context.Context,
 	log logging.Logger,
+	metrics metrics.Metricer,
 	config Config,
 	kzgVerifier *kzgverifier.Verifier,
 ) (common.EigenDAV1Store, error) {
@@ -361,7 +540,7 @@ func buildEigenDAV1Backend(
 
 	if config.MemstoreEnabled {
 		log.Info("Using memstore backend for EigenDA V1")
-		return memstore.New(ctx, verifier, log, config.MemstoreConfig)
+		return memstore.New(ctx, verifier, log, config.MemstoreConfig, metrics, config.MemstoreExpiryNotifier)
 	}
 	// EigenDAV1 backend dependency injection
 	var client *clients.EigenDAClient
@@ -385,23 +564,39 @@ func buildEigenDAV1Backend(
 		client,
 		verifier,
 		log,
+		metrics,
 		storeConfig,
 	)
 }
 
+// buildEthClient builds an EthClient whose read calls fail over between the primary and fallback ETH RPC urls.
+// The returned client is closed when ctx is done.
 func buildEthClient(ctx context.Context, log logging.Logger, secretConfigV2 common.SecretConfigV2,
-	expectedNetwork common.EigenDANetwork) (common_eigenda.EthClient, error) {
+	ethRPCConfig ethrpc.Config, expectedNetwork common.EigenDANetwork) (*failoverEthClient, error) {
+	rpcURLs := secretConfigV2.EthRPCURLs()
 	gethCfg := geth.EthClientConfig{
-		RPCURLs: []string{secretConfigV2.EthRPCURL},
+		RPCURLs: rpcURLs,
 	}
 
-	ethClient, err := geth.NewClient(gethCfg, geth_common.Address{}, 0, log)
+	// the primary endpoint only serves the methods not covered by the failover client, see failoverEthClient
+	primaryClient, err := geth.NewClient(gethCfg, geth_common.Address{}, 0, log)
 	if err != nil {
 		return nil, fmt.Errorf("create geth client: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	rpcClient, err := ethrpc.NewClient(log, rpcURLs, ethRPCConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create failover eth rpc client: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		rpcClient.Close()
+	}()
+	ethClient := newFailoverEthClient(primaryClient, rpcClient)
+	log.Info("Using ETH RPC endpoints", "count", len(rpcURLs), "quorumSize", ethRPCConfig.QuorumSize)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	chainID, err := ethClient.ChainID(ctx)
+	chainID, err := ethClient.ChainID(timeoutCtx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID from ETH RPC: %w", err)
 	}
@@ -531,30 +726,106 @@ func buildEthReader(log logging.Logger,
 	return ethReader, nil
 }
 
+// BuildCertVerifier builds the verifier of V2 certs, along with the provider of the address of the cert verifier
+// contract used for each reference block number. routerOrImmutableVerifierAddr is either the address of an
+// EigenDACertVerifierRouter, or of an immutable EigenDACertVerifier, which is detected by calling it.
+func BuildCertVerifier(
+	ctx context.Context,
+	log logging.Logger,
+	ethClient common_eigenda.EthClient,
+	routerOrImmutableVerifierAddr geth_common.Address,
+) (*verification.CertVerifier, clients_v2.CertVerifierAddressProvider, error) {
+	caller, err := binding.NewContractEigenDACertVerifierRouterCaller(routerOrImmutableVerifierAddr, ethClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new cert verifier router caller: %w", err)
+	}
+
+	isRouter := true
+	// Check if the router address is actually a router. if method `getCertVerifierAt` fails, it means that the
+	// address is not a router, and we should treat it as an immutable cert verifier instead
+	_, err = caller.GetCertVerifierAt(&bind.CallOpts{Context: ctx}, 0)
+	switch {
+	case err != nil && isExecutionReverted(err):
+		log.Warnf("EigenDA cert verifier router address was detected to not be a router at address (%s), "+
+			"using it as an immutable cert verifier instead", routerOrImmutableVerifierAddr.Hex())
+		isRouter = false
+	case err != nil:
+		return nil, nil, fmt.Errorf("failed to determine whether cert verifier is immutable or "+
+			"deployed behind a router at address (%s) : %w", routerOrImmutableVerifierAddr.Hex(), err)
+	default:
+		log.Infof("EigenDA cert verifier address was detected as an EigenDACertVerifierRouter "+
+			"at address (%s), using it as such", routerOrImmutableVerifierAddr.Hex())
+	}
+
+	var provider clients_v2.CertVerifierAddressProvider
+	if !isRouter {
+		provider = verification.NewStaticCertVerifierAddressProvider(
+			routerOrImmutableVerifierAddr)
+	} else {
+		provider, err = verification.BuildRouterAddressProvider(
+			routerOrImmutableVerifierAddr,
+			ethClient,
+			log,
+		)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("build router address provider: %w", err)
+		}
+	}
+
+	certVerifier, err := verification.NewCertVerifier(
+		log,
+		ethClient,
+		provider,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new cert verifier: %w", err)
+	}
+
+	return certVerifier, provider, nil
+}
+
 func buildPayloadDisperser(
 	ctx context.Context,
 	log logging.Logger,
-	clientConfigV2 common.ClientConfigV2,
+	metrics metrics.Metricer,
+	config Config,
 	secrets common.SecretConfigV2,
 	ethClient common_eigenda.EthClient,
 	kzgProver *prover.Prover,
 	certVerifier *verification.CertVerifier,
 	ethReader *eth.Reader,
-) (*payloaddispersal.PayloadDisperser, error) {
-	signer, err := buildLocalSigner(ctx, log, secrets, ethClient)
+) (*payloaddispersal.PayloadDisperser, map[string]*payloaddispersal.PayloadDisperser, error) {
+	clientConfigV2 := config.ClientConfigV2
+	blobRequestSigner, err := buildSigner(ctx, log, clientConfigV2.Signer, secrets.SignerPaymentKey, ethClient)
 	if err != nil {
-		return nil, fmt.Errorf("build local signer: %w", err)
+		return nil, nil, fmt.Errorf("build signer: %w", err)
 	}
 
 	disperserClient, err := clients_v2.NewDisperserClient(
 		log,
 		&clientConfigV2.DisperserClientCfg,
-		signer,
+		blobRequestSigner,
 		kzgProver,
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("new disperser client: %w", err)
+		return nil, nil, fmt.Errorf("new disperser client: %w", err)
+	}
+
+	if config.PaymentsConfig.PollInterval > 0 {
+		accountID, accountErr := blobRequestSigner.GetAccountID()
+		if accountErr != nil {
+			return nil, nil, fmt.Errorf("get signer account ID: %w", accountErr)
+		}
+		go payments.NewPoller(
+			log,
+			metrics,
+			config.PaymentsConfig,
+			accountID.Hex(),
+			&disperserPaymentFetcher{client: disperserClient},
+			config.PaymentsTracker,
+		).Run(ctx)
 	}
 
 	blockNumMonitor, err := verification.NewBlockNumberMonitor(
@@ -564,7 +835,7 @@ func buildPayloadDisperser(
 		//       which have block times of 2 seconds or less.
 	)
 	if err != nil {
-		return nil, fmt.Errorf("new block number monitor: %w", err)
+		return nil, nil, fmt.Errorf("new block number monitor: %w", err)
 	}
 
 	certBuilder, err := clients_v2.NewCertBuilder(
@@ -575,7 +846,7 @@ func buildPayloadDisperser(
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("new cert builder: %w", err)
+		return nil, nil, fmt.Errorf("new cert builder: %w", err)
 	}
 
 	payloadDisperser, err := payloaddispersal.NewPayloadDisperser(
@@ -587,31 +858,96 @@ func buildPayloadDisperser(
 		certVerifier,
 		nil)
 	if err != nil {
-		return nil, fmt.Errorf("new payload disperser: %w", err)
+		return nil, nil, fmt.Errorf("new payload disperser: %w", err)
+	}
+
+	// Tenants with their own signer get their own disperser client, so that their dispersals are paid for by their
+	// own account. The block number monitor and cert builder don't depend on the signer, so are shared.
+	tenantDispersers := make(map[string]*payloaddispersal.PayloadDisperser)
+	for _, t := range config.TenantsConfig.Tenants {
+		if t.Signer == nil {
+			continue
+		}
+		log.Info("Building EigenDA V2 disperser of tenant", "tenant", t.Name, "signer_type", t.Signer.Type)
+		tenantSigner, signerErr := buildSigner(ctx, log, *t.Signer, t.SignerPaymentKey, ethClient)
+		if signerErr != nil {
+			return nil, nil, fmt.Errorf("build signer of tenant %s: %w", t.Name, signerErr)
+		}
+		tenantDisperserClient, clientErr := clients_v2.NewDisperserClient(
+			log,
+			&clientConfigV2.DisperserClientCfg,
+			tenantSigner,
+			kzgProver,
+			nil,
+		)
+		if clientErr != nil {
+			return nil, nil, fmt.Errorf("new disperser client of tenant %s: %w", t.Name, clientErr)
+		}
+		tenantDispersers[t.Name], err = payloaddispersal.NewPayloadDisperser(
+			log,
+			clientConfigV2.PayloadDisperserCfg,
+			tenantDisperserClient,
+			blockNumMonitor,
+			certBuilder,
+			certVerifier,
+			nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new payload disperser of tenant %s: %w", t.Name, err)
+		}
 	}
 
-	return payloadDisperser, nil
+	return payloadDisperser, tenantDispersers, nil
 }
 
-// buildLocalSigner attempts to check the pending balance of the created signer account. If the check fails, or if the
+// buildSigner builds the signer of dispersal payments selected by signerCfg: a local signer using the raw hex
+// paymentKeyHex or a key decrypted from a geth keystore file, or a remote signer.
+//
+// It then attempts to check the pending balance of the signer account. If the check fails, or if the
 // balance is determined to be 0, the user is warned with a log. This method doesn't return an error based on this
 // check:
 // it's possible that a user could want to set up a signer before it's actually ready to be used
 //
-// TODO: the checks performed in this method could be improved in the future, e.g. by checking payment vault state,
-// or by accessing the disperser accountant
-func buildLocalSigner(
+// The reservation and on-demand deposit of the account are monitored separately by the [payments.Poller].
+func buildSigner(
 	ctx context.Context,
 	log logging.Logger,
-	secrets common.SecretConfigV2,
+	signerCfg signer.Config,
+	paymentKeyHex string,
 	ethClient common_eigenda.EthClient,
 ) (core_v2.BlobRequestSigner, error) {
-	signer, err := auth.NewLocalBlobRequestSigner(secrets.SignerPaymentKey)
-	if err != nil {
-		return nil, fmt.Errorf("new local blob request signer: %w", err)
+	var blobRequestSigner core_v2.BlobRequestSigner
+	switch signerCfg.Type {
+	case signer.TypeLocal:
+		localSigner, err := auth.NewLocalBlobRequestSigner(paymentKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("new local blob request signer: %w", err)
+		}
+		blobRequestSigner = localSigner
+	case signer.TypeKeystore:
+		privateKey, err := signer.LoadKeystoreKey(signerCfg.KeystorePath, signerCfg.KeystorePasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("load keystore key: %w", err)
+		}
+		localSigner, err := auth.NewLocalBlobRequestSigner(hex.EncodeToString(crypto.FromECDSA(privateKey)))
+		if err != nil {
+			return nil, fmt.Errorf("new local blob request signer: %w", err)
+		}
+		blobRequestSigner = localSigner
+	case signer.TypeRemote:
+		log.Info("Signing dispersal payments with remote signer",
+			"url", signerCfg.RemoteURL, "address", signerCfg.RemoteAddress)
+		blobRequestSigner = &remoteBlobRequestSigner{
+			remote: signer.NewRemoteSigner(
+				signerCfg.RemoteURL, geth_common.HexToAddress(signerCfg.RemoteAddress), signerCfg.RemoteTimeout),
+		}
+	default:
+		return nil, fmt.Errorf("unknown signer type %q", signerCfg.Type)
 	}
 
-	accountID := crypto.PubkeyToAddress(signer.PrivateKey.PublicKey)
+	accountID, err := blobRequestSigner.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("get signer account ID: %w", err)
+	}
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	pendingBalance, err := ethClient.PendingBalanceAt(ctxWithTimeout, accountID)
@@ -626,5 +962,5 @@ func buildLocalSigner(
 		log.Warnf("pending balance for accountID %v is zero", accountID)
 	}
 
-	return signer, nil
+	return blobRequestSigner, nil
 }
@@ -38,6 +38,11 @@ func (cfg *Config) checkTargets(targets []string) error {
 
 // Check ... verifies that configuration values are adequately set
 func (cfg *Config) Check() error {
+	if !common.Contains(cfg.BackendsToEnable, cfg.DispersalBackend) {
+		return fmt.Errorf("dispersal backend %s is not among the enabled backends",
+			common.EigenDABackendToString(cfg.DispersalBackend))
+	}
+
 	err := cfg.checkTargets(cfg.FallbackTargets)
 	if err != nil {
 		return err
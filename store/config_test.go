@@ -3,11 +3,15 @@ package store
 import (
 	"testing"
 
+	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/stretchr/testify/require"
 )
 
 func validCfg() *Config {
-	return &Config{}
+	return &Config{
+		BackendsToEnable: []common.EigenDABackend{common.V2EigenDABackend},
+		DispersalBackend: common.V2EigenDABackend,
+	}
 }
 
 func TestConfigVerification(t *testing.T) {
@@ -18,6 +22,14 @@ func TestConfigVerification(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("DispersalBackendNotEnabled", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.DispersalBackend = common.V1EigenDABackend
+
+		err := cfg.Check()
+		require.Error(t, err)
+	})
+
 	t.Run("InvalidFallbackTarget", func(t *testing.T) {
 		cfg := validCfg()
 		cfg.FallbackTargets = []string{"postgres"}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/utils"
 	"github.com/Layr-Labs/eigenda/api/clients"
@@ -42,6 +43,7 @@ type Store struct {
 	verifier *verify.Verifier
 	cfg      *StoreConfig
 	log      logging.Logger
+	metrics  metrics.Metricer
 }
 
 var _ common.EigenDAV1Store = (*Store)(nil)
@@ -71,12 +73,14 @@ func NewStore(
 	client *clients.EigenDAClient,
 	v *verify.Verifier,
 	log logging.Logger,
+	m metrics.Metricer,
 	cfg *StoreConfig,
 ) (*Store, error) {
 	return &Store{
 		client:   client,
 		verifier: v,
 		log:      log,
+		metrics:  m,
 		cfg:      cfg,
 	}, nil
 }
@@ -95,14 +99,18 @@ func (e Store) Get(ctx context.Context, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to verify DA cert: %w", err)
 	}
 
+	// V1 blobs are always retrieved from the disperser
+	recordRetrieval := e.metrics.RecordRetrieval(e.BackendType().String(), "disperser")
 	decodedBlob, err := e.client.GetBlob(
 		ctx,
 		cert.BlobVerificationProof.GetBatchMetadata().GetBatchHeaderHash(),
 		cert.BlobVerificationProof.GetBlobIndex(),
 	)
 	if err != nil {
+		recordRetrieval(metrics.RetrievalStatusFailed)
 		return nil, fmt.Errorf("EigenDA client failed to retrieve decoded blob: %w", err)
 	}
+	recordRetrieval(metrics.RetrievalStatusSuccess)
 
 	return decodedBlob, nil
 }
@@ -125,8 +133,17 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 
 	// We attempt to disperse the blob to EigenDA up to e.cfg.PutTries times total,
 	// unless we get a 400 error which aborts retries.
+	backendType := e.BackendType().String()
+	attempts := 0
 	blobInfo, err := retry.DoWithData(
 		func() (*disperser.BlobInfo, error) {
+			if attempts > 0 {
+				e.metrics.RecordDispersalRetry(backendType)
+			}
+			attempts++
+			// PutBlob both disperses the blob and waits for it to be confirmed onchain
+			recordSendAndConfirm := e.metrics.RecordDispersalStage(backendType, metrics.DispersalStageSendAndConfirm)
+			defer recordSendAndConfirm()
 			return e.client.PutBlob(ctx, value)
 		},
 		retry.RetryIf(func(err error) bool {
@@ -177,14 +194,14 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to verify DA cert due to invalid field lengths: %w", err)
 	}
 
-	err = e.verifier.VerifyCommitment(cert.BlobHeader.GetCommitment(), encodedBlob)
+	err = e.verifyCommitment(cert, encodedBlob)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify commitment: %w", err)
 	}
 
 	// The cert will only be included in the batcher's inbox after
 	// the proxy returns the verified cert to the batcher.
-	err = e.verifier.VerifyCert(ctx, cert)
+	err = e.verifyCert(ctx, cert)
 	if err != nil {
 		if errors.Is(err, verify.ErrBatchMetadataHashMismatch) {
 			// This error might have been caused by an L1 reorg.
@@ -202,7 +219,9 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to verify DA cert: %w", err)
 	}
 
+	recordCertSerialize := e.metrics.RecordDispersalStage(backendType, metrics.DispersalStageCertSerialize)
 	bytes, err := rlp.EncodeToBytes(cert)
+	recordCertSerialize()
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
 	}
@@ -231,13 +250,13 @@ func (e Store) Verify(ctx context.Context, serializedCert []byte, payload []byte
 	}
 
 	// verify kzg data commitment
-	err = e.verifier.VerifyCommitment(cert.BlobHeader.GetCommitment(), encodedBlob)
+	err = e.verifyCommitment(&cert, encodedBlob)
 	if err != nil {
 		return fmt.Errorf("failed to verify commitment: %w", err)
 	}
 
 	// verify DA certificate against EigenDA's batch metadata that's bridged to Ethereum
-	err = e.verifier.VerifyCert(ctx, &cert)
+	err = e.verifyCert(ctx, &cert)
 	if errors.Is(err, verify.ErrBatchMetadataHashMismatch) {
 		// This error might have been caused by an L1 reorg.
 		// See https://github.com/Layr-Labs/eigenda-proxy/blob/main/docs/troubleshooting_v1.md#batch-hash-mismatch-error
@@ -253,3 +272,19 @@ func (e Store) Verify(ctx context.Context, serializedCert []byte, payload []byte
 	}
 	return err
 }
+
+// verifyCommitment verifies the cert's kzg commitment against the encoded blob,
+// recording the duration of the verification.
+func (e Store) verifyCommitment(cert *verify.Certificate, encodedBlob []byte) error {
+	recordKZG := e.metrics.RecordVerificationStage(e.BackendType().String(), metrics.VerificationStageKZGCommitment)
+	defer recordKZG()
+	return e.verifier.VerifyCommitment(cert.BlobHeader.GetCommitment(), encodedBlob)
+}
+
+// verifyCert verifies the cert against the batch metadata bridged to Ethereum,
+// recording the duration of the eth-calls.
+func (e Store) verifyCert(ctx context.Context, cert *verify.Certificate) error {
+	recordEthCall := e.metrics.RecordVerificationStage(e.BackendType().String(), metrics.VerificationStageEthCall)
+	defer recordEthCall()
+	return e.verifier.VerifyCert(ctx, cert)
+}
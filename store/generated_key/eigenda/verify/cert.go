@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common/consts"
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
 	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	v1_verifier_binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifierV1"
 	edsm_binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
@@ -19,7 +20,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"golang.org/x/exp/slices"
 )
 
@@ -45,7 +45,7 @@ type CertVerifier struct {
 	svcManagerCaller     *edsm_binding.ContractEigenDAServiceManagerCaller
 
 	securityParamReader SecurityParamReader
-	ethClient           *ethclient.Client
+	ethClient           *ethrpc.Client
 	// The two fields below are fetched from the EigenDAServiceManager contract or
 	// the EigenDACertVerifierV1 (if configured) in the constructor.
 	// They are used to verify the quorums in the received certificates.
@@ -63,14 +63,14 @@ func NewCertVerifier(cfg *Config, log logging.Logger) (*CertVerifier, error) {
 		)
 	}
 
-	client, err := ethclient.Dial(cfg.RPCURL)
+	client, err := ethrpc.NewClient(log, cfg.RPCURLs(), cfg.EthRPC)
 	if err != nil {
-		return nil, fmt.Errorf("failed to dial ETH RPC node: %s", err.Error())
+		return nil, fmt.Errorf("failed to dial ETH RPC nodes: %w", err)
 	}
 
-	// construct caller bindings
+	// construct caller bindings. All of their eth_calls are used for verification, so are subject to quorum reads.
 	svcManagerCaller, err := edsm_binding.NewContractEigenDAServiceManagerCaller(
-		common.HexToAddress(cfg.SvcManagerAddr), client)
+		common.HexToAddress(cfg.SvcManagerAddr), client.QuorumCaller())
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +80,7 @@ func NewCertVerifier(cfg *Config, log logging.Logger) (*CertVerifier, error) {
 	if cfg.CertVerifierV1Addr != "" {
 		log.Infof("Using custom EigenDACertVerifierV1 contract for cert verification at address: %s", cfg.CertVerifierV1Addr)
 		certVerifierCallerV1, err := v1_verifier_binding.NewContractEigenDACertVerifierV1Caller(
-			common.HexToAddress(cfg.CertVerifierV1Addr), client,
+			common.HexToAddress(cfg.CertVerifierV1Addr), client.QuorumCaller(),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create EigenDACertVerifierV1 caller: %w", err)
@@ -198,7 +198,7 @@ func (cv *CertVerifier) getConfDeepBlockNumber(ctx context.Context) (*big.Int, e
 		// need full txs. See
 		// https://github.com/ethereum/execution-apis/blob/4140e528360fea53c34a766d86a000c6c039100e/src/eth/block.yaml#L61
 		// This is equivalent to `cast block finalized`, as opposed to `cast block finalized --full`.
-		err := cv.ethClient.Client().CallContext(ctx, &header, "eth_getBlockByNumber", "finalized", false)
+		err := cv.ethClient.CallContext(ctx, &header, "eth_getBlockByNumber", "finalized", false)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get finalized block: %w", err)
 		}
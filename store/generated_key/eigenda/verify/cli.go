@@ -5,6 +5,7 @@ import (
 	"runtime"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
 	"github.com/Layr-Labs/eigenda-proxy/config/eigendaflags"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli/v2"
@@ -128,6 +129,8 @@ func ReadConfig(ctx *cli.Context, clientConfigV1 common.ClientConfigV1) Config {
 		VerifyCerts: !ctx.Bool(CertVerificationDisabledFlagName),
 		// reuse some configs from the eigenda client
 		RPCURL:               clientConfigV1.EdaClientCfg.EthRpcUrl,
+		FallbackRPCURLs:      clientConfigV1.EthRPCFallbackURLs,
+		EthRPC:               ethrpc.ReadConfig(ctx),
 		SvcManagerAddr:       clientConfigV1.EdaClientCfg.SvcManagerAddr,
 		CertVerifierV1Addr:   ctx.String(EigenDACertVerifierV1FlagName),
 		EthConfirmationDepth: clientConfigV1.EdaClientCfg.WaitForConfirmationDepth,
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Layr-Labs/eigenda-proxy/common/ethrpc"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -29,6 +30,8 @@ type Config struct {
 	VerifyCerts bool
 	// below fields are only required if VerifyCerts is true
 	RPCURL               string
+	FallbackRPCURLs      []string      // used whenever RPCURL is unhealthy, and for quorum reads
+	EthRPC               ethrpc.Config // failover and quorum read settings for RPCURL and FallbackRPCURLs
 	SvcManagerAddr       string
 	CertVerifierV1Addr   string // used if enabling custom quorums/thresholds
 	EthConfirmationDepth uint64
@@ -36,6 +39,11 @@ type Config struct {
 	MaxBlobSizeBytes     uint64
 }
 
+// RPCURLs returns the primary ETH RPC url followed by the fallback urls
+func (c Config) RPCURLs() []string {
+	return append([]string{c.RPCURL}, c.FallbackRPCURLs...)
+}
+
 // Custom MarshalJSON function to control what gets included in the JSON output
 func (c Config) MarshalJSON() ([]byte, error) {
 	type Alias Config // Use an alias to avoid recursion with MarshalJSON
@@ -44,6 +52,9 @@ func (c Config) MarshalJSON() ([]byte, error) {
 	if aux.RPCURL != "" {
 		aux.RPCURL = "*****"
 	}
+	if len(aux.FallbackRPCURLs) > 0 {
+		aux.FallbackRPCURLs = []string{"*****"}
+	}
 	return json.Marshal(aux)
 }
 
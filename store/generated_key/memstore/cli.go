@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/urfave/cli/v2"
@@ -16,6 +17,10 @@ var (
 	PutLatencyFlagName              = withFlagPrefix("put-latency")
 	GetLatencyFlagName              = withFlagPrefix("get-latency")
 	PutReturnsFailoverErrorFlagName = withFlagPrefix("put-returns-failover-error")
+	MaxTotalBytesFlagName           = withFlagPrefix("max-total-bytes")
+	MaxEntriesFlagName              = withFlagPrefix("max-entries")
+	EvictionPolicyFlagName          = withFlagPrefix("eviction-policy")
+	ExpiryWebhookURLFlagName        = withFlagPrefix("expiry-webhook-url")
 )
 
 func withFlagPrefix(s string) string {
@@ -102,10 +107,50 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  []string{withEnvPrefix(envPrefix, "PUT_RETURNS_FAILOVER_ERROR")},
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: MaxTotalBytesFlagName,
+			Usage: "Maximum total size of blobs held by the memstore before entries get evicted. " +
+				"Example units: '512MiB', '2GB'. Setting to (0) results in no limit.",
+			Value:    "0",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "MAX_TOTAL_BYTES")},
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     MaxEntriesFlagName,
+			Usage:    "Maximum number of blobs held by the memstore before entries get evicted. Setting to (0) results in no limit.",
+			Value:    0,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "MAX_ENTRIES")},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: EvictionPolicyFlagName,
+			Usage: fmt.Sprintf("Policy used to pick which entry to evict when a capacity limit is reached. "+
+				"Options are [%s, %s].", memconfig.EvictionPolicyFIFO, memconfig.EvictionPolicyLRU),
+			Value:    string(memconfig.EvictionPolicyFIFO),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "EVICTION_POLICY")},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: ExpiryWebhookURLFlagName,
+			Usage: "URL to which batches of blobs pruned due to expiration are POSTed as a JSON array. " +
+				"Pruned blobs are also streamed as Server-Sent Events on GET /memstore/events.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "EXPIRY_WEBHOOK_URL")},
+			Category: category,
+		},
 	}
 }
 
 func ReadConfig(ctx *cli.Context, maxBlobSizeBytes uint64) (*memconfig.SafeConfig, error) {
+	maxTotalBytes, err := common.ParseBytesAmount(ctx.String(MaxTotalBytesFlagName))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", MaxTotalBytesFlagName, err)
+	}
+
+	evictionPolicy, err := memconfig.StringToEvictionPolicy(ctx.String(EvictionPolicyFlagName))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", EvictionPolicyFlagName, err)
+	}
+
 	return memconfig.NewSafeConfig(
 		memconfig.Config{
 			MaxBlobSizeBytes:        maxBlobSizeBytes,
@@ -113,5 +158,8 @@ func ReadConfig(ctx *cli.Context, maxBlobSizeBytes uint64) (*memconfig.SafeConfi
 			PutLatency:              ctx.Duration(PutLatencyFlagName),
 			GetLatency:              ctx.Duration(GetLatencyFlagName),
 			PutReturnsFailoverError: ctx.Bool(PutReturnsFailoverErrorFlagName),
+			MaxTotalBytes:           maxTotalBytes,
+			MaxEntries:              ctx.Uint64(MaxEntriesFlagName),
+			EvictionPolicy:          evictionPolicy,
 		}), nil
 }
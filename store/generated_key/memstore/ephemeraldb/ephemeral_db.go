@@ -1,6 +1,7 @@
 package ephemeraldb
 
 import (
+	"container/list"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -8,7 +9,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -16,33 +20,88 @@ import (
 
 const (
 	DefaultPruneInterval = 500 * time.Millisecond
+
+	// maxEvictedKeysTracked bounds the number of evicted keys remembered in order to return
+	// [ErrEntryEvicted] instead of [ErrEntryNotFound]. Keys are 32 byte hashes, so this caps
+	// the bookkeeping overhead at a few MiBs.
+	maxEvictedKeysTracked = 100_000
+
+	evictionReasonCapacity   = "capacity"
+	evictionReasonExpiration = "expiration"
 )
 
+var (
+	// ErrEntryNotFound is returned when fetching a key that was never inserted (or that expired).
+	ErrEntryNotFound = errors.New("payload not found")
+	// ErrEntryEvicted is returned when fetching a key that was removed to respect the
+	// configured capacity limits (MaxTotalBytes or MaxEntries).
+	ErrEntryEvicted = errors.New("payload evicted due to memstore capacity limits")
+)
+
+// entry ... a single value held by the db, along with the metadata needed for expiration and eviction
+type entry struct {
+	key        string
+	value      []byte
+	insertedAt time.Time
+	// orderElem is the entry's element in the eviction order list
+	orderElem *list.Element
+	// expiryElem is the entry's element in the expiry index
+	expiryElem *list.Element
+}
+
 // DB ... An ephemeral && simple in-memory database used to emulate
 // an EigenDA network for dispersal/retrieval operations.
 type DB struct {
 	// knobs used to express artificial conditions for testing
-	config *memconfig.SafeConfig
-	log    logging.Logger
+	config      *memconfig.SafeConfig
+	log         logging.Logger
+	m           metrics.Metricer
+	backendType common.BackendType
+	// notifier receives the keys pruned due to expiration. Can be nil.
+	notifier *expiry.Notifier
 
 	// mu guards the below fields
-	mu        sync.RWMutex
-	keyStarts map[string]time.Time // used for managing expiration
-	store     map[string][]byte    // db
+	mu sync.RWMutex
+	// order holds *entry values. The front of the list is the next entry to be evicted:
+	// the oldest insertion for FIFO, or the least recently used entry for LRU.
+	order *list.List
+	// expiryIndex holds *entry values in insertion order. Since all entries share the same
+	// expiration duration, the front of the list is always the next entry to expire,
+	// which makes pruning O(expired) instead of O(entries).
+	expiryIndex *list.List
+	store       map[string]*entry // db
+	sizeBytes   uint64
+	// evicted remembers (a bounded number of) keys evicted due to capacity limits, along with their element in
+	// evictedOrder, which holds the keys in eviction order
+	evicted      map[string]*list.Element
+	evictedOrder *list.List
 }
 
 // New ... constructor
-func New(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger) *DB {
+func New(
+	ctx context.Context,
+	cfg *memconfig.SafeConfig,
+	log logging.Logger,
+	m metrics.Metricer,
+	backendType common.BackendType,
+	notifier *expiry.Notifier,
+) *DB {
 	db := &DB{
-		config:    cfg,
-		keyStarts: make(map[string]time.Time),
-		store:     make(map[string][]byte),
-		log:       log,
+		config:       cfg,
+		log:          log,
+		m:            m,
+		backendType:  backendType,
+		notifier:     notifier,
+		order:        list.New(),
+		expiryIndex:  list.New(),
+		store:        make(map[string]*entry),
+		evicted:      make(map[string]*list.Element),
+		evictedOrder: list.New(),
 	}
 
 	// if no expiration set then blobs will be persisted indefinitely
 	if cfg.BlobExpiration() != 0 {
-		db.log.Info("ephemeral db expiration enabled for payload entries.", "time", cfg.BlobExpiration)
+		db.log.Info("ephemeral db expiration enabled for payload entries.", "time", cfg.BlobExpiration())
 		go db.pruningLoop(ctx)
 	}
 
@@ -61,6 +120,10 @@ func (db *DB) InsertEntry(key []byte, value []byte) error {
 			len(value),
 			db.config.MaxBlobSizeBytes())
 	}
+	maxTotalBytes := db.config.MaxTotalBytes()
+	if maxTotalBytes > 0 && uint64(len(value)) > maxTotalBytes {
+		return fmt.Errorf("blob length %d exceeds ephemeral db capacity of %d bytes", len(value), maxTotalBytes)
+	}
 
 	time.Sleep(db.config.LatencyPUTRoute())
 	db.mu.Lock()
@@ -73,29 +136,120 @@ func (db *DB) InsertEntry(key []byte, value []byte) error {
 		return fmt.Errorf("payload key already exists in ephemeral db: %s", strKey)
 	}
 
-	db.store[strKey] = value
-	// add expiration if applicable
+	db.evictForInsertion(uint64(len(value)))
 
-	if db.config.BlobExpiration() > 0 {
-		db.keyStarts[strKey] = time.Now()
+	e := &entry{
+		key:        strKey,
+		value:      value,
+		insertedAt: time.Now(),
 	}
+	e.orderElem = db.order.PushBack(e)
+	e.expiryElem = db.expiryIndex.PushBack(e)
+	db.store[strKey] = e
+	db.sizeBytes += uint64(len(value))
+	db.forgetEvicted(strKey)
+	db.recordSize()
 
 	return nil
 }
 
-// FetchEntry ... looks up a value from the db provided a key
+// FetchEntry ... looks up a value from the db provided a key.
+// Returns an error wrapping [ErrEntryEvicted] if the key was evicted due to capacity limits,
+// or wrapping [ErrEntryNotFound] otherwise.
 func (db *DB) FetchEntry(key []byte) ([]byte, error) {
 	time.Sleep(db.config.LatencyGETRoute())
-	db.mu.RLock()
-	defer db.mu.RUnlock()
 
-	payload, exists := db.store[string(key)]
+	// LRU needs to update the eviction order on reads, which requires the write lock. The policy is read once,
+	// since it can be changed concurrently and the order must only be updated while holding the write lock.
+	lru := db.config.EvictionPolicy() == memconfig.EvictionPolicyLRU
+	if lru {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+	} else {
+		db.mu.RLock()
+		defer db.mu.RUnlock()
+	}
 
+	e, exists := db.store[string(key)]
 	if !exists {
-		return nil, fmt.Errorf("payload not found for key: %s", hex.EncodeToString(key))
+		if _, wasEvicted := db.evicted[string(key)]; wasEvicted {
+			return nil, fmt.Errorf("%w for key: %s", ErrEntryEvicted, hex.EncodeToString(key))
+		}
+		return nil, fmt.Errorf("%w for key: %s", ErrEntryNotFound, hex.EncodeToString(key))
+	}
+
+	if lru {
+		db.order.MoveToBack(e.orderElem)
 	}
 
-	return payload, nil
+	return e.value, nil
+}
+
+// evictForInsertion ... evicts entries from the front of the eviction order until
+// a new value of size newValueSize fits within the configured capacity limits.
+// Callers must hold the write lock.
+func (db *DB) evictForInsertion(newValueSize uint64) {
+	maxEntries := db.config.MaxEntries()
+	maxTotalBytes := db.config.MaxTotalBytes()
+
+	for db.order.Len() > 0 {
+		overEntries := maxEntries > 0 && uint64(db.order.Len())+1 > maxEntries
+		overBytes := maxTotalBytes > 0 && db.sizeBytes+newValueSize > maxTotalBytes
+		if !overEntries && !overBytes {
+			return
+		}
+
+		front := entryOf(db.order.Front())
+		db.removeEntry(front, evictionReasonCapacity)
+		db.rememberEvicted(front.key)
+	}
+}
+
+// entryOf ... returns the entry held by an element of the order or expiry lists, which only hold *entry values.
+func entryOf(elem *list.Element) *entry {
+	e, ok := elem.Value.(*entry)
+	if !ok {
+		panic(fmt.Sprintf("ephemeral db list holds a %T instead of an *entry, this is a bug", elem.Value))
+	}
+	return e
+}
+
+// removeEntry ... removes an entry from the db. Callers must hold the write lock.
+func (db *DB) removeEntry(e *entry, reason string) {
+	db.order.Remove(e.orderElem)
+	db.expiryIndex.Remove(e.expiryElem)
+	delete(db.store, e.key)
+	db.sizeBytes -= uint64(len(e.value))
+
+	db.m.RecordMemstoreEviction(db.backendType.String(), reason)
+	db.log.Debug("blob removed from ephemeral db", "commit", hex.EncodeToString([]byte(e.key)), "reason", reason)
+}
+
+// rememberEvicted ... records an evicted key, forgetting the oldest one if the tracking limit is reached.
+func (db *DB) rememberEvicted(key string) {
+	db.forgetEvicted(key)
+	db.evicted[key] = db.evictedOrder.PushBack(key)
+	if db.evictedOrder.Len() > maxEvictedKeysTracked {
+		oldest := db.evictedOrder.Front()
+		db.evictedOrder.Remove(oldest)
+		if key, ok := oldest.Value.(string); ok {
+			delete(db.evicted, key)
+		}
+	}
+}
+
+// forgetEvicted ... removes a key from the evicted set, in case it gets re-inserted.
+func (db *DB) forgetEvicted(key string) {
+	elem, ok := db.evicted[key]
+	if !ok {
+		return
+	}
+	delete(db.evicted, key)
+	db.evictedOrder.Remove(elem)
+}
+
+func (db *DB) recordSize() {
+	db.m.RecordMemstoreSize(db.backendType.String(), len(db.store), db.sizeBytes)
 }
 
 // pruningLoop ... runs a background goroutine to prune expired blobs from the store on a regular interval.
@@ -114,16 +268,35 @@ func (db *DB) pruningLoop(ctx context.Context) {
 }
 
 // pruneExpired ... removes expired blobs from the store based on the expiration time.
+// Only the expired entries at the front of the expiry index are visited.
 func (db *DB) pruneExpired() {
-	db.mu.Lock()
-	defer db.mu.Unlock()
+	expiration := db.config.BlobExpiration()
+	if expiration == 0 {
+		return
+	}
 
-	for commit, dur := range db.keyStarts {
-		if time.Since(dur) >= db.config.BlobExpiration() {
-			delete(db.keyStarts, commit)
-			delete(db.store, commit)
+	notify := db.notifier.HasSubscribers()
+	var events []expiry.Event
 
-			db.log.Debug("blob pruned", "commit", commit)
+	db.mu.Lock()
+	now := time.Now()
+	for front := db.expiryIndex.Front(); front != nil; front = db.expiryIndex.Front() {
+		e := entryOf(front)
+		if now.Sub(e.insertedAt) < expiration {
+			break
+		}
+		db.removeEntry(e, evictionReasonExpiration)
+		if notify {
+			events = append(events, expiry.Event{
+				Key:        hex.EncodeToString([]byte(e.key)),
+				Backend:    db.backendType.String(),
+				InsertedAt: e.insertedAt,
+				PrunedAt:   now,
+			})
 		}
 	}
+	db.recordSize()
+	db.mu.Unlock()
+
+	db.notifier.Publish(events)
 }
@@ -2,10 +2,15 @@ package ephemeraldb
 
 import (
 	"context"
+	"encoding/hex"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -35,7 +40,7 @@ func TestGetSet(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	db := New(ctx, testConfig(), testLogger)
+	db := New(ctx, testConfig(), testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
 
 	testKey := []byte("bland")
 	expected := []byte(testPreimage)
@@ -54,7 +59,7 @@ func TestExpiration(t *testing.T) {
 
 	cfg := testConfig()
 	cfg.SetBlobExpiration(10 * time.Millisecond)
-	db := New(ctx, cfg, testLogger)
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
 
 	preimage := []byte(testPreimage)
 	testKey := []byte("bland")
@@ -66,7 +71,38 @@ func TestExpiration(t *testing.T) {
 	time.Sleep(time.Second * 1)
 
 	_, err = db.FetchEntry(testKey)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrEntryNotFound)
+}
+
+func TestExpiryNotifications(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig()
+	cfg.SetBlobExpiration(time.Hour)
+	notifier := expiry.NewNotifier()
+	events, unsubscribe := notifier.Subscribe()
+	defer unsubscribe()
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, notifier)
+
+	require.NoError(t, db.InsertEntry([]byte("old"), []byte(testPreimage)))
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, db.InsertEntry([]byte("new"), []byte(testPreimage)))
+
+	// only the entries at the front of the expiry index get pruned
+	cfg.SetBlobExpiration(10 * time.Millisecond)
+	db.pruneExpired()
+
+	batch := <-events
+	require.Len(t, batch, 1)
+	require.Equal(t, hex.EncodeToString([]byte("old")), batch[0].Key)
+	require.Equal(t, common.MemstoreV2BackendType.String(), batch[0].Backend)
+
+	_, err := db.FetchEntry([]byte("old"))
+	require.ErrorIs(t, err, ErrEntryNotFound)
+	_, err = db.FetchEntry([]byte("new"))
+	require.NoError(t, err)
 }
 
 func TestLatency(t *testing.T) {
@@ -81,7 +117,7 @@ func TestLatency(t *testing.T) {
 	config := testConfig()
 	config.SetLatencyPUTRoute(putLatency)
 	config.SetLatencyGETRoute(getLatency)
-	db := New(ctx, config, testLogger)
+	db := New(ctx, config, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
 
 	preimage := []byte(testPreimage)
 	testKey := []byte("bland")
@@ -105,7 +141,7 @@ func TestPutReturnsFailoverErrorConfig(t *testing.T) {
 	defer cancel()
 
 	config := testConfig()
-	db := New(ctx, config, testLogger)
+	db := New(ctx, config, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
 	testKey := []byte("som-key")
 
 	err := db.InsertEntry(testKey, []byte("some-value"))
@@ -120,3 +156,145 @@ func TestPutReturnsFailoverErrorConfig(t *testing.T) {
 	err = db.InsertEntry(testKey, []byte("some-value"))
 	require.ErrorIs(t, err, &api.ErrorFailover{})
 }
+
+func TestMaxEntriesEvictionFIFO(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig()
+	cfg.SetMaxEntries(2)
+	cfg.SetEvictionPolicy(memconfig.EvictionPolicyFIFO)
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
+
+	require.NoError(t, db.InsertEntry([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.InsertEntry([]byte("key2"), []byte("value2")))
+	// reading key1 has no effect on FIFO ordering
+	_, err := db.FetchEntry([]byte("key1"))
+	require.NoError(t, err)
+	require.NoError(t, db.InsertEntry([]byte("key3"), []byte("value3")))
+
+	_, err = db.FetchEntry([]byte("key1"))
+	require.ErrorIs(t, err, ErrEntryEvicted)
+	_, err = db.FetchEntry([]byte("key2"))
+	require.NoError(t, err)
+	_, err = db.FetchEntry([]byte("key3"))
+	require.NoError(t, err)
+
+	_, err = db.FetchEntry([]byte("never-inserted"))
+	require.ErrorIs(t, err, ErrEntryNotFound)
+}
+
+func TestMaxEntriesEvictionLRU(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig()
+	cfg.SetMaxEntries(2)
+	cfg.SetEvictionPolicy(memconfig.EvictionPolicyLRU)
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
+
+	require.NoError(t, db.InsertEntry([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.InsertEntry([]byte("key2"), []byte("value2")))
+	// reading key1 makes key2 the least recently used entry
+	_, err := db.FetchEntry([]byte("key1"))
+	require.NoError(t, err)
+	require.NoError(t, db.InsertEntry([]byte("key3"), []byte("value3")))
+
+	_, err = db.FetchEntry([]byte("key2"))
+	require.ErrorIs(t, err, ErrEntryEvicted)
+	_, err = db.FetchEntry([]byte("key1"))
+	require.NoError(t, err)
+	_, err = db.FetchEntry([]byte("key3"))
+	require.NoError(t, err)
+}
+
+func TestReinsertEvictedKey(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig()
+	cfg.SetMaxEntries(1)
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
+
+	require.NoError(t, db.InsertEntry([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.InsertEntry([]byte("key2"), []byte("value2")))
+	_, err := db.FetchEntry([]byte("key1"))
+	require.ErrorIs(t, err, ErrEntryEvicted)
+
+	// re-inserting key1 evicts key2, and key1 is no longer tracked as evicted
+	require.NoError(t, db.InsertEntry([]byte("key1"), []byte("value1")))
+	_, err = db.FetchEntry([]byte("key1"))
+	require.NoError(t, err)
+	_, err = db.FetchEntry([]byte("key2"))
+	require.ErrorIs(t, err, ErrEntryEvicted)
+	require.Len(t, db.evicted, 1)
+	require.Equal(t, 1, db.evictedOrder.Len())
+}
+
+func TestEvictionPolicyChangedDuringFetches(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig()
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.InsertEntry([]byte{byte(i)}, []byte("value")))
+	}
+
+	// the eviction order is only updated under the write lock, whatever the policy switches to (see go test -race)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			if i%2 == 0 {
+				cfg.SetEvictionPolicy(memconfig.EvictionPolicyLRU)
+			} else {
+				cfg.SetEvictionPolicy(memconfig.EvictionPolicyFIFO)
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(key byte) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := db.FetchEntry([]byte{key})
+				require.NoError(t, err)
+			}
+		}(byte(i))
+	}
+	wg.Wait()
+	<-done
+	require.Equal(t, 10, db.order.Len())
+}
+
+func TestMaxTotalBytesEviction(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig()
+	cfg.SetMaxTotalBytes(10)
+	db := New(ctx, cfg, testLogger, metrics.NoopMetrics, common.MemstoreV2BackendType, nil)
+
+	require.NoError(t, db.InsertEntry([]byte("key1"), make([]byte, 4)))
+	require.NoError(t, db.InsertEntry([]byte("key2"), make([]byte, 4)))
+	// 4+4+4 > 10, so key1 needs to be evicted
+	require.NoError(t, db.InsertEntry([]byte("key3"), make([]byte, 4)))
+
+	_, err := db.FetchEntry([]byte("key1"))
+	require.ErrorIs(t, err, ErrEntryEvicted)
+	_, err = db.FetchEntry([]byte("key2"))
+	require.NoError(t, err)
+
+	// a single value larger than the total capacity can never be stored
+	err = db.InsertEntry([]byte("key4"), make([]byte, 11))
+	require.Error(t, err)
+	_, err = db.FetchEntry([]byte("key3"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,73 @@
+package expiry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+)
+
+// HandlerHTTP streams memstore expiry events as Server-Sent Events.
+// It adds a route to the proxy's main router (to be served on same port as the main proxy routes):
+// - GET /memstore/events: streams an `expired` event for every pruned memstore entry
+type HandlerHTTP struct {
+	log      logging.Logger
+	notifier *Notifier
+}
+
+func NewHandlerHTTP(log logging.Logger, notifier *Notifier) HandlerHTTP {
+	return HandlerHTTP{
+		log:      log,
+		notifier: notifier,
+	}
+}
+
+func (api HandlerHTTP) RegisterExpiryEventHandlers(r *mux.Router) {
+	memstore := r.PathPrefix("/memstore").Subrouter()
+	memstore.HandleFunc("/events", api.handleEvents).Methods("GET")
+}
+
+func (api HandlerHTTP) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := api.notifier.Subscribe()
+	defer unsubscribe()
+
+	// the stream outlives the server's write timeout, which would otherwise close it
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		api.log.Warn("failed to clear the write deadline of the memstore events stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case batch := <-events:
+			for _, event := range batch {
+				data, err := json.Marshal(event)
+				if err != nil {
+					api.log.Error("failed to encode expiry event", "error", err)
+					continue
+				}
+				if _, writeErr := fmt.Fprintf(w, "event: expired\ndata: %s\n\n", data); writeErr != nil {
+					api.log.Debug("memstore events subscriber went away", "error", writeErr)
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package expiry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// subscribe opens the event stream of srv, and waits for its subscription to notifier
+func subscribe(t *testing.T, srv *httptest.Server, notifier *Notifier) *http.Response {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/memstore/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Eventually(t, notifier.HasSubscribers, time.Second, 10*time.Millisecond)
+	return resp
+}
+
+// readEvents reads n events from the event stream of resp
+func readEvents(t *testing.T, resp *http.Response, n int) []Event {
+	scanner := bufio.NewScanner(resp.Body)
+	var received []Event
+	for len(received) < n && scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+		received = append(received, event)
+	}
+	return received
+}
+
+func TestHandlersHTTP_StreamEvents(t *testing.T) {
+	notifier := NewNotifier()
+	r := mux.NewRouter()
+	NewHandlerHTTP(testLogger, notifier).RegisterExpiryEventHandlers(r)
+	srv := httptest.NewServer(r)
+	// closed after the stream, which would otherwise keep it open
+	t.Cleanup(srv.Close)
+	resp := subscribe(t, srv, notifier)
+
+	expected := []Event{
+		{Key: "aa", Backend: "EigenDAV2Memstore", InsertedAt: time.Unix(1, 0).UTC(), PrunedAt: time.Unix(2, 0).UTC()},
+		{Key: "bb", Backend: "EigenDAV2Memstore", InsertedAt: time.Unix(3, 0).UTC(), PrunedAt: time.Unix(4, 0).UTC()},
+	}
+	notifier.Publish(expected)
+	require.Equal(t, expected, readEvents(t, resp, len(expected)))
+}
+
+func TestHandlersHTTP_StreamOutlivesWriteTimeout(t *testing.T) {
+	notifier := NewNotifier()
+	r := mux.NewRouter()
+	NewHandlerHTTP(testLogger, notifier).RegisterExpiryEventHandlers(r)
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+	resp := subscribe(t, srv, notifier)
+
+	time.Sleep(3 * srv.Config.WriteTimeout)
+	expected := []Event{{Key: "aa", Backend: "EigenDAV2Memstore"}}
+	notifier.Publish(expected)
+	require.Equal(t, expected, readEvents(t, resp, len(expected)))
+}
+
+func TestWebhook(t *testing.T) {
+	received := make(chan []Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&events))
+		received <- events
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier := NewNotifier()
+	go RunWebhook(ctx, testLogger, notifier, srv.URL)
+	require.Eventually(t, notifier.HasSubscribers, time.Second, 10*time.Millisecond)
+
+	expected := []Event{{Key: "aa", Backend: "EigenDAV1Memstore"}}
+	notifier.Publish(expected)
+
+	select {
+	case events := <-received:
+		require.Equal(t, expected[0].Key, events[0].Key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+}
+
+func TestNilNotifier(t *testing.T) {
+	var notifier *Notifier
+	require.False(t, notifier.HasSubscribers())
+	notifier.Publish([]Event{{Key: "aa"}})
+}
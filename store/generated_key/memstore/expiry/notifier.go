@@ -0,0 +1,83 @@
+package expiry
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBufferSize is the number of batches that can be queued for a subscriber
+// before new batches get dropped for it.
+const subscriberBufferSize = 64
+
+// Event ... describes a single memstore entry that was pruned because it expired.
+type Event struct {
+	// Key is the hex encoded key of the pruned entry.
+	Key string `json:"key"`
+	// Backend is the [common.BackendType] string of the memstore the entry was pruned from.
+	Backend    string    `json:"backend"`
+	InsertedAt time.Time `json:"inserted_at"`
+	PrunedAt   time.Time `json:"pruned_at"`
+}
+
+// Notifier ... fans out batches of expiry events to subscribers (e.g. the SSE handler or the webhook poster).
+// Publishing never blocks: batches are dropped for subscribers that don't keep up.
+// A nil *Notifier is valid and drops every event.
+type Notifier struct {
+	mu          sync.RWMutex
+	subscribers map[chan []Event]struct{}
+}
+
+// NewNotifier ... constructor
+func NewNotifier() *Notifier {
+	return &Notifier{
+		subscribers: make(map[chan []Event]struct{}),
+	}
+}
+
+// HasSubscribers returns true if publishing events would reach anyone.
+// Used by publishers to avoid building events that would get dropped anyway.
+func (n *Notifier) HasSubscribers() bool {
+	if n == nil {
+		return false
+	}
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.subscribers) > 0
+}
+
+// Subscribe returns a channel receiving every published batch, along with a function to unsubscribe.
+// The channel is closed once unsubscribe is called.
+func (n *Notifier) Subscribe() (<-chan []Event, func()) {
+	ch := make(chan []Event, subscriberBufferSize)
+
+	n.mu.Lock()
+	n.subscribers[ch] = struct{}{}
+	n.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			n.mu.Lock()
+			delete(n.subscribers, ch)
+			n.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Publish sends a batch of events to every subscriber.
+func (n *Notifier) Publish(events []Event) {
+	if n == nil || len(events) == 0 {
+		return
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for ch := range n.subscribers {
+		select {
+		case ch <- events:
+		default:
+		}
+	}
+}
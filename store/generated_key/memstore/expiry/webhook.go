@@ -0,0 +1,58 @@
+package expiry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const webhookTimeout = 5 * time.Second
+
+// RunWebhook ... POSTs every batch of expiry events published by the notifier as a JSON array to url,
+// until ctx is done. Failed deliveries are logged and not retried.
+func RunWebhook(ctx context.Context, log logging.Logger, notifier *Notifier, url string) {
+	events, unsubscribe := notifier.Subscribe()
+	defer unsubscribe()
+
+	client := &http.Client{Timeout: webhookTimeout}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case batch := <-events:
+			if err := postEvents(ctx, client, url, batch); err != nil {
+				log.Warn("failed to deliver memstore expiry events to webhook", "url", url,
+					"events", len(batch), "err", err)
+			}
+		}
+	}
+}
+
+func postEvents(ctx context.Context, client *http.Client, url string, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("marshal events: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
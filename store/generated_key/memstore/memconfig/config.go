@@ -2,10 +2,35 @@ package memconfig
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
 
+// EvictionPolicy determines which entry is evicted from the memstore when one of its
+// capacity limits (MaxTotalBytes or MaxEntries) would be exceeded by an insertion.
+type EvictionPolicy string
+
+const (
+	// EvictionPolicyFIFO evicts the entry that was inserted first.
+	EvictionPolicyFIFO EvictionPolicy = "fifo"
+	// EvictionPolicyLRU evicts the entry that was least recently read or written.
+	EvictionPolicyLRU EvictionPolicy = "lru"
+)
+
+// StringToEvictionPolicy converts a (case insensitive) string to an EvictionPolicy.
+func StringToEvictionPolicy(s string) (EvictionPolicy, error) {
+	policy := EvictionPolicy(strings.ToLower(strings.TrimSpace(s)))
+	switch policy {
+	case EvictionPolicyFIFO, EvictionPolicyLRU:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown eviction policy %q, must be one of [%s, %s]",
+			s, EvictionPolicyFIFO, EvictionPolicyLRU)
+	}
+}
+
 // Config contains properties that are used to configure the MemStore's behavior.
 type Config struct {
 	MaxBlobSizeBytes uint64
@@ -17,6 +42,12 @@ type Config struct {
 	// after sleeping PutLatency duration.
 	// This can be used to simulate eigenda being down.
 	PutReturnsFailoverError bool
+	// capacity limits used to bound the memory used by long running memstores.
+	// 0 means no limit. When an insertion would exceed either limit, entries are
+	// evicted according to EvictionPolicy until the new entry fits.
+	MaxTotalBytes  uint64
+	MaxEntries     uint64
+	EvictionPolicy EvictionPolicy
 }
 
 // MarshalJSON implements custom JSON marshaling for Config.
@@ -32,12 +63,18 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		PutLatency              string
 		GetLatency              string
 		PutReturnsFailoverError bool
+		MaxTotalBytes           uint64
+		MaxEntries              uint64
+		EvictionPolicy          EvictionPolicy
 	}{
 		MaxBlobSizeBytes:        c.MaxBlobSizeBytes,
 		BlobExpiration:          c.BlobExpiration.String(),
 		PutLatency:              c.PutLatency.String(),
 		GetLatency:              c.GetLatency.String(),
 		PutReturnsFailoverError: c.PutReturnsFailoverError,
+		MaxTotalBytes:           c.MaxTotalBytes,
+		MaxEntries:              c.MaxEntries,
+		EvictionPolicy:          c.EvictionPolicy,
 	})
 }
 
@@ -118,6 +155,43 @@ func (sc *SafeConfig) SetMaxBlobSizeBytes(maxBlobSizeBytes uint64) {
 	sc.config.MaxBlobSizeBytes = maxBlobSizeBytes
 }
 
+func (sc *SafeConfig) MaxTotalBytes() uint64 {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.MaxTotalBytes
+}
+func (sc *SafeConfig) SetMaxTotalBytes(maxTotalBytes uint64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.MaxTotalBytes = maxTotalBytes
+}
+
+func (sc *SafeConfig) MaxEntries() uint64 {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.MaxEntries
+}
+func (sc *SafeConfig) SetMaxEntries(maxEntries uint64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.MaxEntries = maxEntries
+}
+
+// EvictionPolicy returns the configured eviction policy, defaulting to FIFO when unset.
+func (sc *SafeConfig) EvictionPolicy() EvictionPolicy {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if sc.config.EvictionPolicy == "" {
+		return EvictionPolicyFIFO
+	}
+	return sc.config.EvictionPolicy
+}
+func (sc *SafeConfig) SetEvictionPolicy(policy EvictionPolicy) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.EvictionPolicy = policy
+}
+
 func (sc *SafeConfig) Config() Config {
 	sc.mu.RLock()
 	defer sc.mu.RUnlock()
@@ -17,6 +17,9 @@ type ConfigUpdate struct {
 	GetLatency              *string `json:"GetLatency,omitempty"`
 	PutReturnsFailoverError *bool   `json:"PutReturnsFailoverError,omitempty"`
 	BlobExpiration          *string `json:"BlobExpiration,omitempty"`
+	MaxTotalBytes           *uint64 `json:"MaxTotalBytes,omitempty"`
+	MaxEntries              *uint64 `json:"MaxEntries,omitempty"`
+	EvictionPolicy          *string `json:"EvictionPolicy,omitempty"`
 }
 
 // HandlerHTTP is an admin HandlerHTTP for GETting and PATCHing the memstore configuration.
@@ -98,6 +101,23 @@ func (api HandlerHTTP) handleUpdateConfig(w http.ResponseWriter, r *http.Request
 		api.safeConfig.SetBlobExpiration(duration)
 	}
 
+	if update.MaxTotalBytes != nil {
+		api.safeConfig.SetMaxTotalBytes(*update.MaxTotalBytes)
+	}
+
+	if update.MaxEntries != nil {
+		api.safeConfig.SetMaxEntries(*update.MaxEntries)
+	}
+
+	if update.EvictionPolicy != nil {
+		policy, err := StringToEvictionPolicy(*update.EvictionPolicy)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		api.safeConfig.SetEvictionPolicy(policy)
+	}
+
 	// Return the current configuration
 	err := json.NewEncoder(w).Encode(api.safeConfig.Config())
 	if err != nil {
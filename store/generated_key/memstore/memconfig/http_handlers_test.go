@@ -175,6 +175,35 @@ func TestHandlersHTTP_PatchConfig(t *testing.T) {
 				require.Equal(t, inputConfig, outputConfig)
 			},
 		},
+		{
+			name:          "update capacity limits",
+			initialConfig: Config{},
+			requestBodyJSON: `{
+				"MaxTotalBytes": 4096,
+				"MaxEntries": 10,
+				"EvictionPolicy": "LRU"
+			}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, inputConfig Config, sc *SafeConfig) {
+				outputConfig := sc.Config()
+				inputConfig.MaxTotalBytes = 4096
+				inputConfig.MaxEntries = 10
+				inputConfig.EvictionPolicy = EvictionPolicyLRU
+				require.Equal(t, inputConfig, outputConfig)
+			},
+		},
+		{
+			name: "invalid EvictionPolicy does not update config",
+			initialConfig: Config{
+				EvictionPolicy: EvictionPolicyFIFO,
+			},
+			requestBodyJSON: `{"EvictionPolicy": "random"}`,
+			expectedStatus:  http.StatusBadRequest,
+			validate: func(t *testing.T, inputConfig Config, sc *SafeConfig) {
+				outputConfig := sc.Config()
+				require.Equal(t, inputConfig, outputConfig)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,7 +10,9 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/ephemeraldb"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	eigenda_common "github.com/Layr-Labs/eigenda/api/grpc/common"
@@ -46,9 +48,11 @@ var _ common.EigenDAV1Store = (*MemStore)(nil)
 // New ... constructor
 func New(
 	ctx context.Context, verifier *verify.Verifier, log logging.Logger, config *memconfig.SafeConfig,
+	m metrics.Metricer,
+	notifier *expiry.Notifier,
 ) (*MemStore, error) {
 	return &MemStore{
-		ephemeraldb.New(ctx, config, log),
+		ephemeraldb.New(ctx, config, log, m, common.MemstoreV1BackendType, notifier),
 		log,
 		verifier,
 		codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
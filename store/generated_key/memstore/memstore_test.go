@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -61,6 +62,8 @@ func TestGetSet(t *testing.T) {
 		verifier,
 		testLogger,
 		getDefaultMemStoreTestConfig(),
+		metrics.NoopMetrics,
+		nil,
 	)
 
 	require.NoError(t, err)
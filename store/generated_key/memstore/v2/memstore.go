@@ -13,7 +13,9 @@ import (
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/ephemeraldb"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
@@ -70,9 +72,11 @@ var _ common.EigenDAV2Store = (*MemStore)(nil)
 func New(
 	ctx context.Context, log logging.Logger, config *memconfig.SafeConfig,
 	g1SRS []bn254.G1Affine,
+	m metrics.Metricer,
+	notifier *expiry.Notifier,
 ) (*MemStore, error) {
 	return &MemStore{
-		ephemeraldb.New(ctx, config, log),
+		ephemeraldb.New(ctx, config, log, m, common.MemstoreV2BackendType, notifier),
 		log,
 		g1SRS,
 		codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
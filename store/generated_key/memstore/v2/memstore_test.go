@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -44,6 +45,8 @@ func TestGetSet(t *testing.T) {
 		testLogger,
 		getDefaultMemStoreTestConfig(),
 		g1Srs,
+		metrics.NoopMetrics,
+		nil,
 	)
 
 	require.NoError(t, err)
@@ -0,0 +1,57 @@
+package recording
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	RecordPathFlagName            = withFlagPrefix("record-path")
+	ReplayPathFlagName            = withFlagPrefix("replay-path")
+	ReplaySimulateLatencyFlagName = withFlagPrefix("replay-simulate-latency")
+)
+
+func withFlagPrefix(s string) string {
+	return "recording." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_RECORDING_" + s}
+}
+
+// CLIFlags ... used for record-and-replay configuration of the EigenDA backends
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: RecordPathFlagName,
+			Usage: "Path of a file to which every request/response passing through the EigenDA V1/V2 backends " +
+				"gets appended as a JSON line. Recording is disabled when empty.",
+			EnvVars:  withEnvPrefix(envPrefix, "RECORD_PATH"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: ReplayPathFlagName,
+			Usage: fmt.Sprintf("Path of a recording file (created with --%s) to serve EigenDA V1/V2 requests from. "+
+				"When set, proxy runs fully offline and never contacts the EigenDA network.", RecordPathFlagName),
+			EnvVars:  withEnvPrefix(envPrefix, "REPLAY_PATH"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     ReplaySimulateLatencyFlagName,
+			Usage:    "When replaying, sleep for the recorded latency of each request before responding.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "REPLAY_SIMULATE_LATENCY"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		RecordPath:            ctx.String(RecordPathFlagName),
+		ReplayPath:            ctx.String(ReplayPathFlagName),
+		ReplaySimulateLatency: ctx.Bool(ReplaySimulateLatencyFlagName),
+	}
+}
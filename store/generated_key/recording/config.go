@@ -0,0 +1,38 @@
+package recording
+
+import (
+	"fmt"
+)
+
+// Config ... configures the record-and-replay mode of the EigenDA backends.
+// At most one of RecordPath and ReplayPath can be set.
+type Config struct {
+	// RecordPath is the file that requests/responses get appended to. Empty disables recording.
+	RecordPath string
+	// ReplayPath is the file that requests get served from. Empty disables replaying.
+	ReplayPath string
+	// ReplaySimulateLatency makes the replay stores sleep for the recorded latency before responding.
+	ReplaySimulateLatency bool
+}
+
+// RecordEnabled returns true if requests to the EigenDA backends should be recorded.
+func (c Config) RecordEnabled() bool {
+	return c.RecordPath != ""
+}
+
+// ReplayEnabled returns true if the EigenDA backends should be replaced by replay stores.
+func (c Config) ReplayEnabled() bool {
+	return c.ReplayPath != ""
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c Config) Check() error {
+	if c.RecordEnabled() && c.ReplayEnabled() {
+		return fmt.Errorf("cannot both record (--%s) and replay (--%s) at the same time",
+			RecordPathFlagName, ReplayPathFlagName)
+	}
+	if c.ReplaySimulateLatency && !c.ReplayEnabled() {
+		return fmt.Errorf("--%s requires --%s to be set", ReplaySimulateLatencyFlagName, ReplayPathFlagName)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package recording
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Method identifies which store method a Record was captured from.
+type Method string
+
+const (
+	MethodPut    Method = "put"
+	MethodGet    Method = "get"
+	MethodVerify Method = "verify"
+)
+
+// ErrorKind classifies recorded errors, such that replayed errors get mapped
+// to the same HTTP status codes as the original ones.
+type ErrorKind string
+
+const (
+	ErrorKindCertVerificationFailed ErrorKind = "cert_verification_failed"
+	ErrorKindFailover               ErrorKind = "failover"
+	ErrorKindResourceExhausted      ErrorKind = "resource_exhausted"
+	ErrorKindOversizedBlob          ErrorKind = "oversized_blob"
+	ErrorKindOther                  ErrorKind = "other"
+)
+
+// Record ... a single request/response pair that passed through an EigenDA backend.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Backend is the [common.BackendType] string of the recorded store.
+	Backend string `json:"backend"`
+	Method  Method `json:"method"`
+	// CertVersion is only set for EigenDA V2 get and verify requests.
+	CertVersion         byte           `json:"cert_version,omitempty"`
+	SerializedCert      hexutil.Bytes  `json:"serialized_cert,omitempty"`
+	Payload             hexutil.Bytes  `json:"payload,omitempty"`
+	L1InclusionBlockNum uint64         `json:"l1_inclusion_block_num,omitempty"`
+	Error               *RecordedError `json:"error,omitempty"`
+	LatencyMs           int64          `json:"latency_ms"`
+}
+
+// Latency returns the recorded latency of the request.
+func (r Record) Latency() time.Duration {
+	return time.Duration(r.LatencyMs) * time.Millisecond
+}
+
+// RecordedError ... serializable representation of an error returned by an EigenDA backend.
+type RecordedError struct {
+	Kind ErrorKind `json:"kind"`
+	Msg  string    `json:"msg"`
+	// StatusCode is only set for ErrorKindCertVerificationFailed errors.
+	StatusCode uint8 `json:"status_code,omitempty"`
+}
+
+// NewRecordedError converts err into its serializable representation. Returns nil if err is nil.
+func NewRecordedError(err error) *RecordedError {
+	if err == nil {
+		return nil
+	}
+
+	var certVerificationFailedErr *verification.CertVerificationFailedError
+	switch {
+	case errors.As(err, &certVerificationFailedErr):
+		return &RecordedError{
+			Kind:       ErrorKindCertVerificationFailed,
+			Msg:        certVerificationFailedErr.Msg,
+			StatusCode: uint8(certVerificationFailedErr.StatusCode),
+		}
+	case proxyerrors.Is503(err):
+		return &RecordedError{Kind: ErrorKindFailover, Msg: err.Error()}
+	case proxyerrors.Is429(err):
+		return &RecordedError{Kind: ErrorKindResourceExhausted, Msg: status.Convert(err).Message()}
+	case errors.Is(err, proxyerrors.ErrProxyOversizedBlob):
+		return &RecordedError{Kind: ErrorKindOversizedBlob, Msg: err.Error()}
+	default:
+		return &RecordedError{Kind: ErrorKindOther, Msg: err.Error()}
+	}
+}
+
+// ToError reconstructs an error that gets handled by the proxy the same way as the recorded one.
+func (e *RecordedError) ToError() error {
+	if e == nil {
+		return nil
+	}
+
+	switch e.Kind {
+	case ErrorKindCertVerificationFailed:
+		return &verification.CertVerificationFailedError{
+			StatusCode: coretypes.VerificationStatusCode(e.StatusCode),
+			Msg:        e.Msg,
+		}
+	case ErrorKindFailover:
+		return api.NewErrorFailover(errors.New(e.Msg))
+	case ErrorKindResourceExhausted:
+		return status.Error(codes.ResourceExhausted, e.Msg)
+	case ErrorKindOversizedBlob:
+		return fmt.Errorf("%w: %s", proxyerrors.ErrProxyOversizedBlob, e.Msg)
+	case ErrorKindOther:
+		fallthrough
+	default:
+		return errors.New(e.Msg)
+	}
+}
+
+// Writer ... appends records as JSON lines to a file. Safe for concurrent use.
+type Writer struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewWriter opens (or creates) the file at path in append mode.
+func NewWriter(path string) (*Writer, error) {
+	// #nosec G304 - path is provided by the operator via a flag
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open recording file %s: %w", path, err)
+	}
+
+	return &Writer{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// Write appends a single record to the file.
+func (w *Writer) Write(record Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.encoder.Encode(record); err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("sync recording file: %w", err)
+	}
+	return w.file.Close()
+}
+
+// ReadRecords reads all records from the JSON lines file at path, in the order they were written.
+func ReadRecords(path string) ([]Record, error) {
+	// #nosec G304 - path is provided by the operator via a flag
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open recording file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var records []Record
+	decoder := json.NewDecoder(file)
+	for {
+		var record Record
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode record %d from %s: %w", len(records), path, err)
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,141 @@
+package recording
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// RecordingV1Store ... wraps an EigenDA V1 store and records every request/response passing through it.
+// Failing to record a request is logged, but never fails the request itself.
+type RecordingV1Store struct {
+	common.EigenDAV1Store
+	log    logging.Logger
+	writer *Writer
+}
+
+var _ common.EigenDAV1Store = (*RecordingV1Store)(nil)
+
+func NewRecordingV1Store(store common.EigenDAV1Store, writer *Writer, log logging.Logger) *RecordingV1Store {
+	return &RecordingV1Store{
+		EigenDAV1Store: store,
+		log:            log,
+		writer:         writer,
+	}
+}
+
+func (r *RecordingV1Store) Put(ctx context.Context, payload []byte) ([]byte, error) {
+	start := time.Now()
+	serializedCert, err := r.EigenDAV1Store.Put(ctx, payload)
+	r.record(Record{
+		Method:         MethodPut,
+		SerializedCert: serializedCert,
+		Payload:        payload,
+	}, start, err)
+	return serializedCert, err
+}
+
+func (r *RecordingV1Store) Get(ctx context.Context, serializedCert []byte) ([]byte, error) {
+	start := time.Now()
+	payload, err := r.EigenDAV1Store.Get(ctx, serializedCert)
+	r.record(Record{
+		Method:         MethodGet,
+		SerializedCert: serializedCert,
+		Payload:        payload,
+	}, start, err)
+	return payload, err
+}
+
+func (r *RecordingV1Store) Verify(
+	ctx context.Context, serializedCert []byte, payload []byte, opts common.CertVerificationOpts,
+) error {
+	start := time.Now()
+	err := r.EigenDAV1Store.Verify(ctx, serializedCert, payload, opts)
+	r.record(Record{
+		Method:              MethodVerify,
+		SerializedCert:      serializedCert,
+		Payload:             payload,
+		L1InclusionBlockNum: opts.L1InclusionBlockNum,
+	}, start, err)
+	return err
+}
+
+func (r *RecordingV1Store) record(record Record, start time.Time, err error) {
+	writeRecord(r.writer, r.log, r.BackendType(), record, start, err)
+}
+
+// RecordingV2Store ... wraps an EigenDA V2 store and records every request/response passing through it.
+// Failing to record a request is logged, but never fails the request itself.
+type RecordingV2Store struct {
+	common.EigenDAV2Store
+	log    logging.Logger
+	writer *Writer
+}
+
+var _ common.EigenDAV2Store = (*RecordingV2Store)(nil)
+
+func NewRecordingV2Store(store common.EigenDAV2Store, writer *Writer, log logging.Logger) *RecordingV2Store {
+	return &RecordingV2Store{
+		EigenDAV2Store: store,
+		log:            log,
+		writer:         writer,
+	}
+}
+
+func (r *RecordingV2Store) Put(ctx context.Context, payload []byte) ([]byte, error) {
+	start := time.Now()
+	serializedCert, err := r.EigenDAV2Store.Put(ctx, payload)
+	r.record(Record{
+		Method:         MethodPut,
+		SerializedCert: serializedCert,
+		Payload:        payload,
+	}, start, err)
+	return serializedCert, err
+}
+
+func (r *RecordingV2Store) Get(ctx context.Context, versionedCert certs.VersionedCert) ([]byte, error) {
+	start := time.Now()
+	payload, err := r.EigenDAV2Store.Get(ctx, versionedCert)
+	r.record(Record{
+		Method:         MethodGet,
+		CertVersion:    byte(versionedCert.Version),
+		SerializedCert: versionedCert.SerializedCert,
+		Payload:        payload,
+	}, start, err)
+	return payload, err
+}
+
+func (r *RecordingV2Store) Verify(
+	ctx context.Context, versionedCert certs.VersionedCert, opts common.CertVerificationOpts,
+) error {
+	start := time.Now()
+	err := r.EigenDAV2Store.Verify(ctx, versionedCert, opts)
+	r.record(Record{
+		Method:              MethodVerify,
+		CertVersion:         byte(versionedCert.Version),
+		SerializedCert:      versionedCert.SerializedCert,
+		L1InclusionBlockNum: opts.L1InclusionBlockNum,
+	}, start, err)
+	return err
+}
+
+func (r *RecordingV2Store) record(record Record, start time.Time, err error) {
+	writeRecord(r.writer, r.log, r.BackendType(), record, start, err)
+}
+
+func writeRecord(
+	writer *Writer, log logging.Logger, backendType common.BackendType, record Record, start time.Time, err error,
+) {
+	record.Timestamp = start
+	record.Backend = backendType.String()
+	record.LatencyMs = time.Since(start).Milliseconds()
+	record.Error = NewRecordedError(err)
+
+	if writeErr := writer.Write(record); writeErr != nil {
+		log.Error("failed to record EigenDA request", "backend", record.Backend, "method", record.Method,
+			"err", writeErr)
+	}
+}
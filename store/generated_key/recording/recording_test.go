@@ -0,0 +1,131 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// fakeV2Store returns canned responses, and fails verification for any cert other than validCert.
+type fakeV2Store struct {
+	validCert []byte
+	putErr    error
+}
+
+func (f *fakeV2Store) BackendType() common.BackendType { return common.EigenDAV2BackendType }
+
+func (f *fakeV2Store) Put(_ context.Context, payload []byte) ([]byte, error) {
+	if f.putErr != nil {
+		return nil, f.putErr
+	}
+	return append([]byte("cert-"), payload...), nil
+}
+
+func (f *fakeV2Store) Get(_ context.Context, versionedCert certs.VersionedCert) ([]byte, error) {
+	return versionedCert.SerializedCert[len("cert-"):], nil
+}
+
+func (f *fakeV2Store) Verify(
+	_ context.Context, versionedCert certs.VersionedCert, _ common.CertVerificationOpts,
+) error {
+	if string(versionedCert.SerializedCert) != string(f.validCert) {
+		return &verification.CertVerificationFailedError{StatusCode: 7, Msg: "invalid cert"}
+	}
+	return nil
+}
+
+func TestRecordAndReplayV2(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	writer, err := NewWriter(path)
+	require.NoError(t, err)
+	fake := &fakeV2Store{validCert: []byte("cert-hello")}
+	recorder := NewRecordingV2Store(fake, writer, testLogger)
+
+	cert, err := recorder.Put(ctx, []byte("hello"))
+	require.NoError(t, err)
+	versionedCert := certs.NewVersionedCert(cert, certs.V2VersionByte)
+	payload, err := recorder.Get(ctx, versionedCert)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), payload)
+	require.NoError(t, recorder.Verify(ctx, versionedCert, common.CertVerificationOpts{L1InclusionBlockNum: 10}))
+
+	badCert := certs.NewVersionedCert([]byte("cert-bad"), certs.V2VersionByte)
+	require.Error(t, recorder.Verify(ctx, badCert, common.CertVerificationOpts{}))
+
+	fake.putErr = api.NewErrorFailover(errors.New("disperser down"))
+	_, err = recorder.Put(ctx, []byte("failover"))
+	require.True(t, proxyerrors.Is503(err))
+	require.NoError(t, writer.Close())
+
+	records, err := ReadRecords(path)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	replay := NewReplayV2Store(testLogger, records, false)
+
+	cert, err = replay.Put(ctx, []byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("cert-hello"), cert)
+
+	payload, err = replay.Get(ctx, versionedCert)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), payload)
+
+	require.NoError(t, replay.Verify(ctx, versionedCert, common.CertVerificationOpts{L1InclusionBlockNum: 10}))
+
+	err = replay.Verify(ctx, badCert, common.CertVerificationOpts{})
+	var certVerificationFailedErr *verification.CertVerificationFailedError
+	require.ErrorAs(t, err, &certVerificationFailedErr)
+	require.EqualValues(t, 7, certVerificationFailedErr.StatusCode)
+
+	_, err = replay.Put(ctx, []byte("failover"))
+	require.True(t, proxyerrors.Is503(err))
+
+	// requests that were never recorded can't be served
+	err = replay.Verify(ctx, versionedCert, common.CertVerificationOpts{L1InclusionBlockNum: 11})
+	require.ErrorIs(t, err, ErrRecordingNotFound)
+	_, err = NewReplayV1Store(testLogger, records, false).Put(ctx, []byte("hello"))
+	require.ErrorIs(t, err, ErrRecordingNotFound)
+}
+
+func TestReplayServesResponsesInOrder(t *testing.T) {
+	ctx := context.Background()
+	versionedCert := certs.NewVersionedCert([]byte("cert"), certs.V1VersionByte)
+	get := func(payload string, err error) Record {
+		return Record{
+			Backend:        common.EigenDAV2BackendType.String(),
+			Method:         MethodGet,
+			CertVersion:    byte(certs.V1VersionByte),
+			SerializedCert: versionedCert.SerializedCert,
+			Payload:        []byte(payload),
+			Error:          NewRecordedError(err),
+		}
+	}
+
+	replay := NewReplayV2Store(testLogger, []Record{
+		get("", errors.New("relay timeout")),
+		get("payload", nil),
+	}, false)
+
+	_, err := replay.Get(ctx, versionedCert)
+	require.EqualError(t, err, "relay timeout")
+	for i := 0; i < 3; i++ {
+		payload, err := replay.Get(ctx, versionedCert)
+		require.NoError(t, err)
+		require.Equal(t, []byte("payload"), payload)
+	}
+}
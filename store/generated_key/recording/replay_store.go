@@ -0,0 +1,205 @@
+package recording
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrRecordingNotFound is returned by the replay stores when no recording matches a request.
+var ErrRecordingNotFound = errors.New("no recording found for request")
+
+// replayer ... serves recorded responses indexed by request. When the same request was recorded
+// multiple times, the responses are served in the order they were recorded, and the last one
+// is repeated once all of them have been served.
+type replayer struct {
+	log             logging.Logger
+	simulateLatency bool
+
+	mu        sync.Mutex
+	responses map[string][]Record
+	served    map[string]int
+}
+
+func newReplayer(
+	log logging.Logger, records []Record, simulateLatency bool, backendTypes ...common.BackendType,
+) *replayer {
+	backends := make([]string, len(backendTypes))
+	for i, bt := range backendTypes {
+		backends[i] = bt.String()
+	}
+
+	r := &replayer{
+		log:             log,
+		simulateLatency: simulateLatency,
+		responses:       make(map[string][]Record),
+		served:          make(map[string]int),
+	}
+	for _, record := range records {
+		if !slices.Contains(backends, record.Backend) {
+			continue
+		}
+		key := requestKey(record)
+		r.responses[key] = append(r.responses[key], record)
+	}
+
+	log.Info("Loaded EigenDA recordings for replay", "backends", backends, "requests", len(r.responses))
+	return r
+}
+
+// requestKey identifies a request by its inputs, ignoring the outputs of the recorded response.
+func requestKey(record Record) string {
+	switch record.Method {
+	case MethodPut:
+		return string(MethodPut) + "/" + hex.EncodeToString(crypto.Keccak256(record.Payload))
+	case MethodGet:
+		return string(MethodGet) + "/" + strconv.Itoa(int(record.CertVersion)) + "/" +
+			hex.EncodeToString(crypto.Keccak256(record.SerializedCert))
+	case MethodVerify:
+		return string(MethodVerify) + "/" + strconv.Itoa(int(record.CertVersion)) + "/" +
+			hex.EncodeToString(crypto.Keccak256(record.SerializedCert)) + "/" +
+			strconv.FormatUint(record.L1InclusionBlockNum, 10)
+	default:
+		return string(record.Method)
+	}
+}
+
+// replay returns the next recorded response for the given request.
+func (r *replayer) replay(ctx context.Context, request Record) (Record, error) {
+	key := requestKey(request)
+
+	r.mu.Lock()
+	responses, ok := r.responses[key]
+	if !ok {
+		r.mu.Unlock()
+		return Record{}, fmt.Errorf("%w: method %s, cert %s", ErrRecordingNotFound, request.Method,
+			hex.EncodeToString(request.SerializedCert))
+	}
+	idx := min(r.served[key], len(responses)-1)
+	r.served[key]++
+	r.mu.Unlock()
+
+	response := responses[idx]
+	if r.simulateLatency {
+		select {
+		case <-ctx.Done():
+			return Record{}, fmt.Errorf("simulating recorded latency: %w", ctx.Err())
+		case <-time.After(response.Latency()):
+		}
+	}
+
+	return response, nil
+}
+
+// ReplayV1Store ... serves EigenDA V1 requests from recordings, without any network access.
+type ReplayV1Store struct {
+	replayer *replayer
+}
+
+var _ common.EigenDAV1Store = (*ReplayV1Store)(nil)
+
+// NewReplayV1Store ... constructor. Records of both the EigenDA V1 backend and V1 memstore are served.
+func NewReplayV1Store(log logging.Logger, records []Record, simulateLatency bool) *ReplayV1Store {
+	return &ReplayV1Store{
+		replayer: newReplayer(log, records, simulateLatency,
+			common.EigenDABackendType, common.MemstoreV1BackendType),
+	}
+}
+
+func (r *ReplayV1Store) Put(ctx context.Context, payload []byte) ([]byte, error) {
+	response, err := r.replayer.replay(ctx, Record{Method: MethodPut, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	return response.SerializedCert, response.Error.ToError()
+}
+
+func (r *ReplayV1Store) Get(ctx context.Context, serializedCert []byte) ([]byte, error) {
+	response, err := r.replayer.replay(ctx, Record{Method: MethodGet, SerializedCert: serializedCert})
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, response.Error.ToError()
+}
+
+func (r *ReplayV1Store) Verify(
+	ctx context.Context, serializedCert []byte, _ []byte, opts common.CertVerificationOpts,
+) error {
+	response, err := r.replayer.replay(ctx, Record{
+		Method:              MethodVerify,
+		SerializedCert:      serializedCert,
+		L1InclusionBlockNum: opts.L1InclusionBlockNum,
+	})
+	if err != nil {
+		return err
+	}
+	return response.Error.ToError()
+}
+
+func (r *ReplayV1Store) BackendType() common.BackendType {
+	return common.EigenDABackendType
+}
+
+// ReplayV2Store ... serves EigenDA V2 requests from recordings, without any network access.
+type ReplayV2Store struct {
+	replayer *replayer
+}
+
+var _ common.EigenDAV2Store = (*ReplayV2Store)(nil)
+
+// NewReplayV2Store ... constructor. Records of both the EigenDA V2 backend and V2 memstore are served.
+func NewReplayV2Store(log logging.Logger, records []Record, simulateLatency bool) *ReplayV2Store {
+	return &ReplayV2Store{
+		replayer: newReplayer(log, records, simulateLatency,
+			common.EigenDAV2BackendType, common.MemstoreV2BackendType),
+	}
+}
+
+func (r *ReplayV2Store) Put(ctx context.Context, payload []byte) ([]byte, error) {
+	response, err := r.replayer.replay(ctx, Record{Method: MethodPut, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	return response.SerializedCert, response.Error.ToError()
+}
+
+func (r *ReplayV2Store) Get(ctx context.Context, versionedCert certs.VersionedCert) ([]byte, error) {
+	response, err := r.replayer.replay(ctx, Record{
+		Method:         MethodGet,
+		CertVersion:    byte(versionedCert.Version),
+		SerializedCert: versionedCert.SerializedCert,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, response.Error.ToError()
+}
+
+func (r *ReplayV2Store) Verify(
+	ctx context.Context, versionedCert certs.VersionedCert, opts common.CertVerificationOpts,
+) error {
+	response, err := r.replayer.replay(ctx, Record{
+		Method:              MethodVerify,
+		CertVersion:         byte(versionedCert.Version),
+		SerializedCert:      versionedCert.SerializedCert,
+		L1InclusionBlockNum: opts.L1InclusionBlockNum,
+	})
+	if err != nil {
+		return err
+	}
+	return response.Error.ToError()
+}
+
+func (r *ReplayV2Store) BackendType() common.BackendType {
+	return common.EigenDAV2BackendType
+}
@@ -5,25 +5,34 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/utils"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/avast/retry-go/v4"
 	"github.com/ethereum/go-ethereum/rlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // Store does storage interactions and verifications for blobs with the EigenDA V2 protocol.
 type Store struct {
-	log logging.Logger
+	log     logging.Logger
+	metrics metrics.Metricer
 
 	// Number of times to try blob dispersals:
 	// - If > 0: Try N times total
@@ -35,38 +44,68 @@ type Store struct {
 	// If cert.L1InclusionBlock > batch.RBN + rbnRecencyWindowSize, an
 	// [RBNRecencyCheckFailedError] is returned.
 	// This check is optional and will be skipped when rbnRecencyWindowSize is set to 0.
-	rbnRecencyWindowSize uint64
-
-	disperser    *payloaddispersal.PayloadDisperser
-	retrievers   []clients.PayloadRetriever
-	certVerifier *verification.CertVerifier
+	// It is a pointer so that SetRBNRecencyWindowSize is seen by the copies of the Store.
+	rbnRecencyWindowSize *atomic.Uint64
+
+	disperser payloadSender
+	// tenantDispersers are the dispersers of the tenants with their own signer, by tenant name.
+	// Other requests are dispersed with disperser.
+	tenantDispersers map[string]*payloaddispersal.PayloadDisperser
+	retrievers       []clients.PayloadRetriever
+	certVerifier     *verification.CertVerifier
+	// Used to resolve the cert verifier address for a cert's RBN, which is part of the verification cache key.
+	certVerifierAddrProvider clients.CertVerifierAddressProvider
+	// Caches cert verification results to avoid repeating CheckDACert eth-calls. Nil disables caching.
+	verificationCache *CertVerificationCache
 }
 
 var _ common.EigenDAV2Store = (*Store)(nil)
 
+// payloadSender sends payloads to EigenDA and returns their certs, as done by [payloaddispersal.PayloadDisperser].
+type payloadSender interface {
+	SendPayload(ctx context.Context, payload *coretypes.Payload) (coretypes.EigenDACert, error)
+}
+
 func NewStore(
 	log logging.Logger,
+	m metrics.Metricer,
 	putTries int,
 	rbnRecencyWindowSize uint64,
 	disperser *payloaddispersal.PayloadDisperser,
+	tenantDispersers map[string]*payloaddispersal.PayloadDisperser,
 	retrievers []clients.PayloadRetriever,
 	certVerifier *verification.CertVerifier,
+	certVerifierAddrProvider clients.CertVerifierAddressProvider,
+	verificationCache *CertVerificationCache,
 ) (*Store, error) {
 	if putTries == 0 {
 		return nil, fmt.Errorf(
 			"putTries==0 is not permitted. >0 means 'try N times', <0 means 'retry indefinitely'")
 	}
 
+	window := new(atomic.Uint64)
+	window.Store(rbnRecencyWindowSize)
+
 	return &Store{
-		log:                  log,
-		putTries:             putTries,
-		rbnRecencyWindowSize: rbnRecencyWindowSize,
-		disperser:            disperser,
-		retrievers:           retrievers,
-		certVerifier:         certVerifier,
+		log:                      log,
+		metrics:                  m,
+		putTries:                 putTries,
+		rbnRecencyWindowSize:     window,
+		disperser:                disperser,
+		tenantDispersers:         tenantDispersers,
+		retrievers:               retrievers,
+		certVerifier:             certVerifier,
+		certVerifierAddrProvider: certVerifierAddrProvider,
+		verificationCache:        verificationCache,
 	}, nil
 }
 
+// SetRBNRecencyWindowSize sets the allowed distance between the RBN of the certs verified from now on
+// and their L1 inclusion block. 0 disables the check.
+func (e Store) SetRBNRecencyWindowSize(rbnRecencyWindowSize uint64) {
+	e.rbnRecencyWindowSize.Store(rbnRecencyWindowSize)
+}
+
 // Get fetches a blob from DA using certificate fields and verifies blob
 // against commitment to ensure data is valid and non-tampered.
 func (e Store) Get(ctx context.Context, versionedCert certs.VersionedCert) ([]byte, error) {
@@ -96,12 +135,18 @@ func (e Store) Get(ctx context.Context, versionedCert certs.VersionedCert) ([]by
 	// Try each retriever in sequence until one succeeds
 	var errs []error
 	for _, retriever := range e.retrievers {
-		payload, err := retriever.GetPayload(ctx, cert)
+		name := retrieverName(retriever)
+		retrieverCtx, span := tracing.Start(ctx, "EigenDAV2.GetPayload", attribute.String("retriever", name))
+		recordRetrieval := e.metrics.RecordRetrieval(e.BackendType().String(), name)
+		payload, err := retriever.GetPayload(retrieverCtx, cert)
+		tracing.End(span, err)
 		if err == nil {
+			recordRetrieval(metrics.RetrievalStatusSuccess)
 			return payload.Serialize(), nil
 		}
+		recordRetrieval(metrics.RetrievalStatusFailed)
 
-		e.log.Debugf("Payload retriever failed: %v", err)
+		proxy_logging.FromContext(ctx, e.log).Debugf("Payload retriever failed: %v", err)
 	}
 
 	return nil, fmt.Errorf("all retrievers failed: %w", errors.Join(errs...))
@@ -110,7 +155,7 @@ func (e Store) Get(ctx context.Context, versionedCert certs.VersionedCert) ([]by
 // Put disperses a blob for some pre-image and returns the associated RLP encoded certificate commit.
 // TODO: Client polling for different status codes, Mapping status codes to 503 failover
 func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
-	e.log.Debug("Dispersing payload to EigenDA V2 network")
+	proxy_logging.FromContext(ctx, e.log).Debug("Dispersing payload to EigenDA V2 network")
 
 	// TODO: https://github.com/Layr-Labs/eigenda/issues/1271
 
@@ -118,9 +163,26 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 
 	payload := coretypes.NewPayload(value)
 
+	disperser := e.disperser
+	if t := tenant.FromContext(ctx); t != nil {
+		if tenantDisperser, ok := e.tenantDispersers[t.Name]; ok {
+			disperser = tenantDisperser
+		}
+	}
+
+	backendType := e.BackendType().String()
+	attempts := 0
 	cert, err := retry.DoWithData(
 		func() (coretypes.EigenDACert, error) {
-			return e.disperser.SendPayload(ctx, payload)
+			if attempts > 0 {
+				e.metrics.RecordDispersalRetry(backendType)
+			}
+			attempts++
+			// SendPayload sends the payload, polls for its confirmation and builds the cert,
+			// without exposing the time spent in each of these steps.
+			recordSendAndConfirm := e.metrics.RecordDispersalStage(backendType, metrics.DispersalStageSendAndConfirm)
+			defer recordSendAndConfirm()
+			return disperser.SendPayload(ctx, payload)
 		},
 		retry.RetryIf(
 			func(err error) bool {
@@ -158,6 +220,8 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	recordCertSerialize := e.metrics.RecordDispersalStage(backendType, metrics.DispersalStageCertSerialize)
+	defer recordCertSerialize()
 	switch cert.Version() {
 	case coretypes.VersionTwoCert:
 		return nil, fmt.Errorf("EigenDA V2 certs are not supported anymore, use V3 instead")
@@ -229,14 +293,14 @@ func (e Store) Verify(ctx context.Context, versionedCert certs.VersionedCert, op
 	}
 
 	// check recency first since it requires less processing and no IO vs verifying the cert
-	err := verifyCertRBNRecencyCheck(referenceBlockNumber, opts.L1InclusionBlockNum, e.rbnRecencyWindowSize)
+	err := verifyCertRBNRecencyCheck(referenceBlockNumber, opts.L1InclusionBlockNum, e.rbnRecencyWindowSize.Load())
 	if err != nil {
 		// Already a structured error converted to a 418 HTTP error by the error middleware.
 		return err
 	}
 
-	// verify cert via simulation call to verifier contract
-	err = e.certVerifier.CheckDACert(ctx, sumDACert)
+	// verify cert via simulation call to verifier contract, unless the result is already cached
+	err = e.checkDACert(ctx, versionedCert, referenceBlockNumber, sumDACert)
 	if err != nil {
 		// CheckDACert already returns a structured error that is converted to a 418 HTTP error by the error middleware.
 		// We still wrap it to provide more context.
@@ -246,6 +310,83 @@ func (e Store) Verify(ctx context.Context, versionedCert certs.VersionedCert, op
 	return nil
 }
 
+// checkDACert calls CertVerifier.checkDACert, going through the verification cache when it is enabled.
+func (e Store) checkDACert(
+	ctx context.Context,
+	versionedCert certs.VersionedCert,
+	referenceBlockNumber uint64,
+	sumDACert coretypes.EigenDACert,
+) error {
+	ctx, span := tracing.Start(ctx, "EigenDAV2.CheckDACert",
+		// #nosec G115 - reference block numbers don't overflow int64
+		attribute.Int64("reference_block_number", int64(referenceBlockNumber)))
+	err := e.checkDACertCached(ctx, versionedCert, referenceBlockNumber, sumDACert, span)
+	tracing.End(span, err)
+	return err
+}
+
+// checkDACertCached holds the logic of checkDACert. Cache lookups are recorded on span.
+func (e Store) checkDACertCached(
+	ctx context.Context,
+	versionedCert certs.VersionedCert,
+	referenceBlockNumber uint64,
+	sumDACert coretypes.EigenDACert,
+	span trace.Span,
+) error {
+	if e.verificationCache == nil {
+		return e.ethCallCheckDACert(ctx, sumDACert)
+	}
+
+	savedRPCCalls := 0
+	verifierAddr, ok := e.verificationCache.verifierAddrByRBN.get(referenceBlockNumber)
+	if ok {
+		savedRPCCalls++
+	} else {
+		var err error
+		verifierAddr, err = e.certVerifierAddrProvider.GetCertVerifierAddress(ctx, referenceBlockNumber)
+		if err != nil {
+			return fmt.Errorf("get cert verifier address for RBN %d: %w", referenceBlockNumber, err)
+		}
+		e.verificationCache.verifierAddrByRBN.add(referenceBlockNumber, verifierAddr)
+	}
+
+	key := newCertVerificationKey(versionedCert, verifierAddr, e.rbnRecencyWindowSize.Load())
+	if cachedResult, hit := e.verificationCache.results.get(key); hit {
+		e.metrics.RecordCertVerificationCacheLookup(true, savedRPCCalls+1)
+		span.SetAttributes(attribute.Bool("cache_hit", true))
+		return cachedResult
+	}
+	e.metrics.RecordCertVerificationCacheLookup(false, savedRPCCalls)
+	span.SetAttributes(attribute.Bool("cache_hit", false))
+
+	err := e.ethCallCheckDACert(ctx, sumDACert)
+	// only cache deterministic results, never transient errors such as RPC failures
+	var certVerificationFailedErr *verification.CertVerificationFailedError
+	if err == nil || errors.As(err, &certVerificationFailedErr) {
+		e.verificationCache.results.add(key, err)
+	}
+	return err
+}
+
+// ethCallCheckDACert calls CertVerifier.checkDACert, recording the duration of the eth-call.
+func (e Store) ethCallCheckDACert(ctx context.Context, sumDACert coretypes.EigenDACert) error {
+	recordEthCall := e.metrics.RecordVerificationStage(e.BackendType().String(), metrics.VerificationStageEthCall)
+	defer recordEthCall()
+	return e.certVerifier.CheckDACert(ctx, sumDACert)
+}
+
+// retrieverName returns the name identifying a payload retriever in metrics and traces.
+func retrieverName(retriever clients.PayloadRetriever) string {
+	switch retriever.(type) {
+	case *payloadretrieval.RelayPayloadRetriever:
+		return "relay"
+	case *payloadretrieval.ValidatorPayloadRetriever:
+		return "validator"
+	default:
+		return fmt.Sprintf("%T", retriever)
+	}
+}
+
 // verifyCertRBNRecencyCheck arguments:
 //   - certRBN: ReferenceBlockNumber included in the cert itself at which operator stakes are referenced
 //     when verifying that a cert's signature meets the required quorum thresholds.
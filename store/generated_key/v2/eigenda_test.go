@@ -0,0 +1,161 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// storeMetricer records the dispersal and retrieval metrics of the store.
+type storeMetricer struct {
+	metrics.Metricer
+	stages     []string
+	retries    int
+	retrievals []string
+}
+
+func (m *storeMetricer) RecordDispersalStage(_ string, stage string) func() {
+	return func() { m.stages = append(m.stages, stage) }
+}
+
+func (m *storeMetricer) RecordDispersalRetry(_ string) {
+	m.retries++
+}
+
+func (m *storeMetricer) RecordRetrieval(_ string, retriever string) func(status string) {
+	return func(status string) { m.retrievals = append(m.retrievals, retriever+":"+status) }
+}
+
+// flakySender fails its first failures sends with err, then returns an empty V3 cert.
+type flakySender struct {
+	failures int
+	err      error
+	sends    int
+}
+
+func (s *flakySender) SendPayload(context.Context, *coretypes.Payload) (coretypes.EigenDACert, error) {
+	s.sends++
+	if s.sends <= s.failures {
+		return nil, s.err
+	}
+	return &coretypes.EigenDACertV3{}, nil
+}
+
+// failingRetriever stands in for a relay retriever that can't serve the payload.
+type failingRetriever struct{}
+
+func (failingRetriever) GetPayload(context.Context, coretypes.RetrievableEigenDACert) (*coretypes.Payload, error) {
+	return nil, errors.New("relay unavailable")
+}
+
+// staticRetriever returns the same payload for every cert.
+type staticRetriever struct {
+	payload []byte
+}
+
+func (r staticRetriever) GetPayload(context.Context, coretypes.RetrievableEigenDACert) (*coretypes.Payload, error) {
+	return coretypes.NewPayload(r.payload), nil
+}
+
+var (
+	_ clients.PayloadRetriever = failingRetriever{}
+	_ clients.PayloadRetriever = staticRetriever{}
+)
+
+func newTestStore(t *testing.T, m metrics.Metricer, putTries int, retrievers ...clients.PayloadRetriever) *Store {
+	store, err := NewStore(testLogger, m, putTries, 0, nil, nil, retrievers, nil, nil, nil)
+	require.NoError(t, err)
+	return store
+}
+
+func TestPutDispersalMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		putTries    int
+		sender      *flakySender
+		wantErr     bool
+		wantRetries int
+		wantStages  []string
+	}{
+		{
+			name:       "first attempt succeeds",
+			putTries:   3,
+			sender:     &flakySender{},
+			wantStages: []string{metrics.DispersalStageSendAndConfirm, metrics.DispersalStageCertSerialize},
+		},
+		{
+			name:        "failing then succeeding disperser",
+			putTries:    3,
+			sender:      &flakySender{failures: 1, err: errors.New("failover")},
+			wantRetries: 1,
+			wantStages: []string{
+				metrics.DispersalStageSendAndConfirm,
+				metrics.DispersalStageSendAndConfirm,
+				metrics.DispersalStageCertSerialize,
+			},
+		},
+		{
+			name:        "all attempts fail",
+			putTries:    2,
+			sender:      &flakySender{failures: 2, err: errors.New("failover")},
+			wantErr:     true,
+			wantRetries: 1,
+			wantStages:  []string{metrics.DispersalStageSendAndConfirm, metrics.DispersalStageSendAndConfirm},
+		},
+		{
+			name:       "invalid argument is not retried",
+			putTries:   3,
+			sender:     &flakySender{failures: 1, err: status.Error(codes.InvalidArgument, "invalid payload")},
+			wantErr:    true,
+			wantStages: []string{metrics.DispersalStageSendAndConfirm},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &storeMetricer{Metricer: metrics.NoopMetrics}
+			store := newTestStore(t, m, tt.putTries)
+			store.disperser = tt.sender
+
+			_, err := store.Put(context.Background(), []byte("payload"))
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantRetries, m.retries)
+			require.Equal(t, tt.wantStages, m.stages)
+		})
+	}
+}
+
+func TestGetRetrievalMetrics(t *testing.T) {
+	serializedCert, err := rlp.EncodeToBytes(&coretypes.EigenDACertV3{})
+	require.NoError(t, err)
+	versionedCert := certs.NewVersionedCert(serializedCert, certs.V2VersionByte)
+
+	m := &storeMetricer{Metricer: metrics.NoopMetrics}
+	store := newTestStore(t, m, 1, failingRetriever{}, staticRetriever{payload: []byte("payload")})
+
+	payload, err := store.Get(context.Background(), versionedCert)
+	require.NoError(t, err)
+	require.Equal(t, []byte("payload"), payload)
+	require.Equal(t, []string{
+		fmt.Sprintf("%s:%s", retrieverName(failingRetriever{}), metrics.RetrievalStatusFailed),
+		fmt.Sprintf("%s:%s", retrieverName(staticRetriever{}), metrics.RetrievalStatusSuccess),
+	}, m.retrievals)
+}
@@ -0,0 +1,95 @@
+package payments
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PollIntervalFlagName            = withFlagPrefix("poll-interval")
+	LowBalanceThresholdWeiFlagName  = withFlagPrefix("low-balance-threshold-wei")
+	ExhaustionWarningWindowFlagName = withFlagPrefix("exhaustion-warning-window")
+)
+
+func withFlagPrefix(s string) string {
+	return "payments." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_PAYMENTS_" + s}
+}
+
+// Config ... configures the polling of the EigenDA V2 payment state of the signer account
+type Config struct {
+	// PollInterval is the interval at which the payment state is fetched from the disperser. 0 disables polling.
+	PollInterval time.Duration
+	// LowBalanceThresholdWei triggers a warning on every poll where the remaining on-demand deposit is below it.
+	// nil disables the warning.
+	LowBalanceThresholdWei *big.Int
+	// ExhaustionWarningWindow triggers a warning on every poll where the on-demand deposit is projected to be
+	// exhausted, or the reservation to expire, within this window. 0 disables the warnings.
+	ExhaustionWarningWindow time.Duration
+}
+
+func (c Config) Check() error {
+	if c.PollInterval < 0 {
+		return fmt.Errorf("payments poll interval must not be negative, got %s", c.PollInterval)
+	}
+	if c.LowBalanceThresholdWei != nil && c.LowBalanceThresholdWei.Sign() < 0 {
+		return fmt.Errorf("payments low balance threshold must not be negative, got %s", c.LowBalanceThresholdWei)
+	}
+	if c.ExhaustionWarningWindow < 0 {
+		return fmt.Errorf("payments exhaustion warning window must not be negative, got %s", c.ExhaustionWarningWindow)
+	}
+	return nil
+}
+
+// CLIFlags ... used for configuring the EigenDA V2 payment accounting poller
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name: PollIntervalFlagName,
+			Usage: "Interval at which the reservation and on-demand payment state of the signer account is fetched " +
+				"from the EigenDA V2 disperser, and exported as metrics and on GET /admin/payments. 0 disables polling.",
+			Value:    time.Minute,
+			EnvVars:  withEnvPrefix(envPrefix, "POLL_INTERVAL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: LowBalanceThresholdWeiFlagName,
+			Usage: "Log a warning whenever the remaining on-demand deposit of the signer account is below " +
+				"this amount of wei. Empty disables the warning.",
+			EnvVars:  withEnvPrefix(envPrefix, "LOW_BALANCE_THRESHOLD_WEI"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: ExhaustionWarningWindowFlagName,
+			Usage: "Log a warning whenever the on-demand deposit is projected to be exhausted at the current " +
+				"spend rate, or the reservation to expire, within this window. 0 disables the warnings.",
+			Value:    72 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "EXHAUSTION_WARNING_WINDOW"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) (Config, error) {
+	var threshold *big.Int
+	if s := ctx.String(LowBalanceThresholdWeiFlagName); s != "" {
+		var ok bool
+		threshold, ok = new(big.Int).SetString(s, 10)
+		if !ok {
+			return Config{}, fmt.Errorf("parse --%s: %q is not a base 10 integer", LowBalanceThresholdWeiFlagName, s)
+		}
+	}
+
+	return Config{
+		PollInterval:            ctx.Duration(PollIntervalFlagName),
+		LowBalanceThresholdWei:  threshold,
+		ExhaustionWarningWindow: ctx.Duration(ExhaustionWarningWindowFlagName),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package payments
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+)
+
+// HandlerHTTP serves the payment state of the signer account.
+// It adds a route to the proxy's main router (to be served on same port as the main proxy routes):
+// - GET /admin/payments: returns the latest [State] observed by the [Poller]
+type HandlerHTTP struct {
+	log     logging.Logger
+	tracker *Tracker
+}
+
+func NewHandlerHTTP(log logging.Logger, tracker *Tracker) HandlerHTTP {
+	return HandlerHTTP{
+		log:     log,
+		tracker: tracker,
+	}
+}
+
+func (api HandlerHTTP) RegisterPaymentsHandlers(r *mux.Router) {
+	admin := r.PathPrefix("/admin").Subrouter()
+	admin.HandleFunc("/payments", api.handleGetPayments).Methods("GET")
+}
+
+func (api HandlerHTTP) handleGetPayments(w http.ResponseWriter, _ *http.Request) {
+	state, ok := api.tracker.Latest()
+	if !ok {
+		http.Error(w, "payment state not available: it is only polled for the EigenDA V2 backend, "+
+			"and may not have been fetched yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		api.log.Error("failed to encode payment state", "error", err)
+	}
+}
@@ -0,0 +1,220 @@
+package payments
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// spendRateWindow is the window over which the on-demand spend rate is averaged to project exhaustion.
+const spendRateWindow = time.Hour
+
+// Snapshot ... is the raw payment state of an account, as reported by the disperser.
+type Snapshot struct {
+	// ReservationSymbolsPerSecond is 0 when the account has no reservation.
+	ReservationSymbolsPerSecond uint64
+	ReservationStart            time.Time
+	ReservationEnd              time.Time
+	// ReservationWindow is the length of a reservation period.
+	ReservationWindow time.Duration
+	// PeriodUsage maps reservation period indices to the number of symbols dispersed during that period.
+	PeriodUsage map[uint64]uint64
+
+	// OnchainDepositWei is the total amount ever deposited for on-demand payments in the payment vault.
+	OnchainDepositWei *big.Int
+	// CumulativePaymentWei is the total amount ever spent on on-demand dispersals.
+	CumulativePaymentWei *big.Int
+}
+
+// Fetcher ... fetches the payment state of the signer account.
+type Fetcher interface {
+	FetchPaymentState(ctx context.Context) (Snapshot, error)
+}
+
+type spendSample struct {
+	at                   time.Time
+	cumulativePaymentWei *big.Int
+}
+
+// Poller ... periodically fetches the payment state of the signer account, stores it in a [Tracker],
+// exports it as metrics, and warns when the account is running low on funds.
+type Poller struct {
+	log       logging.Logger
+	metrics   metrics.Metricer
+	cfg       Config
+	accountID string
+	fetcher   Fetcher
+	tracker   *Tracker
+	now       func() time.Time
+
+	// samples of the cumulative payment over the last spendRateWindow, oldest first
+	samples []spendSample
+}
+
+// NewPoller ... constructor
+func NewPoller(
+	log logging.Logger,
+	m metrics.Metricer,
+	cfg Config,
+	accountID string,
+	fetcher Fetcher,
+	tracker *Tracker,
+) *Poller {
+	return &Poller{
+		log:       log,
+		metrics:   m,
+		cfg:       cfg,
+		accountID: accountID,
+		fetcher:   fetcher,
+		tracker:   tracker,
+		now:       time.Now,
+	}
+}
+
+// Run polls the payment state every cfg.PollInterval until ctx is done.
+// Polling failures are logged and don't stop the poller.
+func (p *Poller) Run(ctx context.Context) {
+	if p.cfg.PollInterval <= 0 {
+		return
+	}
+	p.log.Info("Polling EigenDA payment state", "account", p.accountID, "interval", p.cfg.PollInterval)
+
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := p.poll(ctx); err != nil {
+			p.log.Warn("Failed to poll EigenDA payment state", "account", p.accountID, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Poller) poll(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.PollInterval)
+	defer cancel()
+	snapshot, err := p.fetcher.FetchPaymentState(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := p.observe(snapshot)
+	p.tracker.set(state)
+	p.warn(state)
+	return nil
+}
+
+// observe builds the State out of a snapshot and records it as metrics.
+func (p *Poller) observe(snapshot Snapshot) State {
+	now := p.now()
+	state := State{
+		AccountID: p.accountID,
+		UpdatedAt: now,
+	}
+
+	var usage, capacity uint64
+	if snapshot.ReservationSymbolsPerSecond > 0 {
+		reservation := &ReservationState{
+			SymbolsPerSecond: snapshot.ReservationSymbolsPerSecond,
+			StartTime:        snapshot.ReservationStart,
+			EndTime:          snapshot.ReservationEnd,
+			Active:           !now.Before(snapshot.ReservationStart) && now.Before(snapshot.ReservationEnd),
+		}
+		windowSeconds := uint64(snapshot.ReservationWindow / time.Second)
+		if reservation.Active && windowSeconds > 0 {
+			reservation.PeriodIndex = uint64(now.Unix()) / windowSeconds // #nosec G115 -- now is after 1970
+			reservation.PeriodUsageSymbols = snapshot.PeriodUsage[reservation.PeriodIndex]
+			reservation.PeriodCapacitySymbols = snapshot.ReservationSymbolsPerSecond * windowSeconds
+			usage, capacity = reservation.PeriodUsageSymbols, reservation.PeriodCapacitySymbols
+		}
+		state.Reservation = reservation
+	}
+	p.metrics.RecordReservationUsage(usage, capacity)
+
+	deposit := valueOrZero(snapshot.OnchainDepositWei)
+	cumulative := valueOrZero(snapshot.CumulativePaymentWei)
+	remaining := new(big.Int).Sub(deposit, cumulative)
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	state.OnDemand = OnDemandState{
+		DepositWei:           deposit.String(),
+		CumulativePaymentWei: cumulative.String(),
+		RemainingWei:         remaining.String(),
+	}
+
+	remainingFloat, _ := new(big.Float).SetInt(remaining).Float64()
+	secondsUntilExhaustion := -1.0
+	rate := p.spendRate(now, cumulative)
+	if rate > 0 {
+		secondsUntilExhaustion = remainingFloat / rate
+		exhaustion := now.Add(time.Duration(secondsUntilExhaustion * float64(time.Second)))
+		state.OnDemand.SpendRateWeiPerSecond = rate
+		state.OnDemand.ProjectedExhaustion = &exhaustion
+	}
+	p.metrics.RecordOnDemandBalance(remainingFloat, secondsUntilExhaustion)
+
+	return state
+}
+
+// spendRate records a sample of the cumulative payment and returns the average on-demand spend rate,
+// in wei per second, over the samples of the last spendRateWindow. Returns 0 if it can't be determined yet.
+func (p *Poller) spendRate(now time.Time, cumulativePaymentWei *big.Int) float64 {
+	// the cumulative payment only ever grows. If it didn't, the account state was reset and older samples are stale.
+	if n := len(p.samples); n > 0 && cumulativePaymentWei.Cmp(p.samples[n-1].cumulativePaymentWei) < 0 {
+		p.samples = nil
+	}
+	p.samples = append(p.samples, spendSample{at: now, cumulativePaymentWei: cumulativePaymentWei})
+	for len(p.samples) > 1 && now.Sub(p.samples[1].at) >= spendRateWindow {
+		p.samples = p.samples[1:]
+	}
+
+	oldest := p.samples[0]
+	elapsed := now.Sub(oldest.at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	spent, _ := new(big.Float).SetInt(new(big.Int).Sub(cumulativePaymentWei, oldest.cumulativePaymentWei)).Float64()
+	return spent / elapsed
+}
+
+// warn logs the configured low balance and exhaustion warnings.
+func (p *Poller) warn(state State) {
+	remaining, _ := new(big.Int).SetString(state.OnDemand.RemainingWei, 10)
+	if p.cfg.LowBalanceThresholdWei != nil && remaining.Cmp(p.cfg.LowBalanceThresholdWei) < 0 {
+		p.log.Warn("EigenDA on-demand deposit is below the low balance threshold",
+			"account", p.accountID,
+			"remainingWei", state.OnDemand.RemainingWei,
+			"thresholdWei", p.cfg.LowBalanceThresholdWei.String())
+	}
+
+	if p.cfg.ExhaustionWarningWindow <= 0 {
+		return
+	}
+	deadline := state.UpdatedAt.Add(p.cfg.ExhaustionWarningWindow)
+	if exhaustion := state.OnDemand.ProjectedExhaustion; exhaustion != nil && exhaustion.Before(deadline) {
+		p.log.Warn("EigenDA on-demand deposit is projected to be exhausted soon",
+			"account", p.accountID,
+			"remainingWei", state.OnDemand.RemainingWei,
+			"spendRateWeiPerSecond", state.OnDemand.SpendRateWeiPerSecond,
+			"projectedExhaustion", exhaustion.UTC().Format(time.RFC3339))
+	}
+	if state.Reservation != nil && state.Reservation.Active && state.Reservation.EndTime.Before(deadline) {
+		p.log.Warn("EigenDA reservation expires soon",
+			"account", p.accountID,
+			"end", state.Reservation.EndTime.UTC().Format(time.RFC3339))
+	}
+}
+
+func valueOrZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
@@ -0,0 +1,138 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+type staticFetcher struct {
+	snapshot Snapshot
+}
+
+func (f *staticFetcher) FetchPaymentState(context.Context) (Snapshot, error) {
+	return f.snapshot, nil
+}
+
+// balanceMetricer records the values of the last payments metrics calls.
+type balanceMetricer struct {
+	metrics.Metricer
+	usage, capacity                      uint64
+	remainingWei, secondsUntilExhaustion float64
+}
+
+func (m *balanceMetricer) RecordReservationUsage(usedSymbols uint64, capacitySymbols uint64) {
+	m.usage, m.capacity = usedSymbols, capacitySymbols
+}
+
+func (m *balanceMetricer) RecordOnDemandBalance(remainingWei float64, secondsUntilExhaustion float64) {
+	m.remainingWei, m.secondsUntilExhaustion = remainingWei, secondsUntilExhaustion
+}
+
+func TestPoller_ReservationUsage(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	fetcher := &staticFetcher{snapshot: Snapshot{
+		ReservationSymbolsPerSecond: 100,
+		ReservationStart:            now.Add(-time.Hour),
+		ReservationEnd:              now.Add(time.Hour),
+		ReservationWindow:           300 * time.Second,
+		// 1_000_000 / 300 = 3333
+		PeriodUsage: map[uint64]uint64{3332: 29_000, 3333: 1_500},
+	}}
+	m := &balanceMetricer{Metricer: metrics.NoopMetrics}
+	tracker := NewTracker()
+	poller := NewPoller(testLogger, m, Config{PollInterval: time.Minute}, "0xabc", fetcher, tracker)
+	poller.now = func() time.Time { return now }
+
+	require.NoError(t, poller.poll(context.Background()))
+
+	state, ok := tracker.Latest()
+	require.True(t, ok)
+	require.NotNil(t, state.Reservation)
+	require.True(t, state.Reservation.Active)
+	require.Equal(t, uint64(3333), state.Reservation.PeriodIndex)
+	require.Equal(t, uint64(1_500), state.Reservation.PeriodUsageSymbols)
+	require.Equal(t, uint64(30_000), state.Reservation.PeriodCapacitySymbols)
+	require.Equal(t, uint64(1_500), m.usage)
+	require.Equal(t, uint64(30_000), m.capacity)
+	require.Equal(t, -1.0, m.secondsUntilExhaustion, "no on-demand spending means no projection")
+}
+
+func TestPoller_ProjectsOnDemandExhaustion(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	fetcher := &staticFetcher{snapshot: Snapshot{
+		OnchainDepositWei:    big.NewInt(10_000),
+		CumulativePaymentWei: big.NewInt(1_000),
+	}}
+	m := &balanceMetricer{Metricer: metrics.NoopMetrics}
+	tracker := NewTracker()
+	poller := NewPoller(testLogger, m, Config{PollInterval: time.Minute}, "0xabc", fetcher, tracker)
+	poller.now = func() time.Time { return now }
+
+	// a single sample isn't enough to determine a spend rate
+	require.NoError(t, poller.poll(context.Background()))
+	state, ok := tracker.Latest()
+	require.True(t, ok)
+	require.Equal(t, "9000", state.OnDemand.RemainingWei)
+	require.Nil(t, state.OnDemand.ProjectedExhaustion)
+	require.Equal(t, 9000.0, m.remainingWei)
+
+	// spending 600 wei per minute leaves 8400 wei, i.e. 14 more minutes
+	now = now.Add(time.Minute)
+	fetcher.snapshot.CumulativePaymentWei = big.NewInt(1_600)
+	require.NoError(t, poller.poll(context.Background()))
+	state, ok = tracker.Latest()
+	require.True(t, ok)
+	require.Equal(t, "8400", state.OnDemand.RemainingWei)
+	require.Equal(t, 10.0, state.OnDemand.SpendRateWeiPerSecond)
+	require.NotNil(t, state.OnDemand.ProjectedExhaustion)
+	require.Equal(t, now.Add(14*time.Minute), *state.OnDemand.ProjectedExhaustion)
+	require.Equal(t, 840.0, m.secondsUntilExhaustion)
+
+	// samples older than the spend rate window are discarded, so the projection follows the recent spend rate
+	now = now.Add(2 * spendRateWindow)
+	require.NoError(t, poller.poll(context.Background()))
+	now = now.Add(time.Minute)
+	require.NoError(t, poller.poll(context.Background()))
+	state, ok = tracker.Latest()
+	require.True(t, ok)
+	require.Nil(t, state.OnDemand.ProjectedExhaustion, "no spending over the last window")
+	require.Equal(t, -1.0, m.secondsUntilExhaustion)
+}
+
+func TestHandlersHTTP_GetPayments(t *testing.T) {
+	tracker := NewTracker()
+	r := mux.NewRouter()
+	NewHandlerHTTP(testLogger, tracker).RegisterPaymentsHandlers(r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/payments", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code, "no state was polled yet")
+
+	expected := State{
+		AccountID: "0xabc",
+		UpdatedAt: time.Unix(1_000_000, 0).UTC(),
+		OnDemand:  OnDemandState{DepositWei: "10", CumulativePaymentWei: "4", RemainingWei: "6"},
+	}
+	tracker.set(expected)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/payments", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var state State
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	require.Equal(t, expected, state)
+}
@@ -0,0 +1,78 @@
+package payments
+
+import (
+	"sync"
+	"time"
+)
+
+// State ... is the payment state of the signer account, as last observed by the [Poller].
+// Wei amounts are decimal strings, since they can overflow JSON numbers.
+type State struct {
+	AccountID string    `json:"account_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Reservation is nil when the account has no reservation.
+	Reservation *ReservationState `json:"reservation,omitempty"`
+	OnDemand    OnDemandState     `json:"on_demand"`
+}
+
+// ReservationState ... describes the reservation of the account and its usage during the current period.
+type ReservationState struct {
+	SymbolsPerSecond uint64    `json:"symbols_per_second"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+	// Active is true when the current time is within [StartTime, EndTime).
+	Active bool `json:"active"`
+	// PeriodIndex is the index of the current reservation period.
+	PeriodIndex           uint64 `json:"period_index"`
+	PeriodUsageSymbols    uint64 `json:"period_usage_symbols"`
+	PeriodCapacitySymbols uint64 `json:"period_capacity_symbols"`
+}
+
+// OnDemandState ... describes the on-demand deposit of the account and its projected exhaustion.
+type OnDemandState struct {
+	// DepositWei is the total amount ever deposited for on-demand payments in the payment vault.
+	DepositWei string `json:"deposit_wei"`
+	// CumulativePaymentWei is the total amount ever spent on on-demand dispersals.
+	CumulativePaymentWei string `json:"cumulative_payment_wei"`
+	RemainingWei         string `json:"remaining_wei"`
+	// SpendRateWeiPerSecond is the average spend rate over the last polls. 0 when unknown or not spending.
+	SpendRateWeiPerSecond float64 `json:"spend_rate_wei_per_second"`
+	// ProjectedExhaustion is when the deposit runs out at the current spend rate. nil when not spending.
+	ProjectedExhaustion *time.Time `json:"projected_exhaustion,omitempty"`
+}
+
+// Tracker ... holds the latest payment state of the signer account.
+// It is written to by the [Poller] and read from by the /admin/payments handler.
+// A nil *Tracker is valid: it never holds a state.
+type Tracker struct {
+	mu    sync.RWMutex
+	state *State
+}
+
+// NewTracker ... constructor
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+func (t *Tracker) set(state State) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state = &state
+}
+
+// Latest returns the latest payment state, or false if none was observed yet
+// (polling is disabled, hasn't succeeded yet, or there is no EigenDA V2 disperser).
+func (t *Tracker) Latest() (State, bool) {
+	if t == nil {
+		return State{}, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.state == nil {
+		return State{}, false
+	}
+	return *t.state, true
+}
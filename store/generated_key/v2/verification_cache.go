@@ -0,0 +1,128 @@
+package eigenda
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// certVerificationKey identifies the result of a CheckDACert eth-call. The verifier address is part of the key
+// so that results are never reused across cert verifiers, e.g. when the router starts pointing to a new verifier.
+type certVerificationKey struct {
+	certHash             geth_common.Hash
+	certVersion          certs.VersionByte
+	verifierAddress      geth_common.Address
+	rbnRecencyWindowSize uint64
+}
+
+// CertVerificationCache ... a bounded LRU cache of cert verification results with a TTL.
+// Only deterministic results are cached: certs that verified successfully, and certs that were
+// rejected by the cert verifier contract. Transient errors (e.g. RPC failures) are never cached.
+//
+// The cert verifier address resolved for each reference block number (RBN) is also cached,
+// since resolving it through the EigenDACertVerifierRouter requires an extra eth-call.
+type CertVerificationCache struct {
+	results           *ttlCache[certVerificationKey, error]
+	verifierAddrByRBN *ttlCache[uint64, geth_common.Address]
+}
+
+// NewCertVerificationCache ... constructor. Returns nil (disabling caching) if size is 0.
+func NewCertVerificationCache(size int, ttl time.Duration) *CertVerificationCache {
+	if size <= 0 {
+		return nil
+	}
+	return &CertVerificationCache{
+		results:           newTTLCache[certVerificationKey, error](size, ttl),
+		verifierAddrByRBN: newTTLCache[uint64, geth_common.Address](size, ttl),
+	}
+}
+
+func newCertVerificationKey(
+	versionedCert certs.VersionedCert, verifierAddress geth_common.Address, rbnRecencyWindowSize uint64,
+) certVerificationKey {
+	return certVerificationKey{
+		certHash:             crypto.Keccak256Hash(versionedCert.SerializedCert),
+		certVersion:          versionedCert.Version,
+		verifierAddress:      verifierAddress,
+		rbnRecencyWindowSize: rbnRecencyWindowSize,
+	}
+}
+
+// ttlCache ... a minimal thread-safe LRU cache whose entries expire after a fixed TTL.
+// A TTL of 0 means entries never expire.
+type ttlCache[K comparable, V any] struct {
+	size int
+	ttl  time.Duration
+
+	mu sync.Mutex
+	// order holds *ttlEntry values, the front being the least recently used entry
+	order   *list.List
+	entries map[K]*list.Element
+}
+
+type ttlEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func newTTLCache[K comparable, V any](size int, ttl time.Duration) *ttlCache[K, V] {
+	return &ttlCache[K, V]{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+	}
+}
+
+func (c *ttlCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := c.entryOf(elem)
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return zero, false
+	}
+	c.order.MoveToBack(elem)
+	return entry.value, true
+}
+
+func (c *ttlCache[K, V]) add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &ttlEntry[K, V]{key: key, value: value, expiresAt: time.Now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToBack(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushBack(entry)
+	for c.order.Len() > c.size {
+		oldest := c.entryOf(c.order.Front())
+		c.order.Remove(c.order.Front())
+		delete(c.entries, oldest.key)
+	}
+}
+
+// entryOf ... returns the entry held by an element of the order list, which only holds *ttlEntry values.
+func (c *ttlCache[K, V]) entryOf(elem *list.Element) *ttlEntry[K, V] {
+	entry, ok := elem.Value.(*ttlEntry[K, V])
+	if !ok {
+		panic(fmt.Sprintf("ttl cache list holds a %T instead of a *ttlEntry, this is a bug", elem.Value))
+	}
+	return entry
+}
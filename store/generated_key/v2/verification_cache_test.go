@@ -0,0 +1,62 @@
+package eigenda
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCertVerificationCacheDisabled(t *testing.T) {
+	require.Nil(t, NewCertVerificationCache(0, time.Hour))
+}
+
+func TestTTLCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newTTLCache[int, string](2, 0)
+	cache.add(1, "one")
+	cache.add(2, "two")
+
+	// reading 1 makes 2 the least recently used entry
+	_, ok := cache.get(1)
+	require.True(t, ok)
+	cache.add(3, "three")
+
+	_, ok = cache.get(2)
+	require.False(t, ok)
+	value, ok := cache.get(1)
+	require.True(t, ok)
+	require.Equal(t, "one", value)
+	value, ok = cache.get(3)
+	require.True(t, ok)
+	require.Equal(t, "three", value)
+}
+
+func TestTTLCacheExpiration(t *testing.T) {
+	cache := newTTLCache[int, error](10, 10*time.Millisecond)
+	cachedErr := errors.New("invalid cert")
+	cache.add(1, cachedErr)
+
+	value, ok := cache.get(1)
+	require.True(t, ok)
+	require.Equal(t, cachedErr, value)
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = cache.get(1)
+	require.False(t, ok)
+}
+
+func TestCertVerificationKey(t *testing.T) {
+	cert := certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte)
+	verifierA := geth_common.HexToAddress("0xa")
+	verifierB := geth_common.HexToAddress("0xb")
+
+	require.Equal(t, newCertVerificationKey(cert, verifierA, 100), newCertVerificationKey(cert, verifierA, 100))
+	// results must not be shared across cert verifiers, RBN recency windows or cert versions
+	require.NotEqual(t, newCertVerificationKey(cert, verifierA, 100), newCertVerificationKey(cert, verifierB, 100))
+	require.NotEqual(t, newCertVerificationKey(cert, verifierA, 100), newCertVerificationKey(cert, verifierA, 0))
+	otherVersion := certs.NewVersionedCert(cert.SerializedCert, certs.V1VersionByte)
+	require.NotEqual(t, newCertVerificationKey(cert, verifierA, 100), newCertVerificationKey(otherVersion, verifierA, 100))
+}
@@ -9,9 +9,13 @@ import (
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 //go:generate mockgen -package mocks --destination ../test/mocks/manager.go . IManager
@@ -65,6 +69,11 @@ func (m *Manager) SetDispersalBackend(backend common.EigenDABackend) {
 	m.dispersalBackend.Store(backend)
 }
 
+// logger returns the manager's logger, annotated with the request ID carried by ctx
+func (m *Manager) logger(ctx context.Context) logging.Logger {
+	return proxy_logging.FromContext(ctx, m.log)
+}
+
 // NewManager ... Init
 func NewManager(
 	eigenda common.EigenDAV1Store,
@@ -100,6 +109,20 @@ func (m *Manager) Get(ctx context.Context,
 	versionedCert certs.VersionedCert,
 	cm commitments.CommitmentMode,
 	verifyOpts common.CertVerificationOpts,
+) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "Manager.Get",
+		attribute.String("commitment_mode", string(cm)),
+		attribute.Int("cert_version", int(versionedCert.Version)),
+	)
+	data, err := m.get(ctx, versionedCert, cm, verifyOpts)
+	tracing.End(span, err)
+	return data, err
+}
+
+func (m *Manager) get(ctx context.Context,
+	versionedCert certs.VersionedCert,
+	cm commitments.CommitmentMode,
+	verifyOpts common.CertVerificationOpts,
 ) ([]byte, error) {
 	switch cm {
 	case commitments.StandardCommitmentMode, commitments.OptimismGenericCommitmentMode:
@@ -117,13 +140,13 @@ func (m *Manager) Get(ctx context.Context,
 
 		// 1 - read blob from cache if enabled
 		if m.secondary.CachingEnabled() {
-			m.log.Debug("Retrieving data from cached backends")
+			m.logger(ctx).Debug("Retrieving data from cached backends")
 			data, err := m.secondary.MultiSourceRead(ctx, versionedCert.SerializedCert, false, verifyMethod, verifyOpts)
 			if err == nil {
 				return data, nil
 			}
 
-			m.log.Warn("Failed to read from cache targets", "err", err)
+			m.logger(ctx).Warn("Failed to read from cache targets", "err", err)
 		}
 
 		// 2 - read blob from EigenDA
@@ -140,7 +163,7 @@ func (m *Manager) Get(ctx context.Context,
 		if m.secondary.FallbackEnabled() {
 			data, err = m.secondary.MultiSourceRead(ctx, versionedCert.SerializedCert, true, verifyMethod, verifyOpts)
 			if err != nil {
-				m.log.Error("Failed to read from fallback targets", "err", err)
+				m.logger(ctx).Error("Failed to read from fallback targets", "err", err)
 				return nil, err
 			}
 		} else {
@@ -157,6 +180,16 @@ func (m *Manager) Get(ctx context.Context,
 
 // Put ... inserts a value into a storage backend based on the commitment mode
 func (m *Manager) Put(ctx context.Context, cm commitments.CommitmentMode, value []byte) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "Manager.Put",
+		attribute.String("commitment_mode", string(cm)),
+		attribute.Int("payload_size", len(value)),
+	)
+	commit, err := m.put(ctx, cm, value)
+	tracing.End(span, err)
+	return commit, err
+}
+
+func (m *Manager) put(ctx context.Context, cm commitments.CommitmentMode, value []byte) ([]byte, error) {
 	var commit []byte
 	var err error
 
@@ -184,17 +217,19 @@ func (m *Manager) Put(ctx context.Context, cm commitments.CommitmentMode, value
 
 func (m *Manager) backupToSecondary(ctx context.Context, commitment []byte, value []byte) {
 	if m.secondary.AsyncWriteEntry() { // publish put notification to secondary's subscription on PutNotify topic
-		m.log.Debug("Publishing data to async secondary stores")
+		m.logger(ctx).Debug("Publishing data to async secondary stores")
 		m.secondary.Topic() <- secondary.PutNotify{
 			Commitment: commitment,
 			Value:      value,
+			RequestID:  proxy_logging.RequestIDFromContext(ctx),
+			Tenant:     tenant.FromContext(ctx),
 		}
 		// secondary is available only for synchronous writes
 	} else {
-		m.log.Debug("Publishing data to single threaded secondary stores")
+		m.logger(ctx).Debug("Publishing data to single threaded secondary stores")
 		err := m.secondary.HandleRedundantWrites(ctx, commitment, value)
 		if err != nil {
-			m.log.Error("Secondary insertions failed", "error", err.Error())
+			m.logger(ctx).Error("Secondary insertions failed", "error", err.Error())
 		}
 	}
 }
@@ -250,7 +285,7 @@ func (m *Manager) getFromCorrectEigenDABackend(
 ) ([]byte, error) {
 	switch versionedCert.Version {
 	case certs.V0VersionByte:
-		m.log.Debug("Reading blob from EigenDAV1 backend")
+		m.logger(ctx).Debug("Reading blob from EigenDAV1 backend")
 		data, err := m.eigenda.Get(ctx, versionedCert.SerializedCert)
 		if err == nil {
 			// verify v1 (payload, cert)
@@ -272,7 +307,7 @@ func (m *Manager) getFromCorrectEigenDABackend(
 			return nil, fmt.Errorf("verify EigenDACert: %w", err)
 		}
 
-		m.log.Debug("Reading blob from EigenDAV2 backend")
+		m.logger(ctx).Debug("Reading blob from EigenDAV2 backend")
 		data, err := m.eigendaV2.Get(ctx, versionedCert)
 		if err != nil {
 			return nil, fmt.Errorf("get data from V2 backend: %w", err)
@@ -288,6 +323,13 @@ func (m *Manager) getFromCorrectEigenDABackend(
 // If key!=keccak(value), a Keccak256KeyValueMismatchError is returned.
 // This is only used for OP keccak256 commitments.
 func (m *Manager) PutOPKeccakPairInS3(ctx context.Context, key []byte, value []byte) error {
+	ctx, span := tracing.Start(ctx, "Manager.PutOPKeccakPairInS3", attribute.Int("payload_size", len(value)))
+	err := m.putOPKeccakPairInS3(ctx, key, value)
+	tracing.End(span, err)
+	return err
+}
+
+func (m *Manager) putOPKeccakPairInS3(ctx context.Context, key []byte, value []byte) error {
 	if m.s3 == nil {
 		return errors.New("S3 is disabled but is only supported for posting known commitment keys")
 	}
@@ -306,12 +348,19 @@ func (m *Manager) PutOPKeccakPairInS3(ctx context.Context, key []byte, value []b
 // It verifies that the key=keccak(value) and returns an error if they don't match.
 // Otherwise returns the value and nil.
 func (m *Manager) GetOPKeccakValueFromS3(ctx context.Context, key []byte) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "Manager.GetOPKeccakValueFromS3")
+	value, err := m.getOPKeccakValueFromS3(ctx, key)
+	tracing.End(span, err)
+	return value, err
+}
+
+func (m *Manager) getOPKeccakValueFromS3(ctx context.Context, key []byte) ([]byte, error) {
 	if m.s3 == nil {
 		return nil, errors.New("expected S3 backend for OP keccak256 commitment type, but none configured")
 	}
 
 	// 1 - read blob from S3 backend
-	m.log.Debug("Retrieving data from S3 backend")
+	m.logger(ctx).Debug("Retrieving data from S3 backend")
 	value, err := m.s3.Get(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("s3 get: %w", err)
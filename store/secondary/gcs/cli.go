@@ -0,0 +1,79 @@
+package gcs
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	CredentialTypeFlagName  = withFlagPrefix("credential-type")
+	CredentialsFileFlagName = withFlagPrefix("credentials-file") // #nosec G101
+	CredentialsJSONFlagName = withFlagPrefix("credentials-json") // #nosec G101
+	EndpointFlagName        = withFlagPrefix("endpoint")
+	BucketFlagName          = withFlagPrefix("bucket")
+	PathFlagName            = withFlagPrefix("path")
+)
+
+func withFlagPrefix(s string) string {
+	return "gcs." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_GCS_" + s}
+}
+
+// CLIFlags ... used for Google Cloud Storage backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: CredentialTypeFlagName,
+			Usage: "the way to authenticate to GCS, options are [service-account, default, none]. " +
+				"default uses the application default credentials (e.g. workload identity)",
+			Value:    string(CredentialTypeDefault),
+			EnvVars:  withEnvPrefix(envPrefix, "CREDENTIAL_TYPE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     CredentialsFileFlagName,
+			Usage:    "path to the JSON key of the service account, for the service-account credential type",
+			EnvVars:  withEnvPrefix(envPrefix, "CREDENTIALS_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: CredentialsJSONFlagName,
+			Usage: "JSON key of the service account, for the service-account credential type when no " +
+				"credentials file is set",
+			EnvVars:  withEnvPrefix(envPrefix, "CREDENTIALS_JSON"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "endpoint overriding the GCS one, e.g. http://localhost:4443/storage/v1/ for a local emulator",
+			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     BucketFlagName,
+			Usage:    "bucket name for GCS storage",
+			EnvVars:  withEnvPrefix(envPrefix, "BUCKET"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     PathFlagName,
+			Usage:    "path for GCS storage",
+			EnvVars:  withEnvPrefix(envPrefix, "PATH"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		CredentialType:  StringToCredentialType(ctx.String(CredentialTypeFlagName)),
+		CredentialsFile: ctx.String(CredentialsFileFlagName),
+		CredentialsJSON: ctx.String(CredentialsJSONFlagName),
+		Endpoint:        ctx.String(EndpointFlagName),
+		Bucket:          ctx.String(BucketFlagName),
+		Path:            ctx.String(PathFlagName),
+	}
+}
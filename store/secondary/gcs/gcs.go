@@ -0,0 +1,185 @@
+package gcs
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const (
+	// CredentialTypeServiceAccount authenticates with a service account key, read from a JSON file or passed inline
+	CredentialTypeServiceAccount CredentialType = "service-account"
+	// CredentialTypeDefault uses the application default credentials, e.g. GKE workload identity or the metadata
+	// server of the instance
+	CredentialTypeDefault CredentialType = "default"
+	// CredentialTypeNone doesn't authenticate, for public buckets and local emulators
+	CredentialTypeNone    CredentialType = "none"
+	CredentialTypeUnknown CredentialType = "unknown"
+)
+
+func StringToCredentialType(s string) CredentialType {
+	switch s {
+	case "service-account":
+		return CredentialTypeServiceAccount
+	case "default":
+		return CredentialTypeDefault
+	case "none":
+		return CredentialTypeNone
+	default:
+		return CredentialTypeUnknown
+	}
+}
+
+var _ common.ListableSecondaryStore = (*Store)(nil)
+
+type CredentialType string
+type Config struct {
+	CredentialType CredentialType
+	// CredentialsFile is the path to the JSON key of the service account
+	CredentialsFile string
+	// CredentialsJSON is the JSON key of the service account, used when no CredentialsFile is set
+	CredentialsJSON string
+	// Endpoint overrides the GCS endpoint, e.g. to point at a local emulator. Empty uses the default endpoint.
+	Endpoint string
+	Bucket   string
+	Path     string
+}
+
+// Custom MarshalJSON function to control what gets included in the JSON output
+func (c Config) MarshalJSON() ([]byte, error) {
+	type Alias Config // Use an alias to avoid recursion with MarshalJSON
+	aux := (Alias)(c)
+	// Conditionally include masked credentials if they are set
+	if aux.CredentialsJSON != "" {
+		aux.CredentialsJSON = "*****"
+	}
+	return json.Marshal(aux)
+}
+
+// Store ... Google Cloud Storage store, using the native GCS API rather than its S3 interoperability API
+// client safe for concurrent use: https://pkg.go.dev/cloud.google.com/go/storage#Client
+type Store struct {
+	cfg    Config
+	client *storage.Client
+}
+
+func NewStore(ctx context.Context, cfg Config) (*Store, error) {
+	client, err := storage.NewClient(ctx, clientOptions(cfg)...)
+	if err != nil {
+		return nil, fmt.Errorf("create GCS client: %w", err)
+	}
+
+	return &Store{
+		cfg:    cfg,
+		client: client,
+	}, nil
+}
+
+// Get ... retrieves a value from the bucket. Returns nil if the object doesn't exist vs. an error
+// if it exists but isn't retrievable.
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	reader, err := s.object(key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("GCS Get: %w", err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("GCS Get: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	writer := s.object(key).NewWriter(ctx)
+	// the payload is written in a single request, rather than with a resumable upload
+	writer.ChunkSize = 0
+	if _, err := writer.Write(value); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("GCS Put: %w", err)
+	}
+	// the object is only committed once the writer is closed
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("GCS Put: %w", err)
+	}
+	return nil
+}
+
+// ForEachKey lists the objects under the path of the bucket. Objects whose name isn't a hex encoded key weren't
+// written by the proxy, and are skipped.
+func (s *Store) ForEachKey(ctx context.Context, fn func(key []byte) error) error {
+	prefix := ""
+	if s.cfg.Path != "" {
+		prefix = strings.TrimSuffix(s.cfg.Path, "/") + "/"
+	}
+	query := &storage.Query{Prefix: prefix}
+	if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+		return fmt.Errorf("GCS list objects: %w", err)
+	}
+	objects := s.client.Bucket(s.cfg.Bucket).Objects(ctx, query)
+	for {
+		object, err := objects.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("GCS list objects: %w", err)
+		}
+		key, err := hex.DecodeString(strings.TrimPrefix(object.Name, prefix))
+		if err != nil {
+			continue
+		}
+		if err = fn(key); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Store) Verify(_ context.Context, _, _ []byte) error {
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.GCSBackendType
+}
+
+// object returns the handle of the object of key, named the same way as by the S3 store so that a bucket written
+// through the S3 interoperability API can be read natively
+func (s *Store) object(key []byte) *storage.ObjectHandle {
+	return s.client.Bucket(s.cfg.Bucket).Object(path.Join(s.cfg.Path, hex.EncodeToString(key)))
+}
+
+func clientOptions(cfg Config) []option.ClientOption {
+	var opts []option.ClientOption
+	switch cfg.CredentialType {
+	case CredentialTypeServiceAccount:
+		if cfg.CredentialsFile != "" {
+			opts = append(opts, option.WithCredentialsFile(cfg.CredentialsFile))
+		} else {
+			opts = append(opts, option.WithCredentialsJSON([]byte(cfg.CredentialsJSON)))
+		}
+	case CredentialTypeNone:
+		opts = append(opts, option.WithoutAuthentication())
+	case CredentialTypeDefault, CredentialTypeUnknown:
+		// the client falls back to the application default credentials
+	}
+	if cfg.Endpoint != "" {
+		// objects are read through the XML API of the GCS host by default, which emulators don't serve. Reading
+		// through the JSON API keeps every request on the endpoint.
+		opts = append(opts, option.WithEndpoint(cfg.Endpoint), storage.WithJSONReads())
+	}
+	return opts
+}
@@ -0,0 +1,38 @@
+package gcs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringToCredentialType(t *testing.T) {
+	require.Equal(t, CredentialTypeServiceAccount, StringToCredentialType("service-account"))
+	require.Equal(t, CredentialTypeDefault, StringToCredentialType("default"))
+	require.Equal(t, CredentialTypeNone, StringToCredentialType("none"))
+	require.Equal(t, CredentialTypeUnknown, StringToCredentialType("static"))
+	require.Equal(t, CredentialTypeUnknown, StringToCredentialType(""))
+}
+
+func TestConfigMarshalJSONMasksCredentials(t *testing.T) {
+	cfg := Config{
+		CredentialType:  CredentialTypeServiceAccount,
+		CredentialsJSON: `{"type": "service_account", "private_key": "secret"}`,
+		Bucket:          "test-bucket",
+	}
+
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret")
+	require.Contains(t, string(data), "test-bucket")
+}
+
+func TestClientOptions(t *testing.T) {
+	require.Empty(t, clientOptions(Config{CredentialType: CredentialTypeDefault}))
+	require.Len(t, clientOptions(Config{CredentialType: CredentialTypeNone}), 1)
+	require.Len(t, clientOptions(Config{CredentialType: CredentialTypeServiceAccount, CredentialsFile: "key.json"}), 1)
+	// the endpoint of an emulator is also used to read objects
+	emulator := Config{CredentialType: CredentialTypeNone, Endpoint: "http://localhost:4443/storage/v1/"}
+	require.Len(t, clientOptions(emulator), 3)
+}
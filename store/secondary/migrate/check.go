@@ -0,0 +1,294 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/store/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// maxReportedProblems bounds the number of problems listed in a report, which would otherwise be as large as the
+// backends when checking an empty target
+const maxReportedProblems = 10000
+
+// PayloadVerifier checks that a payload is the one committed to by a cert, the same way as the proxy does on
+// retrieval
+type PayloadVerifier interface {
+	VerifyPayload(versionedCert certs.VersionedCert, payload []byte) error
+}
+
+// ProblemKind is the kind of inconsistency found by a check
+type ProblemKind string
+
+const (
+	// Missing entries are held by the other backend, or committed to by a commitment, but not by this backend
+	Missing ProblemKind = "missing"
+	// Corrupted entries don't match the cert of their commitment
+	Corrupted ProblemKind = "corrupted"
+	// Mismatched entries have different values in the two backends. Which one is corrupted is only known by
+	// checking the commitment of the entry.
+	Mismatched ProblemKind = "mismatched"
+	// Unreadable entries failed to be read
+	Unreadable ProblemKind = "unreadable"
+)
+
+// CheckReport summarizes a check
+type CheckReport struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Commitments is the source of the commitments checked, and is empty when the keys of the backends were listed
+	Commitments string  `json:"commitments,omitempty"`
+	SampleRate  float64 `json:"sample_rate"`
+
+	// Checked is the number of entries (or commitments) checked, which are Consistent or have at least one problem
+	Checked    int `json:"checked"`
+	Consistent int `json:"consistent"`
+	Missing    int `json:"missing"`
+	Corrupted  int `json:"corrupted"`
+	Mismatched int `json:"mismatched"`
+	Unreadable int `json:"unreadable"`
+	// Problems lists the first maxReportedProblems problems found
+	Problems          []Problem `json:"problems,omitempty"`
+	ProblemsTruncated bool      `json:"problems_truncated,omitempty"`
+	Duration          string    `json:"duration"`
+}
+
+// Problem is an inconsistency of an entry
+type Problem struct {
+	Kind ProblemKind `json:"kind"`
+	// Backend is the backend the problem was found in. It is empty for mismatches.
+	Backend string `json:"backend,omitempty"`
+	// Key is hex encoded
+	Key string `json:"key"`
+	// Commitment and Position identify the commitment of the entry, when checking commitments
+	Commitment string `json:"commitment,omitempty"`
+	Position   uint64 `json:"position,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Checker checks that the entries of two secondary storage backends are consistent
+type Checker struct {
+	log    logging.Logger
+	source common.ListableSecondaryStore
+	target common.ListableSecondaryStore
+	cfg    Config
+}
+
+func NewChecker(
+	log logging.Logger, source common.ListableSecondaryStore, target common.ListableSecondaryStore, cfg Config,
+) *Checker {
+	return &Checker{
+		log:    log,
+		source: source,
+		target: target,
+		cfg:    cfg,
+	}
+}
+
+// CheckKeys lists the keys of both backends, and reports the entries missing from either of them, as well as those
+// whose values differ. A sample of the keys is checked when the sample rate is below 1.
+// Payloads can't be verified, as the keys of entries are hashes of their certs. See [Checker.CheckCommitments].
+func (c *Checker) CheckKeys(ctx context.Context) (*CheckReport, error) {
+	start := time.Now()
+	report := c.newReport()
+	var reportLock sync.Mutex
+	checked := func(problems []Problem) {
+		reportLock.Lock()
+		defer reportLock.Unlock()
+		report.record(problems)
+		if report.Checked%progressLogInterval == 0 {
+			c.log.Info("Check progress", "checked", report.Checked, "consistent", report.Consistent)
+		}
+	}
+
+	// entries of the source, which are compared to those of the target
+	err := process(ctx, c.cfg.Concurrency, listKeys(c.source), func(ctx context.Context, key []byte) {
+		if !c.sampled() {
+			return
+		}
+		problems, ok := c.compare(ctx, key)
+		if ok && ctx.Err() == nil {
+			checked(problems)
+		}
+	})
+	if err != nil {
+		report.Duration = time.Since(start).String()
+		return report, fmt.Errorf("check source entries: %w", err)
+	}
+
+	// entries of the target, which were already compared when the source holds them as well
+	err = process(ctx, c.cfg.Concurrency, listKeys(c.target), func(ctx context.Context, key []byte) {
+		if !c.sampled() {
+			return
+		}
+		value, readErr := get(ctx, c.source, key)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case readErr != nil:
+			checked([]Problem{c.problem(Unreadable, c.source, key, readErr)})
+		case value == nil:
+			checked([]Problem{c.problem(Missing, c.source, key, nil)})
+		}
+	})
+	report.Duration = time.Since(start).String()
+	if err != nil {
+		return report, fmt.Errorf("check target entries: %w", err)
+	}
+	return report, nil
+}
+
+// compare compares the entries of key in both backends. It returns false when the source no longer holds the entry
+// (e.g. it was evicted since its key was listed), in which case it isn't checked.
+func (c *Checker) compare(ctx context.Context, key []byte) ([]Problem, bool) {
+	sourceValue, err := get(ctx, c.source, key)
+	if err != nil {
+		return []Problem{c.problem(Unreadable, c.source, key, err)}, true
+	}
+	if sourceValue == nil {
+		return nil, false
+	}
+	targetValue, err := get(ctx, c.target, key)
+	switch {
+	case err != nil:
+		return []Problem{c.problem(Unreadable, c.target, key, err)}, true
+	case targetValue == nil:
+		return []Problem{c.problem(Missing, c.target, key, nil)}, true
+	case !bytes.Equal(sourceValue, targetValue):
+		return []Problem{{Kind: Mismatched, Key: hex.EncodeToString(key)}}, true
+	}
+	return nil, true
+}
+
+// CheckCommitments looks up the entries of the commitments of source in both backends, and verifies their payloads
+// against the certs of the commitments. A sample of the commitments is checked when the sample rate is below 1.
+// Entries are looked up under the secondary prefix of the tenant of ctx, if any.
+func (c *Checker) CheckCommitments(
+	ctx context.Context, source backfill.Source, verifier PayloadVerifier,
+) (*CheckReport, error) {
+	start := time.Now()
+	report := c.newReport()
+	report.Commitments = source.Name()
+	produce := func(ctx context.Context, out chan<- backfill.Commitment) error {
+		return source.Commitments(ctx, 0, out)
+	}
+
+	var reportLock sync.Mutex
+	err := process(ctx, c.cfg.Concurrency, produce, func(ctx context.Context, commitment backfill.Commitment) {
+		if !c.sampled() {
+			return
+		}
+		key := secondary.EntryKey(ctx, commitment.VersionedCert.SerializedCert)
+		var problems []Problem
+		for _, store := range []common.SecondaryStore{c.source, c.target} {
+			if problem := c.verifyEntry(ctx, store, key, commitment, verifier); problem != nil {
+				problems = append(problems, *problem)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		reportLock.Lock()
+		defer reportLock.Unlock()
+		report.record(problems)
+		if report.Checked%progressLogInterval == 0 {
+			c.log.Info("Check progress", "checked", report.Checked, "consistent", report.Consistent,
+				"position", commitment.Position)
+		}
+	})
+	report.Duration = time.Since(start).String()
+	if err != nil {
+		return report, fmt.Errorf("check commitments: %w", err)
+	}
+	return report, nil
+}
+
+// verifyEntry checks that store holds the payload of commitment under key, and returns the problem found if any
+func (c *Checker) verifyEntry(
+	ctx context.Context,
+	store common.SecondaryStore,
+	key []byte,
+	commitment backfill.Commitment,
+	verifier PayloadVerifier,
+) *Problem {
+	var problem Problem
+	payload, err := get(ctx, store, key)
+	switch {
+	case err != nil:
+		problem = c.problem(Unreadable, store, key, err)
+	case payload == nil:
+		problem = c.problem(Missing, store, key, nil)
+	default:
+		if err = verifier.VerifyPayload(commitment.VersionedCert, payload); err == nil {
+			return nil
+		}
+		problem = c.problem(Corrupted, store, key, err)
+	}
+	problem.Commitment = commitment.Encode()
+	problem.Position = commitment.Position
+	return &problem
+}
+
+// sampled returns whether the next entry is checked
+func (c *Checker) sampled() bool {
+	// #nosec G404 - sampling doesn't need a secure source of randomness
+	return c.cfg.SampleRate >= 1 || rand.Float64() < c.cfg.SampleRate
+}
+
+func (c *Checker) problem(kind ProblemKind, store common.SecondaryStore, key []byte, err error) Problem {
+	problem := Problem{Kind: kind, Backend: store.BackendType().String(), Key: hex.EncodeToString(key)}
+	if err != nil {
+		problem.Error = err.Error()
+	}
+	return problem
+}
+
+func (c *Checker) newReport() *CheckReport {
+	return &CheckReport{
+		Source:     c.source.BackendType().String(),
+		Target:     c.target.BackendType().String(),
+		SampleRate: c.cfg.SampleRate,
+	}
+}
+
+// Inconsistencies returns the number of problems found
+func (r *CheckReport) Inconsistencies() int {
+	return r.Missing + r.Corrupted + r.Mismatched + r.Unreadable
+}
+
+// record adds the problems found when checking an entry, which is consistent if there are none
+func (r *CheckReport) record(problems []Problem) {
+	r.Checked++
+	if len(problems) == 0 {
+		r.Consistent++
+		return
+	}
+	for _, problem := range problems {
+		switch problem.Kind {
+		case Missing:
+			r.Missing++
+		case Corrupted:
+			r.Corrupted++
+		case Mismatched:
+			r.Mismatched++
+		case Unreadable:
+			r.Unreadable++
+		}
+		if len(r.Problems) < maxReportedProblems {
+			r.Problems = append(r.Problems, problem)
+		} else {
+			r.ProblemsTruncated = true
+		}
+	}
+}
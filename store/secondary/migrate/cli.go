@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	SourceFlagName          = withFlagPrefix("source")
+	TargetFlagName          = withFlagPrefix("target")
+	ConcurrencyFlagName     = withFlagPrefix("concurrency")
+	OverwriteFlagName       = withFlagPrefix("overwrite")
+	SampleRateFlagName      = withFlagPrefix("sample-rate")
+	CommitmentsFileFlagName = withFlagPrefix("commitments-file")
+	CommitmentModeFlagName  = withFlagPrefix("commitment-mode")
+	ReportFileFlagName      = withFlagPrefix("report-file")
+	TenantFlagName          = withFlagPrefix("tenant")
+)
+
+func withFlagPrefix(s string) string {
+	return "migrate." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_MIGRATE_" + s}
+}
+
+// CLIFlags ... used for the secondary copy and check commands
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: SourceFlagName,
+			Usage: "Secondary storage backend entries are copied from, or checked against the target (s3, redis or gcs). " +
+				"It is configured by the same flags as the cache and fallback targets.",
+			EnvVars:  withEnvPrefix(envPrefix, "SOURCE"),
+			Category: category,
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     TargetFlagName,
+			Usage:    "Secondary storage backend entries are copied to, or checked against the source (s3, redis or gcs).",
+			EnvVars:  withEnvPrefix(envPrefix, "TARGET"),
+			Category: category,
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:     ConcurrencyFlagName,
+			Usage:    "Number of entries copied or checked concurrently.",
+			Value:    8,
+			EnvVars:  withEnvPrefix(envPrefix, "CONCURRENCY"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     OverwriteFlagName,
+			Usage:    "Copy the entries the target already holds. Only used by the copy command.",
+			EnvVars:  withEnvPrefix(envPrefix, "OVERWRITE"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name: SampleRateFlagName,
+			Usage: "Fraction of the entries checked, between 0 (exclusive) and 1. 1 checks all of them. " +
+				"Only used by the check command.",
+			Value:    1,
+			EnvVars:  withEnvPrefix(envPrefix, "SAMPLE_RATE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: CommitmentsFileFlagName,
+			Usage: "File listing the commitments whose entries are checked, one hex encoded commitment per line. " +
+				"Their payloads are verified against their certs. When empty, the keys of both backends are " +
+				"listed, and their entries compared with each other. Only used by the check command.",
+			EnvVars:  withEnvPrefix(envPrefix, "COMMITMENTS_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     CommitmentModeFlagName,
+			Usage:    "Commitment mode of the commitments file (standard or optimism_generic).",
+			Value:    string(commitments.StandardCommitmentMode),
+			EnvVars:  withEnvPrefix(envPrefix, "COMMITMENT_MODE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ReportFileFlagName,
+			Usage:    "File the JSON summary report is written to. It is printed to stdout when empty.",
+			EnvVars:  withEnvPrefix(envPrefix, "REPORT_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: TenantFlagName,
+			Usage: "Name of the tenant whose entries are checked, when checking the commitments of a commitments " +
+				"file. Entries are looked up under the secondary prefix of the tenant.",
+			EnvVars:  withEnvPrefix(envPrefix, "TENANT"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Source:          ctx.String(SourceFlagName),
+		Target:          ctx.String(TargetFlagName),
+		Concurrency:     ctx.Int(ConcurrencyFlagName),
+		Overwrite:       ctx.Bool(OverwriteFlagName),
+		SampleRate:      ctx.Float64(SampleRateFlagName),
+		CommitmentsFile: ctx.String(CommitmentsFileFlagName),
+		CommitmentMode:  commitments.CommitmentMode(ctx.String(CommitmentModeFlagName)),
+		ReportFile:      ctx.String(ReportFileFlagName),
+		Tenant:          ctx.String(TenantFlagName),
+	}
+}
@@ -0,0 +1,59 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+)
+
+type Config struct {
+	// Source and Target are the secondary storage backends (e.g. s3, redis) entries are copied from and to, or
+	// which are checked against each other
+	Source string
+	Target string
+
+	Concurrency int
+	// Overwrite copies the entries the target already holds
+	Overwrite bool
+
+	// SampleRate is the fraction of the entries checked, in (0, 1]
+	SampleRate float64
+	// CommitmentsFile lists the commitments whose entries are checked, which are of CommitmentMode. When empty, the
+	// keys of both backends are listed instead.
+	CommitmentsFile string
+	CommitmentMode  commitments.CommitmentMode
+
+	// ReportFile is where the summary report is written. It is printed to stdout when empty.
+	ReportFile string
+	// Tenant is the name of the tenant whose entries are checked, when checking the commitments of CommitmentsFile.
+	// Empty for anonymous entries.
+	Tenant string
+}
+
+// Check ... verifies that configuration values are adequately set
+func (cfg *Config) Check() error {
+	for _, backend := range []string{cfg.Source, cfg.Target} {
+		switch common.StringToBackendType(backend) {
+		case common.S3BackendType, common.RedisBackendType, common.GCSBackendType:
+		default:
+			return fmt.Errorf("%q is not a secondary storage backend whose keys can be listed", backend)
+		}
+	}
+	if common.StringToBackendType(cfg.Source) == common.StringToBackendType(cfg.Target) {
+		return fmt.Errorf("source and target are the same backend: %s", cfg.Source)
+	}
+
+	if cfg.Concurrency <= 0 {
+		return fmt.Errorf("concurrency must be positive, got %d", cfg.Concurrency)
+	}
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		return fmt.Errorf("sample rate must be in (0, 1], got %v", cfg.SampleRate)
+	}
+	if cfg.CommitmentsFile != "" && cfg.CommitmentMode != commitments.StandardCommitmentMode &&
+		cfg.CommitmentMode != commitments.OptimismGenericCommitmentMode {
+		return fmt.Errorf("commitment mode %q can't be checked, expected %s or %s", cfg.CommitmentMode,
+			commitments.StandardCommitmentMode, commitments.OptimismGenericCommitmentMode)
+	}
+	return nil
+}
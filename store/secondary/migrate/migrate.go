@@ -0,0 +1,207 @@
+// Package migrate copies the entries of a secondary storage backend to another, e.g. when moving from redis to s3
+// as the long-term fallback, and checks that the entries of two backends are consistent.
+package migrate
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// progressLogInterval is the number of entries processed between progress logs
+const progressLogInterval = 1000
+
+// CopyReport summarizes a copy
+type CopyReport struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Keys is the number of keys listed in the source, whose entries were either copied, already present in the
+	// target, gone from the source by the time they were read (e.g. evicted), or failed to be copied.
+	Keys           int          `json:"keys"`
+	Copied         int          `json:"copied"`
+	AlreadyPresent int          `json:"already_present"`
+	Gone           int          `json:"gone"`
+	Failed         int          `json:"failed"`
+	Failures       []KeyFailure `json:"failures,omitempty"`
+	Duration       string       `json:"duration"`
+}
+
+// KeyFailure is an entry which couldn't be copied
+type KeyFailure struct {
+	// Key is hex encoded
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+type copyResult int
+
+const (
+	copied copyResult = iota
+	alreadyPresent
+	gone
+	copyFailed
+)
+
+// Copier copies all the entries of a secondary storage backend to another
+type Copier struct {
+	log    logging.Logger
+	source common.ListableSecondaryStore
+	target common.SecondaryStore
+	cfg    Config
+	// retryStrategy is the backoff between the attempts to write an entry to the target
+	retryStrategy retry.Strategy
+}
+
+func NewCopier(
+	log logging.Logger, source common.ListableSecondaryStore, target common.SecondaryStore, cfg Config,
+) *Copier {
+	return &Copier{
+		log:           log,
+		source:        source,
+		target:        target,
+		cfg:           cfg,
+		retryStrategy: retry.Exponential(),
+	}
+}
+
+// Run copies the entries of the source to the target. Entries are copied as is, so the entries of all the tenants
+// are copied. The returned report covers the entries processed, including when an error is returned.
+func (c *Copier) Run(ctx context.Context) (*CopyReport, error) {
+	start := time.Now()
+	report := &CopyReport{
+		Source: c.source.BackendType().String(),
+		Target: c.target.BackendType().String(),
+	}
+
+	var reportLock sync.Mutex
+	err := process(ctx, c.cfg.Concurrency, listKeys(c.source), func(ctx context.Context, key []byte) {
+		res, err := c.copy(ctx, key)
+		if ctx.Err() != nil {
+			return
+		}
+		reportLock.Lock()
+		defer reportLock.Unlock()
+		report.record(key, res, err)
+		if report.Keys%progressLogInterval == 0 {
+			c.log.Info("Copy progress", "keys", report.Keys, "copied", report.Copied, "failed", report.Failed)
+		}
+	})
+	report.Duration = time.Since(start).String()
+	if err != nil {
+		return report, fmt.Errorf("copy entries: %w", err)
+	}
+	return report, nil
+}
+
+// copy copies the entry of key to the target, unless the target already holds one and overwriting is disabled
+func (c *Copier) copy(ctx context.Context, key []byte) (copyResult, error) {
+	if !c.cfg.Overwrite {
+		existing, err := get(ctx, c.target, key)
+		if err == nil && existing != nil {
+			return alreadyPresent, nil
+		}
+	}
+
+	value, err := get(ctx, c.source, key)
+	if err != nil {
+		return copyFailed, fmt.Errorf("read source: %w", err)
+	}
+	if value == nil {
+		return gone, nil
+	}
+
+	// retried the same way as the writes to secondary storage of the proxy
+	_, err = retry.Do[any](ctx, 5, c.retryStrategy, func() (any, error) {
+		return nil, c.target.Put(ctx, key, value)
+	})
+	if err != nil {
+		return copyFailed, fmt.Errorf("write target: %w", err)
+	}
+	return copied, nil
+}
+
+func (r *CopyReport) record(key []byte, res copyResult, err error) {
+	r.Keys++
+	switch res {
+	case copied:
+		r.Copied++
+	case alreadyPresent:
+		r.AlreadyPresent++
+	case gone:
+		r.Gone++
+	case copyFailed:
+		r.Failed++
+		r.Failures = append(r.Failures, KeyFailure{Key: hex.EncodeToString(key), Error: err.Error()})
+	}
+}
+
+// get reads the entry of key, and returns nil if there is none. Unlike the other backends, s3 returns an error for
+// missing keys.
+func get(ctx context.Context, store common.SecondaryStore, key []byte) ([]byte, error) {
+	value, err := store.Get(ctx, key)
+	if errors.Is(err, s3.ErrKeccakKeyNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// listKeys returns a producer of the keys of store, for [process]
+func listKeys(store common.ListableSecondaryStore) func(ctx context.Context, out chan<- []byte) error {
+	return func(ctx context.Context, out chan<- []byte) error {
+		return store.ForEachKey(ctx, func(key []byte) error {
+			select {
+			case out <- key:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}
+}
+
+// process calls fn with each item sent by produce, from concurrency goroutines. It returns once all the items were
+// processed, or once ctx is done, in which case the remaining items are skipped.
+func process[T any](
+	ctx context.Context,
+	concurrency int,
+	produce func(ctx context.Context, out chan<- T) error,
+	fn func(ctx context.Context, item T),
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make(chan T, concurrency)
+	var produceErr error
+	go func() {
+		defer close(items)
+		produceErr = produce(ctx, items)
+	}()
+
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range items {
+				if ctx.Err() == nil {
+					fn(ctx, item)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// ctx is only done at this point if the parent ctx is
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return produceErr
+}
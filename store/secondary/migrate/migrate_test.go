@@ -0,0 +1,307 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+type memListableStore struct {
+	mu          sync.Mutex
+	backendType common.BackendType
+	entries     map[string][]byte
+	// evicted keys are still listed, but have no entry
+	evicted map[string]bool
+	// failing keys fail to be written
+	failing map[string]bool
+}
+
+func newMemListableStore(backendType common.BackendType, entries map[string]string) *memListableStore {
+	s := &memListableStore{
+		backendType: backendType,
+		entries:     make(map[string][]byte),
+		evicted:     make(map[string]bool),
+		failing:     make(map[string]bool),
+	}
+	for key, value := range entries {
+		s.entries[key] = []byte(value)
+	}
+	return s
+}
+
+// Get returns the entry of key. As the real backends, s3 stores return an error for missing entries, and other
+// stores an empty value.
+func (s *memListableStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.entries[string(key)]
+	if !ok && s.backendType == common.S3BackendType {
+		return nil, s3.ErrKeccakKeyNotFound
+	}
+	return value, nil
+}
+
+func (s *memListableStore) Put(_ context.Context, key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing[string(key)] {
+		return errors.New("write failed")
+	}
+	s.entries[string(key)] = value
+	return nil
+}
+
+func (s *memListableStore) Verify(_ context.Context, _ []byte, _ []byte) error {
+	return nil
+}
+
+func (s *memListableStore) BackendType() common.BackendType {
+	return s.backendType
+}
+
+func (s *memListableStore) ForEachKey(ctx context.Context, fn func(key []byte) error) error {
+	s.mu.Lock()
+	var keys []string
+	for key := range s.entries {
+		keys = append(keys, key)
+	}
+	for key := range s.evicted {
+		keys = append(keys, key)
+	}
+	s.mu.Unlock()
+	for _, key := range keys {
+		if err := fn([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *memListableStore) value(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return string(s.entries[key])
+}
+
+func testConfig() Config {
+	return Config{
+		Source:      "redis",
+		Target:      "s3",
+		Concurrency: 3,
+		SampleRate:  1,
+	}
+}
+
+func TestCopy(t *testing.T) {
+	source := newMemListableStore(common.RedisBackendType, map[string]string{
+		"a": "value-a",
+		"b": "value-b",
+		"c": "value-c",
+		"d": "value-d",
+	})
+	source.evicted["e"] = true
+	target := newMemListableStore(common.S3BackendType, map[string]string{"b": "existing-b"})
+	target.failing["c"] = true
+
+	copier := NewCopier(testLogger, source, target, testConfig())
+	copier.retryStrategy = retry.Fixed(0)
+	report, err := copier.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Redis", report.Source)
+	require.Equal(t, "S3", report.Target)
+	require.Equal(t, 5, report.Keys)
+	require.Equal(t, 2, report.Copied)
+	require.Equal(t, 1, report.AlreadyPresent)
+	require.Equal(t, 1, report.Gone)
+	require.Equal(t, 1, report.Failed)
+	require.Len(t, report.Failures, 1)
+	require.Equal(t, fmt.Sprintf("%x", "c"), report.Failures[0].Key)
+	require.Contains(t, report.Failures[0].Error, "write failed")
+
+	require.Equal(t, "value-a", target.value("a"))
+	require.Equal(t, "existing-b", target.value("b"))
+	require.Equal(t, "value-d", target.value("d"))
+
+	// overwriting copies the entries the target already holds
+	cfg := testConfig()
+	cfg.Overwrite = true
+	copier = NewCopier(testLogger, source, target, cfg)
+	copier.retryStrategy = retry.Fixed(0)
+	report, err = copier.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Copied)
+	require.Equal(t, "value-b", target.value("b"))
+}
+
+func TestCopyFromS3(t *testing.T) {
+	source := newMemListableStore(common.S3BackendType, map[string]string{"a": "value-a"})
+	// deleted from the bucket after being listed
+	source.evicted["b"] = true
+	target := newMemListableStore(common.RedisBackendType, nil)
+
+	cfg := testConfig()
+	cfg.Source, cfg.Target = "s3", "redis"
+	report, err := NewCopier(testLogger, source, target, cfg).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Copied)
+	require.Equal(t, 1, report.Gone)
+	require.Zero(t, report.Failed)
+	require.Equal(t, "value-a", target.value("a"))
+}
+
+func TestCopyCancelled(t *testing.T) {
+	source := newMemListableStore(common.RedisBackendType, map[string]string{"a": "value-a"})
+	target := newMemListableStore(common.S3BackendType, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewCopier(testLogger, source, target, testConfig()).Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// sortedProblems returns the problems of report in a deterministic order, as entries are checked concurrently
+func sortedProblems(report *CheckReport) []Problem {
+	problems := append([]Problem(nil), report.Problems...)
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Key != problems[j].Key {
+			return problems[i].Key < problems[j].Key
+		}
+		return problems[i].Backend < problems[j].Backend
+	})
+	return problems
+}
+
+func TestCheckKeys(t *testing.T) {
+	source := newMemListableStore(common.RedisBackendType, map[string]string{
+		"a": "value-a",
+		"b": "value-b",
+		"c": "value-c",
+	})
+	source.evicted["e"] = true
+	target := newMemListableStore(common.S3BackendType, map[string]string{
+		"a": "value-a",
+		"b": "corrupted",
+		"d": "value-d",
+	})
+
+	report, err := NewChecker(testLogger, source, target, testConfig()).CheckKeys(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, report.Commitments)
+	require.Equal(t, 4, report.Checked)
+	require.Equal(t, 1, report.Consistent)
+	require.Equal(t, 2, report.Missing)
+	require.Equal(t, 1, report.Mismatched)
+	require.Equal(t, 3, report.Inconsistencies())
+	require.Equal(t, []Problem{
+		{Kind: Mismatched, Key: fmt.Sprintf("%x", "b")},
+		{Kind: Missing, Backend: "S3", Key: fmt.Sprintf("%x", "c")},
+		{Kind: Missing, Backend: "Redis", Key: fmt.Sprintf("%x", "d")},
+	}, sortedProblems(report))
+}
+
+func TestCheckKeysSampled(t *testing.T) {
+	entries := make(map[string]string)
+	for i := range 1000 {
+		entries[fmt.Sprint(i)] = "value"
+	}
+	source := newMemListableStore(common.RedisBackendType, entries)
+	target := newMemListableStore(common.S3BackendType, entries)
+	cfg := testConfig()
+	cfg.SampleRate = 0.2
+
+	report, err := NewChecker(testLogger, source, target, cfg).CheckKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, report.Checked, report.Consistent)
+	require.Greater(t, report.Checked, 100)
+	require.Less(t, report.Checked, 300)
+}
+
+// fakePayloadVerifier accepts the payload "payload-<cert>" of certs
+type fakePayloadVerifier struct{}
+
+func (fakePayloadVerifier) VerifyPayload(versionedCert certs.VersionedCert, payload []byte) error {
+	if string(payload) != "payload-"+string(versionedCert.SerializedCert) {
+		return errors.New("payload doesn't match the cert")
+	}
+	return nil
+}
+
+func TestCheckCommitments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commitments.txt")
+	var file []byte
+	for _, cert := range []string{"cert-a", "cert-b", "cert-c"} {
+		file = append(file, fmt.Sprintf("0x%02x%x\n", byte(certs.V2VersionByte), cert)...)
+	}
+	require.NoError(t, os.WriteFile(path, file, 0o600))
+	key := func(cert string) string {
+		return string(crypto.Keccak256([]byte(cert)))
+	}
+	source := newMemListableStore(common.RedisBackendType, map[string]string{
+		key("cert-a"): "payload-cert-a",
+		key("cert-b"): "corrupted",
+	})
+	target := newMemListableStore(common.S3BackendType, map[string]string{
+		key("cert-a"): "payload-cert-a",
+		key("cert-c"): "payload-cert-c",
+	})
+
+	report, err := NewChecker(testLogger, source, target, testConfig()).CheckCommitments(
+		context.Background(), backfill.NewFileSource(path, commitments.StandardCommitmentMode), fakePayloadVerifier{})
+	require.NoError(t, err)
+	require.Equal(t, "file:"+path, report.Commitments)
+	require.Equal(t, 3, report.Checked)
+	require.Equal(t, 1, report.Consistent)
+	require.Equal(t, 2, report.Missing)
+	require.Equal(t, 1, report.Corrupted)
+
+	problems := make(map[string]Problem)
+	for _, problem := range report.Problems {
+		problems[problem.Backend+":"+problem.Commitment] = problem
+	}
+	require.Len(t, problems, 3)
+	corrupted := problems[fmt.Sprintf("Redis:0x02%x", "cert-b")]
+	require.Equal(t, Corrupted, corrupted.Kind)
+	require.Equal(t, uint64(2), corrupted.Position)
+	require.Equal(t, fmt.Sprintf("%x", key("cert-b")), corrupted.Key)
+	require.Contains(t, corrupted.Error, "doesn't match")
+	require.Equal(t, Missing, problems[fmt.Sprintf("S3:0x02%x", "cert-b")].Kind)
+	require.Equal(t, Missing, problems[fmt.Sprintf("Redis:0x02%x", "cert-c")].Kind)
+}
+
+func TestConfigCheck(t *testing.T) {
+	cfg := testConfig()
+	require.NoError(t, cfg.Check())
+
+	cfg.Target = "redis"
+	require.ErrorContains(t, cfg.Check(), "same backend")
+
+	cfg = testConfig()
+	cfg.Source = "memstore"
+	require.ErrorContains(t, cfg.Check(), "can be listed")
+
+	cfg = testConfig()
+	cfg.SampleRate = 0
+	require.ErrorContains(t, cfg.Check(), "sample rate")
+
+	cfg = testConfig()
+	cfg.CommitmentsFile = "commitments.txt"
+	cfg.CommitmentMode = commitments.OptimismKeccakCommitmentMode
+	require.ErrorContains(t, cfg.Check(), "commitment mode")
+}
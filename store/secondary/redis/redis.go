@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
 	"github.com/go-redis/redis/v8"
 )
 
+// scanCount is the number of keys hinted to be returned by each SCAN call
+const scanCount = 1000
+
 // Config ... user configurable
 type Config struct {
 	Endpoint string
@@ -35,12 +39,13 @@ func (c Config) MarshalJSON() ([]byte, error) {
 // Store ... Redis storage backend implementation
 // go-redis client is safe for concurrent usage: https://github.com/redis/go-redis/blob/v8.11.5/redis.go#L535-L544
 type Store struct {
-	eviction time.Duration
+	// eviction is the time.Duration after which entries expire, which can be changed at runtime by SetEviction.
+	eviction atomic.Int64
 
 	client *redis.Client
 }
 
-var _ common.SecondaryStore = (*Store)(nil)
+var _ common.ListableSecondaryStore = (*Store)(nil)
 
 // NewStore ... constructor
 func NewStore(cfg *Config) (*Store, error) {
@@ -59,10 +64,14 @@ func NewStore(cfg *Config) (*Store, error) {
 		return nil, fmt.Errorf("failed to ping redis server: %w", cmd.Err())
 	}
 
-	return &Store{
-		eviction: cfg.Eviction,
-		client:   client,
-	}, nil
+	store := &Store{client: client}
+	store.SetEviction(cfg.Eviction)
+	return store, nil
+}
+
+// SetEviction ... sets the eviction time of the entries inserted from now on
+func (r *Store) SetEviction(eviction time.Duration) {
+	r.eviction.Store(int64(eviction))
 }
 
 // Get ... retrieves a value from the Redis store. Returns nil if the key is not found vs. an error
@@ -81,7 +90,21 @@ func (r *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
 
 // Put ... inserts a value into the Redis store
 func (r *Store) Put(ctx context.Context, key []byte, value []byte) error {
-	return r.client.Set(ctx, string(key), string(value), r.eviction).Err()
+	return r.client.Set(ctx, string(key), string(value), time.Duration(r.eviction.Load())).Err()
+}
+
+// ForEachKey ... lists the keys of the database with SCAN, which doesn't block the server like KEYS does
+func (r *Store) ForEachKey(ctx context.Context, fn func(key []byte) error) error {
+	iter := r.client.Scan(ctx, 0, "", scanCount).Iterator()
+	for iter.Next(ctx) {
+		if err := fn([]byte(iter.Val())); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("redis SCAN: %w", err)
+	}
+	return nil
 }
 
 func (r *Store) Verify(_ context.Context, _, _ []byte) error {
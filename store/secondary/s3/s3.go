@@ -37,7 +37,7 @@ func StringToCredentialType(s string) CredentialType {
 	}
 }
 
-var _ common.SecondaryStore = (*Store)(nil)
+var _ common.ListableSecondaryStore = (*Store)(nil)
 
 type CredentialType string
 type Config struct {
@@ -105,23 +105,29 @@ func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
 		minio.GetObjectOptions{},
 	)
 	if err != nil {
-		errResponse := minio.ToErrorResponse(err)
-		// minio-go doesn't seem to define an error code enum... so we just use the "NoSuchKey" string manually.
-		// See https://github.com/minio/minio-go/blob/5d96728978e67e3dca618a76cbbad47cc313a45f/s3-error.go#L39
-		if errResponse.Code == "NoSuchKey" {
-			return nil, ErrKeccakKeyNotFound
-		}
-		return nil, err
+		return nil, toKeyNotFoundErr(err)
 	}
 	defer result.Close()
+	// GetObject is lazy: the object is only requested, and found to be missing, when it is first read
 	data, err := io.ReadAll(result)
 	if err != nil {
-		return nil, err
+		return nil, toKeyNotFoundErr(err)
 	}
 
 	return data, nil
 }
 
+// toKeyNotFoundErr returns ErrKeccakKeyNotFound if err is the error of a missing object, and err otherwise
+func toKeyNotFoundErr(err error) error {
+	errResponse := minio.ToErrorResponse(err)
+	// minio-go doesn't seem to define an error code enum... so we just use the "NoSuchKey" string manually.
+	// See https://github.com/minio/minio-go/blob/5d96728978e67e3dca618a76cbbad47cc313a45f/s3-error.go#L39
+	if errResponse.Code == "NoSuchKey" {
+		return ErrKeccakKeyNotFound
+	}
+	return err
+}
+
 func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
 	_, err := s.client.PutObject(
 		ctx,
@@ -137,6 +143,33 @@ func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
 	return nil
 }
 
+// ForEachKey lists the objects under the path of the bucket. Objects whose name isn't a hex encoded key weren't
+// written by the proxy, and are skipped.
+func (s *Store) ForEachKey(ctx context.Context, fn func(key []byte) error) error {
+	// the listing stops once ctx is cancelled
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prefix := ""
+	if s.cfg.Path != "" {
+		prefix = strings.TrimSuffix(s.cfg.Path, "/") + "/"
+	}
+	objects := s.client.ListObjects(ctx, s.cfg.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true})
+	for object := range objects {
+		if object.Err != nil {
+			return fmt.Errorf("S3 ListObjects: %w", object.Err)
+		}
+		key, err := hex.DecodeString(strings.TrimPrefix(object.Key, prefix))
+		if err != nil {
+			continue
+		}
+		if err = fn(key); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
 // TODO: this should probably live elsewhere, it's related to op keccak commitments, not to S3.
 func (s *Store) Verify(_ context.Context, key []byte, value []byte) error {
 	keccakedValue := crypto.Keccak256Hash(value)
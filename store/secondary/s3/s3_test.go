@@ -1,9 +1,17 @@
 package s3
 
 import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsGoogleEndpoint_StorageGoogleapis(t *testing.T) {
@@ -29,3 +37,59 @@ func TestIsGoogleEndpoint_Empty(t *testing.T) {
 	result := isGoogleEndpoint(endpoint)
 	assert.False(t, result, "Expected false for empty endpoint")
 }
+
+// newFakeS3Server returns an S3 endpoint serving objects from a single bucket, which answers requests for missing
+// objects with a NoSuchKey error, as S3 and minio do.
+func newFakeS3Server(t *testing.T, bucket string, objects map[string][]byte) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+				`us-east-1</LocationConstraint>`))
+			return
+		}
+		objectName := strings.TrimPrefix(r.URL.Path, "/"+bucket+"/")
+		object, ok := objects[objectName]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code>` +
+				`<Message>The specified key does not exist.</Message>` +
+				`<Key>` + objectName + `</Key><BucketName>` + bucket + `</BucketName></Error>`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		_, _ = w.Write(object)
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return serverURL.Host
+}
+
+func TestGet(t *testing.T) {
+	key := []byte{0x01, 0x02}
+	endpoint := newFakeS3Server(t, "bucket", map[string][]byte{
+		"path/" + hex.EncodeToString(key): []byte("value"),
+	})
+	store, err := NewStore(Config{
+		CredentialType:  CredentialTypeStatic,
+		Endpoint:        endpoint,
+		AccessKeyID:     "access-key-id",
+		AccessKeySecret: "access-key-secret",
+		Bucket:          "bucket",
+		Path:            "path",
+	})
+	require.NoError(t, err)
+
+	value, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// minio's GetObject is lazy, so the missing object is only reported by the read of its content
+	_, err = store.Get(context.Background(), []byte{0x03})
+	require.ErrorIs(t, err, ErrKeccakKeyNotFound)
+}
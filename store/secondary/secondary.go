@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	proxy_logging "github.com/Layr-Labs/eigenda-proxy/logging"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/tenant"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum/go-ethereum/crypto"
-
-	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type MetricExpression = string
@@ -38,6 +41,7 @@ type ISecondary interface {
 	) ([]byte, error)
 	WriteSubscriptionLoop(ctx context.Context)
 	WriteOnCacheMissEnabled() bool
+	SetTargets(caches []common.SecondaryStore, fallbacks []common.SecondaryStore, writeOnCacheMiss bool)
 }
 
 // PutNotify ... notification received by primary manager to perform insertion across
@@ -45,6 +49,10 @@ type ISecondary interface {
 type PutNotify struct {
 	Commitment []byte
 	Value      []byte
+	// RequestID of the request that triggered the insertion, so that async writes can be correlated with it
+	RequestID string
+	// Tenant that posted the value, whose secondary prefix the entry is written under. Nil for anonymous requests.
+	Tenant *tenant.Tenant
 }
 
 // SecondaryManager ... routing abstraction for secondary storage backends
@@ -52,13 +60,16 @@ type SecondaryManager struct {
 	log logging.Logger
 	m   metrics.Metricer
 
-	caches    []common.SecondaryStore
-	fallbacks []common.SecondaryStore
+	// targetsLock guards caches, fallbacks and writeOnCacheMiss, which can be replaced at runtime by SetTargets.
+	// The slices are never modified in place, so they can be used after releasing the lock.
+	targetsLock      sync.RWMutex
+	caches           []common.SecondaryStore
+	fallbacks        []common.SecondaryStore
+	writeOnCacheMiss bool
 
 	verifyLock       sync.RWMutex
 	topic            chan PutNotify
 	concurrentWrites bool
-	writeOnCacheMiss bool
 }
 
 // NewSecondaryManager ... creates a new secondary storage manager
@@ -83,6 +94,11 @@ func NewSecondaryManager(
 }
 
 // Topic ...
+// logger returns the manager's logger, annotated with the request ID carried by ctx
+func (sm *SecondaryManager) logger(ctx context.Context) logging.Logger {
+	return proxy_logging.FromContext(ctx, sm.log)
+}
+
 func (sm *SecondaryManager) Topic() chan<- PutNotify {
 	return sm.topic
 }
@@ -92,28 +108,57 @@ func (sm *SecondaryManager) Enabled() bool {
 }
 
 func (sm *SecondaryManager) CachingEnabled() bool {
+	sm.targetsLock.RLock()
+	defer sm.targetsLock.RUnlock()
 	return len(sm.caches) > 0
 }
 
 func (sm *SecondaryManager) FallbackEnabled() bool {
+	sm.targetsLock.RLock()
+	defer sm.targetsLock.RUnlock()
 	return len(sm.fallbacks) > 0
 }
 
 func (sm *SecondaryManager) WriteOnCacheMissEnabled() bool {
-	return sm.CachingEnabled() && sm.writeOnCacheMiss
+	sm.targetsLock.RLock()
+	defer sm.targetsLock.RUnlock()
+	return len(sm.caches) > 0 && sm.writeOnCacheMiss
+}
+
+// SetTargets ... replaces the cache and fallback targets, in order of priority. Requests already reading from or
+// writing to the previous targets complete against them.
+func (sm *SecondaryManager) SetTargets(
+	caches []common.SecondaryStore,
+	fallbacks []common.SecondaryStore,
+	writeOnCacheMiss bool,
+) {
+	sm.targetsLock.Lock()
+	defer sm.targetsLock.Unlock()
+	sm.caches = slices.Clone(caches)
+	sm.fallbacks = slices.Clone(fallbacks)
+	sm.writeOnCacheMiss = writeOnCacheMiss
 }
 
 // HandleRedundantWrites ... writes to both sets of backends (i.e, fallback, cache)
 // and returns an error if NONE of them succeed
 func (sm *SecondaryManager) HandleRedundantWrites(ctx context.Context, commitment []byte, value []byte) error {
-	sources := sm.caches
-	sources = append(sources, sm.fallbacks...)
+	ctx, span := tracing.Start(ctx, "SecondaryManager.HandleRedundantWrites",
+		attribute.Int("payload_size", len(value)))
+	err := sm.handleRedundantWrites(ctx, commitment, value)
+	tracing.End(span, err)
+	return err
+}
 
-	key := crypto.Keccak256(commitment)
+func (sm *SecondaryManager) handleRedundantWrites(ctx context.Context, commitment []byte, value []byte) error {
+	sm.targetsLock.RLock()
+	sources := slices.Concat(sm.caches, sm.fallbacks)
+	sm.targetsLock.RUnlock()
+
+	key := EntryKey(ctx, commitment)
 	successes := 0
 
 	for _, src := range sources {
-		sm.log.Debug("Attempting to write to secondary storage", "backend", src.BackendType())
+		sm.logger(ctx).Debug("Attempting to write to secondary storage", "backend", src.BackendType())
 		cb := sm.m.RecordSecondaryRequest(src.BackendType().String(), http.MethodPut)
 
 		// for added safety - we retry the insertion 5x using a default exponential backoff
@@ -126,7 +171,7 @@ func (sm *SecondaryManager) HandleRedundantWrites(ctx context.Context, commitmen
 				) // this implementation assumes that all secondary clients are thread safe
 			})
 		if err != nil {
-			sm.log.Warn("Failed to write to redundant target", "backend", src.BackendType(), "err", err)
+			sm.logger(ctx).Warn("Failed to write to redundant target", "backend", src.BackendType(), "err", err)
 			cb(Failed)
 		} else {
 			successes++
@@ -153,9 +198,13 @@ func (sm *SecondaryManager) WriteSubscriptionLoop(ctx context.Context) {
 	for {
 		select {
 		case notif := <-sm.topic:
-			err := sm.HandleRedundantWrites(context.Background(), notif.Commitment, notif.Value)
+			writeCtx := proxy_logging.ContextWithRequestID(context.Background(), notif.RequestID)
+			if notif.Tenant != nil {
+				writeCtx = tenant.ContextWithTenant(writeCtx, notif.Tenant)
+			}
+			err := sm.HandleRedundantWrites(writeCtx, notif.Commitment, notif.Value)
 			if err != nil {
-				sm.log.Error("Failed to write to redundant targets", "err", err)
+				sm.logger(writeCtx).Error("Failed to write to redundant targets", "err", err)
 			}
 
 		case <-ctx.Done():
@@ -165,6 +214,16 @@ func (sm *SecondaryManager) WriteSubscriptionLoop(ctx context.Context) {
 	}
 }
 
+// EntryKey returns the key of the entry of a commitment in secondary storage: the keccak hash of the commitment,
+// prefixed with the secondary prefix of the tenant making the request, if any.
+func EntryKey(ctx context.Context, commitment []byte) []byte {
+	key := crypto.Keccak256(commitment)
+	if t := tenant.FromContext(ctx); t != nil && t.SecondaryPrefix != "" {
+		key = append([]byte(t.SecondaryPrefix), key...)
+	}
+	return key
+}
+
 // MultiSourceRead ... reads from a set of backends and returns the first successfully read blob
 // NOTE: - this can also be parallelized when reading from multiple sources and discarding connections that fail
 // - for complete optimization we can profile secondary storage backends to determine the fastest / most reliable and
@@ -176,36 +235,54 @@ func (sm *SecondaryManager) MultiSourceRead(
 	// verifyOpts are passed to the verification function
 	verify func(context.Context, []byte, []byte, common.CertVerificationOpts) error,
 	verifyOpts common.CertVerificationOpts,
+) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "SecondaryManager.MultiSourceRead", attribute.Bool("fallback", fallback))
+	data, err := sm.multiSourceRead(ctx, commitment, fallback, verify, verifyOpts)
+	tracing.End(span, err)
+	return data, err
+}
+
+func (sm *SecondaryManager) multiSourceRead(
+	ctx context.Context,
+	commitment []byte,
+	fallback bool,
+	verify func(context.Context, []byte, []byte, common.CertVerificationOpts) error,
+	verifyOpts common.CertVerificationOpts,
 ) ([]byte, error) {
 	var sources []common.SecondaryStore
+	sm.targetsLock.RLock()
 	if fallback {
 		sources = sm.fallbacks
 	} else {
 		sources = sm.caches
 	}
+	sm.targetsLock.RUnlock()
 
-	key := crypto.Keccak256(commitment)
+	key := EntryKey(ctx, commitment)
 	for _, src := range sources {
 		cb := sm.m.RecordSecondaryRequest(src.BackendType().String(), http.MethodGet)
 		data, err := src.Get(ctx, key)
 		if err != nil {
 			cb(Failed)
-			sm.log.Warn("Failed to read from redundant target", "backend", src.BackendType(), "err", err)
+			sm.logger(ctx).Warn("Failed to read from redundant target", "backend", src.BackendType(), "err", err)
 			continue
 		}
 
 		if data == nil {
 			cb(Miss)
-			sm.log.Debug("No data found in redundant target", "backend", src.BackendType())
+			sm.logger(ctx).Debug("No data found in redundant target", "backend", src.BackendType())
 			continue
 		}
 
 		// verify cert:data using provided verification function
 		sm.verifyLock.Lock()
-		err = verify(ctx, commitment, data, verifyOpts)
+		verifyCtx, verifySpan := tracing.Start(ctx, "SecondaryManager.Verify",
+			attribute.String("backend", src.BackendType().String()))
+		err = verify(verifyCtx, commitment, data, verifyOpts)
+		tracing.End(verifySpan, err)
 		if err != nil {
 			cb(Failed)
-			log.Warn("Failed to verify blob", "err", err, "backend", src.BackendType())
+			sm.logger(ctx).Warn("Failed to verify blob", "err", err, "backend", src.BackendType())
 			sm.verifyLock.Unlock()
 			continue
 		}
@@ -0,0 +1,56 @@
+package tenant
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ConfigPathFlagName     = withFlagPrefix("config-path")
+	AllowAnonymousFlagName = withFlagPrefix("allow-anonymous")
+)
+
+func withFlagPrefix(s string) string {
+	return "tenants." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_TENANTS_" + s}
+}
+
+// CLIFlags ... used for configuring multi-tenancy
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: ConfigPathFlagName,
+			Usage: "Path to a JSON file defining the tenants of the proxy: their API key hash, EigenDA V2 signer, " +
+				"allowed commitment modes, quota and secondary storage prefix. Empty disables multi-tenancy.",
+			EnvVars:  withEnvPrefix(envPrefix, "CONFIG_PATH"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name: AllowAnonymousFlagName,
+			Usage: "Serve cert requests without an API key when tenants are configured. Such requests use the " +
+				"default signer and secondary storage keys, and aren't subject to any quota.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ALLOW_ANONYMOUS"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) (Config, error) {
+	cfg := Config{
+		AllowAnonymous: ctx.Bool(AllowAnonymousFlagName),
+	}
+	if path := ctx.String(ConfigPathFlagName); path != "" {
+		tenants, err := LoadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("load tenants: %w", err)
+		}
+		cfg.Tenants = tenants
+	}
+	return cfg, nil
+}
@@ -0,0 +1,202 @@
+package tenant
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+)
+
+// validName restricts tenant names, which are used as metric labels and in logs.
+var validName = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Config ... configures multi-tenancy. It is disabled when no tenant is configured.
+type Config struct {
+	Tenants []Tenant
+	// AllowAnonymous serves cert requests without an API key, without attributing them to any tenant.
+	// When false, cert requests must carry the API key of a configured tenant.
+	AllowAnonymous bool
+}
+
+// Enabled returns true if at least one tenant is configured
+func (c Config) Enabled() bool {
+	return len(c.Tenants) > 0
+}
+
+// Tenant ... is a client of the proxy (typically a rollup), identified by its API key.
+type Tenant struct {
+	Name string
+	// APIKeySHA256 is the hex encoded sha256 hash of the tenant's API key.
+	// Only the hash is configured, so that the tenants file doesn't hold credentials usable against the proxy.
+	APIKeySHA256 string
+	// Signer of the tenant's EigenDA V2 dispersals. Nil means the proxy's default signer is used.
+	Signer *signer.Config
+	// SignerPaymentKey is the raw hex private key of a local Signer.
+	SignerPaymentKey string `json:"-"`
+	// AllowedCommitmentModes restricts the cert routes usable by the tenant. Empty means all modes are allowed.
+	AllowedCommitmentModes []commitments.CommitmentMode
+	Quota                  Quota
+	// SecondaryPrefix is prepended to the keys of the tenant's entries in secondary storage (caches and fallbacks),
+	// so that they are kept apart from other tenants' entries.
+	SecondaryPrefix string
+}
+
+// Quota ... limits the usage of a tenant over fixed windows. A zero limit means unlimited.
+type Quota struct {
+	Window time.Duration
+	// MaxRequests is the number of cert requests (GET and POST) admitted per window.
+	MaxRequests int64
+	// MaxBytes is the number of payload bytes that can be posted per window.
+	MaxBytes int64
+}
+
+// Limited returns true if the quota limits anything
+func (q Quota) Limited() bool {
+	return q.MaxRequests > 0 || q.MaxBytes > 0
+}
+
+// AllowsCommitmentMode returns true if the tenant can use the cert routes of the given commitment mode
+func (t *Tenant) AllowsCommitmentMode(mode commitments.CommitmentMode) bool {
+	return len(t.AllowedCommitmentModes) == 0 || slices.Contains(t.AllowedCommitmentModes, mode)
+}
+
+// Check checks config invariants, and returns an error if there is a problem with the config struct
+func (c Config) Check() error {
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	prefixes := make(map[string]bool)
+	for _, t := range c.Tenants {
+		if !validName.MatchString(t.Name) {
+			return fmt.Errorf("tenant name %q must be 1 to 64 characters of [A-Za-z0-9._-]", t.Name)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate tenant name %q", t.Name)
+		}
+		names[t.Name] = true
+
+		keyHash, err := hex.DecodeString(strings.TrimPrefix(t.APIKeySHA256, "0x"))
+		if err != nil || len(keyHash) != 32 {
+			return fmt.Errorf("tenant %s: api key sha256 must be a hex encoded 32 byte hash", t.Name)
+		}
+		if keys[string(keyHash)] {
+			return fmt.Errorf("tenant %s: api key is shared with another tenant", t.Name)
+		}
+		keys[string(keyHash)] = true
+
+		if t.SecondaryPrefix != "" {
+			if prefixes[t.SecondaryPrefix] {
+				return fmt.Errorf("tenant %s: secondary prefix %q is shared with another tenant", t.Name, t.SecondaryPrefix)
+			}
+			prefixes[t.SecondaryPrefix] = true
+		}
+
+		for _, mode := range t.AllowedCommitmentModes {
+			switch mode {
+			case commitments.OptimismKeccakCommitmentMode, commitments.OptimismGenericCommitmentMode,
+				commitments.StandardCommitmentMode:
+			default:
+				return fmt.Errorf("tenant %s: unknown commitment mode %q", t.Name, mode)
+			}
+		}
+
+		if t.Quota.MaxRequests < 0 || t.Quota.MaxBytes < 0 {
+			return fmt.Errorf("tenant %s: quota limits must be >= 0", t.Name)
+		}
+		if t.Quota.Limited() && t.Quota.Window <= 0 {
+			return fmt.Errorf("tenant %s: quota window must be > 0 when quota limits are set", t.Name)
+		}
+
+		if t.Signer != nil {
+			if err = t.Signer.Check(t.SignerPaymentKey != ""); err != nil {
+				return fmt.Errorf("tenant %s: check signer config: %w", t.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// fileConfig ... is the format of the tenants file. Durations are strings parsed by [time.ParseDuration].
+type fileConfig struct {
+	Tenants []struct {
+		Name         string `json:"name"`
+		APIKeySHA256 string `json:"api_key_sha256"`
+		Signer       *struct {
+			Type                 signer.Type `json:"type"`
+			PrivateKeyHex        string      `json:"private_key_hex"`
+			KeystorePath         string      `json:"keystore_path"`
+			KeystorePasswordFile string      `json:"keystore_password_file"`
+			RemoteURL            string      `json:"remote_url"`
+			RemoteAddress        string      `json:"remote_address"`
+			RemoteTimeout        string      `json:"remote_timeout"`
+		} `json:"signer"`
+		AllowedCommitmentModes []commitments.CommitmentMode `json:"allowed_commitment_modes"`
+		Quota                  struct {
+			Window      string `json:"window"`
+			MaxRequests int64  `json:"max_requests"`
+			MaxBytes    int64  `json:"max_bytes"`
+		} `json:"quota"`
+		SecondaryPrefix string `json:"secondary_prefix"`
+	} `json:"tenants"`
+}
+
+// LoadFile reads the tenants defined in the JSON file at path.
+func LoadFile(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("read tenants file: %w", err)
+	}
+
+	var file fileConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode tenants file %s: %w", path, err)
+	}
+
+	tenants := make([]Tenant, 0, len(file.Tenants))
+	for _, ft := range file.Tenants {
+		t := Tenant{
+			Name:                   ft.Name,
+			APIKeySHA256:           ft.APIKeySHA256,
+			AllowedCommitmentModes: ft.AllowedCommitmentModes,
+			Quota: Quota{
+				MaxRequests: ft.Quota.MaxRequests,
+				MaxBytes:    ft.Quota.MaxBytes,
+			},
+			SecondaryPrefix: ft.SecondaryPrefix,
+		}
+		if ft.Quota.Window != "" {
+			t.Quota.Window, err = time.ParseDuration(ft.Quota.Window)
+			if err != nil {
+				return nil, fmt.Errorf("tenant %s: parse quota window: %w", ft.Name, err)
+			}
+		}
+		if ft.Signer != nil {
+			t.Signer = &signer.Config{
+				Type:                 ft.Signer.Type,
+				KeystorePath:         ft.Signer.KeystorePath,
+				KeystorePasswordFile: ft.Signer.KeystorePasswordFile,
+				RemoteURL:            ft.Signer.RemoteURL,
+				RemoteAddress:        ft.Signer.RemoteAddress,
+				RemoteTimeout:        5 * time.Second,
+			}
+			t.SignerPaymentKey = ft.Signer.PrivateKeyHex
+			if ft.Signer.RemoteTimeout != "" {
+				t.Signer.RemoteTimeout, err = time.ParseDuration(ft.Signer.RemoteTimeout)
+				if err != nil {
+					return nil, fmt.Errorf("tenant %s: parse signer remote timeout: %w", ft.Name, err)
+				}
+			}
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, nil
+}
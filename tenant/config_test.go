@@ -0,0 +1,111 @@
+package tenant
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "tenants": [
+    {
+      "name": "rollup-a",
+      "api_key_sha256": "`+apiKeyHash("key-a")+`",
+      "signer": {
+        "type": "remote",
+        "remote_url": "http://signer:9000",
+        "remote_address": "0x1234",
+        "remote_timeout": "2s"
+      },
+      "allowed_commitment_modes": ["optimism_generic"],
+      "quota": {"window": "24h", "max_requests": 1000, "max_bytes": 1048576},
+      "secondary_prefix": "rollup-a/"
+    },
+    {
+      "name": "rollup-b",
+      "api_key_sha256": "`+apiKeyHash("key-b")+`"
+    }
+  ]
+}`), 0o600))
+
+	tenants, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, tenants, 2)
+	require.Equal(t, Tenant{
+		Name:         "rollup-a",
+		APIKeySHA256: apiKeyHash("key-a"),
+		Signer: &signer.Config{
+			Type:          signer.TypeRemote,
+			RemoteURL:     "http://signer:9000",
+			RemoteAddress: "0x1234",
+			RemoteTimeout: 2 * time.Second,
+		},
+		AllowedCommitmentModes: []commitments.CommitmentMode{commitments.OptimismGenericCommitmentMode},
+		Quota:                  Quota{Window: 24 * time.Hour, MaxRequests: 1000, MaxBytes: 1048576},
+		SecondaryPrefix:        "rollup-a/",
+	}, tenants[0])
+	require.Nil(t, tenants[1].Signer)
+	require.False(t, tenants[1].Quota.Limited())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"tenants": [{"name": "rollup-a", "api_key": "key-a"}]}`), 0o600))
+	_, err = LoadFile(path)
+	require.Error(t, err, "unknown fields are rejected, e.g. a raw api key instead of its hash")
+}
+
+func TestConfigCheck(t *testing.T) {
+	valid := func() Tenant {
+		return Tenant{Name: "rollup-a", APIKeySHA256: apiKeyHash("key-a"), SecondaryPrefix: "a/"}
+	}
+	other := Tenant{Name: "rollup-b", APIKeySHA256: apiKeyHash("key-b"), SecondaryPrefix: "b/"}
+
+	tests := []struct {
+		name    string
+		mutate  func(t *Tenant)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(*Tenant) {}},
+		{name: "invalid name", mutate: func(t *Tenant) { t.Name = "rollup a" }, wantErr: true},
+		{name: "duplicate name", mutate: func(t *Tenant) { t.Name = other.Name }, wantErr: true},
+		{name: "invalid key hash", mutate: func(t *Tenant) { t.APIKeySHA256 = "key-a" }, wantErr: true},
+		{name: "duplicate key hash", mutate: func(t *Tenant) { t.APIKeySHA256 = other.APIKeySHA256 }, wantErr: true},
+		{name: "duplicate prefix", mutate: func(t *Tenant) { t.SecondaryPrefix = other.SecondaryPrefix }, wantErr: true},
+		{
+			name:    "unknown commitment mode",
+			mutate:  func(t *Tenant) { t.AllowedCommitmentModes = []commitments.CommitmentMode{"keccak"} },
+			wantErr: true,
+		},
+		{name: "quota without window", mutate: func(t *Tenant) { t.Quota.MaxBytes = 10 }, wantErr: true},
+		{
+			name:    "local signer without key",
+			mutate:  func(t *Tenant) { t.Signer = &signer.Config{Type: signer.TypeLocal} },
+			wantErr: true,
+		},
+		{
+			name: "local signer",
+			mutate: func(t *Tenant) {
+				t.Signer = &signer.Config{Type: signer.TypeLocal}
+				t.SignerPaymentKey = "0123"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := valid()
+			tt.mutate(&tenant)
+			err := Config{Tenants: []Tenant{other, tenant}}.Check()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
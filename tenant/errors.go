@@ -0,0 +1,39 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+)
+
+var (
+	// ErrMissingAPIKey is returned for requests without an API key, when anonymous requests aren't allowed
+	ErrMissingAPIKey = errors.New("missing api key")
+	// ErrUnknownAPIKey is returned for requests whose API key doesn't belong to any tenant
+	ErrUnknownAPIKey = errors.New("unknown api key")
+)
+
+// CommitmentModeNotAllowedError is returned when a tenant uses a cert route of a commitment mode it isn't allowed.
+type CommitmentModeNotAllowedError struct {
+	Tenant string
+	Mode   commitments.CommitmentMode
+}
+
+func (e CommitmentModeNotAllowedError) Error() string {
+	return fmt.Sprintf("tenant %s is not allowed to use commitment mode %s", e.Tenant, e.Mode)
+}
+
+// QuotaExceededError is returned when a tenant exhausted its quota for the current window.
+type QuotaExceededError struct {
+	Tenant string
+	// Limit is the exhausted limit: "requests" or "bytes"
+	Limit string
+	// RetryAfter is the time left until the current quota window ends
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("tenant %s exhausted its %s quota, retry in %s", e.Tenant, e.Limit, e.RetryAfter.Round(time.Second))
+}
@@ -0,0 +1,153 @@
+package tenant
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+)
+
+// Registry ... resolves the tenant of each request from its API key, and enforces the tenant's
+// allowed commitment modes and quota. A nil Registry is valid and means multi-tenancy is disabled.
+type Registry struct {
+	tenants        []*Tenant
+	keyHashes      [][sha256.Size]byte
+	allowAnonymous bool
+
+	mu     sync.Mutex
+	usages map[string]*usage
+	now    func() time.Time
+}
+
+// usage ... of a tenant over the current quota window
+type usage struct {
+	windowStart time.Time
+	requests    int64
+	bytes       int64
+}
+
+// NewRegistry ... constructor. Returns nil when no tenant is configured.
+// cfg is expected to have been checked with [Config.Check].
+func NewRegistry(cfg Config) *Registry {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	r := &Registry{
+		tenants:        make([]*Tenant, len(cfg.Tenants)),
+		keyHashes:      make([][sha256.Size]byte, len(cfg.Tenants)),
+		allowAnonymous: cfg.AllowAnonymous,
+		usages:         make(map[string]*usage, len(cfg.Tenants)),
+		now:            time.Now,
+	}
+	for i := range cfg.Tenants {
+		t := cfg.Tenants[i]
+		r.tenants[i] = &t
+		keyHash, _ := hex.DecodeString(strings.TrimPrefix(t.APIKeySHA256, "0x"))
+		copy(r.keyHashes[i][:], keyHash)
+		r.usages[t.Name] = &usage{}
+	}
+	return r
+}
+
+// Enabled returns true if tenants are configured
+func (r *Registry) Enabled() bool {
+	return r != nil
+}
+
+// Tenants returns the configured tenants
+func (r *Registry) Tenants() []*Tenant {
+	if r == nil {
+		return nil
+	}
+	return r.tenants
+}
+
+// Resolve returns the tenant whose API key is apiKey.
+// A nil tenant and nil error are returned for requests without an API key when anonymous requests are allowed.
+func (r *Registry) Resolve(apiKey string) (*Tenant, error) {
+	if apiKey == "" {
+		if r.allowAnonymous {
+			return nil, nil
+		}
+		return nil, ErrMissingAPIKey
+	}
+
+	// all hashes are compared in constant time, so that response times don't leak which tenant almost matched
+	keyHash := sha256.Sum256([]byte(apiKey))
+	var found *Tenant
+	for i, h := range r.keyHashes {
+		if subtle.ConstantTimeCompare(keyHash[:], h[:]) == 1 {
+			found = r.tenants[i]
+		}
+	}
+	if found == nil {
+		return nil, ErrUnknownAPIKey
+	}
+	return found, nil
+}
+
+// Admit checks that the tenant is allowed to use the commitment mode, and that its quota isn't exhausted.
+// Admitted requests are counted against the quota. payloadBytes is the size of the posted payload when known
+// in advance (0 for GET requests): the request is rejected if it would exceed the bytes quota.
+// The actual size of the payload must then be counted with [Registry.RecordBytes].
+func (r *Registry) Admit(t *Tenant, mode commitments.CommitmentMode, payloadBytes int64) error {
+	if !t.AllowsCommitmentMode(mode) {
+		return CommitmentModeNotAllowedError{Tenant: t.Name, Mode: mode}
+	}
+	if !t.Quota.Limited() {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u := r.currentUsage(t)
+	retryAfter := u.windowStart.Add(t.Quota.Window).Sub(r.now())
+	if t.Quota.MaxRequests > 0 && u.requests >= t.Quota.MaxRequests {
+		return &QuotaExceededError{Tenant: t.Name, Limit: "requests", RetryAfter: retryAfter}
+	}
+	if t.Quota.MaxBytes > 0 && (u.bytes >= t.Quota.MaxBytes || u.bytes+payloadBytes > t.Quota.MaxBytes) {
+		return &QuotaExceededError{Tenant: t.Name, Limit: "bytes", RetryAfter: retryAfter}
+	}
+	u.requests++
+	return nil
+}
+
+// RecordBytes counts payload bytes posted by the tenant against its quota.
+func (r *Registry) RecordBytes(t *Tenant, n int64) {
+	if t.Quota.MaxBytes <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.currentUsage(t).bytes += n
+}
+
+// currentUsage returns the usage of the tenant over the current window, starting a new window if the previous
+// one has ended. Must be called with mu held.
+func (r *Registry) currentUsage(t *Tenant) *usage {
+	u := r.usages[t.Name]
+	now := r.now()
+	if now.Sub(u.windowStart) >= t.Quota.Window {
+		*u = usage{windowStart: now}
+	}
+	return u
+}
+
+type contextKey struct{}
+
+// ContextWithTenant returns a copy of ctx carrying the tenant of the request.
+func ContextWithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant carried by ctx, or nil if the request isn't attributed to any tenant.
+func FromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(contextKey{}).(*Tenant)
+	return t
+}
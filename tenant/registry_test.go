@@ -0,0 +1,101 @@
+package tenant
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/common/types/commitments"
+	"github.com/stretchr/testify/require"
+)
+
+func apiKeyHash(apiKey string) string {
+	h := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(h[:])
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	cfg := Config{Tenants: []Tenant{
+		{Name: "rollup-a", APIKeySHA256: apiKeyHash("key-a")},
+		{Name: "rollup-b", APIKeySHA256: "0x" + apiKeyHash("key-b")},
+	}}
+	require.NoError(t, cfg.Check())
+
+	registry := NewRegistry(cfg)
+	require.True(t, registry.Enabled())
+
+	tenant, err := registry.Resolve("key-a")
+	require.NoError(t, err)
+	require.Equal(t, "rollup-a", tenant.Name)
+	tenant, err = registry.Resolve("key-b")
+	require.NoError(t, err)
+	require.Equal(t, "rollup-b", tenant.Name)
+
+	_, err = registry.Resolve("key-c")
+	require.ErrorIs(t, err, ErrUnknownAPIKey)
+	_, err = registry.Resolve("")
+	require.ErrorIs(t, err, ErrMissingAPIKey)
+
+	cfg.AllowAnonymous = true
+	tenant, err = NewRegistry(cfg).Resolve("")
+	require.NoError(t, err)
+	require.Nil(t, tenant, "anonymous requests aren't attributed to any tenant")
+}
+
+func TestRegistry_DisabledWithoutTenants(t *testing.T) {
+	registry := NewRegistry(Config{AllowAnonymous: true})
+	require.Nil(t, registry)
+	require.False(t, registry.Enabled())
+	require.Empty(t, registry.Tenants())
+}
+
+func TestRegistry_AdmitCommitmentModes(t *testing.T) {
+	registry := NewRegistry(Config{Tenants: []Tenant{{
+		Name:                   "rollup-a",
+		APIKeySHA256:           apiKeyHash("key-a"),
+		AllowedCommitmentModes: []commitments.CommitmentMode{commitments.OptimismGenericCommitmentMode},
+	}}})
+	tenant := registry.Tenants()[0]
+
+	require.NoError(t, registry.Admit(tenant, commitments.OptimismGenericCommitmentMode, 0))
+	var notAllowedErr CommitmentModeNotAllowedError
+	require.ErrorAs(t, registry.Admit(tenant, commitments.StandardCommitmentMode, 0), &notAllowedErr)
+	require.Equal(t, commitments.StandardCommitmentMode, notAllowedErr.Mode)
+}
+
+func TestRegistry_AdmitQuota(t *testing.T) {
+	registry := NewRegistry(Config{Tenants: []Tenant{{
+		Name:         "rollup-a",
+		APIKeySHA256: apiKeyHash("key-a"),
+		Quota:        Quota{Window: time.Hour, MaxRequests: 3, MaxBytes: 100},
+	}}})
+	now := time.Unix(1_000_000, 0)
+	registry.now = func() time.Time { return now }
+	tenant := registry.Tenants()[0]
+	mode := commitments.OptimismGenericCommitmentMode
+
+	// payloads announced to exceed the bytes quota are rejected upfront
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, registry.Admit(tenant, mode, 101), &quotaErr)
+	require.Equal(t, "bytes", quotaErr.Limit)
+
+	require.NoError(t, registry.Admit(tenant, mode, 60))
+	registry.RecordBytes(tenant, 60)
+	require.ErrorAs(t, registry.Admit(tenant, mode, 60), &quotaErr)
+	require.Equal(t, "bytes", quotaErr.Limit)
+
+	// GET requests only count against the requests quota
+	require.NoError(t, registry.Admit(tenant, mode, 0))
+	now = now.Add(15 * time.Minute)
+	require.NoError(t, registry.Admit(tenant, mode, 0))
+	require.ErrorAs(t, registry.Admit(tenant, mode, 0), &quotaErr)
+	require.Equal(t, "requests", quotaErr.Limit)
+	require.Equal(t, 45*time.Minute, quotaErr.RetryAfter)
+
+	// the quota is reset when the window ends
+	now = now.Add(45 * time.Minute)
+	require.NoError(t, registry.Admit(tenant, mode, 100))
+	registry.RecordBytes(tenant, 100)
+	require.ErrorAs(t, registry.Admit(tenant, mode, 1), &quotaErr)
+}
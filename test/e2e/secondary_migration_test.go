@@ -0,0 +1,58 @@
+package e2e
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/migrate"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda-proxy/test/testutils"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSecondaryMigration copies the entries of redis to a new s3 bucket, listing the keys of both backends
+func TestSecondaryMigration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	log := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	redisConfig := testutils.CreateRedisConfig()
+	// the other tests use the default database, whose keys would be copied as well
+	redisConfig.DB = 1
+	redisStore, err := redis.NewStore(&redisConfig)
+	require.NoError(t, err)
+	s3Store, err := s3.NewStore(testutils.CreateS3Config())
+	require.NoError(t, err)
+
+	entries := make(map[string][]byte)
+	for range 20 {
+		key := testutils.RandBytes(32)
+		entries[string(key)] = testutils.RandBytes(1000)
+		require.NoError(t, redisStore.Put(ctx, key, entries[string(key)]))
+	}
+	cfg := migrate.Config{Source: "redis", Target: "s3", Concurrency: 4, SampleRate: 1}
+	checker := migrate.NewChecker(log, redisStore, s3Store, cfg)
+
+	checkReport, err := checker.CheckKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(entries), checkReport.Missing)
+
+	copyReport, err := migrate.NewCopier(log, redisStore, s3Store, cfg).Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(entries), copyReport.Keys)
+	require.Equal(t, len(entries), copyReport.Copied)
+	for key, value := range entries {
+		copied, getErr := s3Store.Get(ctx, []byte(key))
+		require.NoError(t, getErr)
+		require.Equal(t, value, copied)
+	}
+
+	checkReport, err = checker.CheckKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(entries), checkReport.Checked)
+	require.Equal(t, len(entries), checkReport.Consistent)
+	require.Zero(t, checkReport.Inconsistencies())
+}
@@ -223,12 +223,43 @@ func testProxyCachingWithRedis(t *testing.T, dispersalBackend common.EigenDABack
 	requireDispersalRetrievalEigenDA(t, ts.Metrics.HTTPServerRequestsTotal, commitments.StandardCommitmentMode)
 }
 
+func TestProxyCachingWithGCSV1(t *testing.T) {
+	testProxyCachingWithGCS(t, common.V1EigenDABackend)
+}
+
+func TestProxyCachingWithGCSV2(t *testing.T) {
+	testProxyCachingWithGCS(t, common.V2EigenDABackend)
+}
+
+func testProxyCachingWithGCS(t *testing.T, dispersalBackend common.EigenDABackend) {
+	t.Parallel()
+
+	testCfg := testutils.NewTestConfig(testutils.GetBackend(), dispersalBackend, nil)
+	testCfg.UseGCSCaching = true
+
+	tsConfig := testutils.BuildTestSuiteConfig(testCfg)
+	ts, kill := testutils.CreateTestSuite(tsConfig)
+	defer kill()
+
+	requireStandardClientSetGet(t, ts, testutils.RandBytes(1_000_000))
+	requireWriteReadSecondary(t, ts.Metrics.SecondaryRequestsTotal, common.GCSBackendType)
+	requireDispersalRetrievalEigenDA(t, ts.Metrics.HTTPServerRequestsTotal, commitments.StandardCommitmentMode)
+}
+
 func TestProxyReadFallbackV1(t *testing.T) {
-	testProxyReadFallback(t, common.V1EigenDABackend)
+	testProxyReadFallback(t, common.V1EigenDABackend, common.S3BackendType)
 }
 
 func TestProxyReadFallbackV2(t *testing.T) {
-	testProxyReadFallback(t, common.V2EigenDABackend)
+	testProxyReadFallback(t, common.V2EigenDABackend, common.S3BackendType)
+}
+
+func TestProxyReadFallbackWithGCSV1(t *testing.T) {
+	testProxyReadFallback(t, common.V1EigenDABackend, common.GCSBackendType)
+}
+
+func TestProxyReadFallbackWithGCSV2(t *testing.T) {
+	testProxyReadFallback(t, common.V2EigenDABackend, common.GCSBackendType)
 }
 
 /*
@@ -236,7 +267,7 @@ Ensure that fallback location is read from when EigenDA blob is not available.
 This is done by setting the memstore expiration time to 1ms and waiting for the blob to expire
 before attempting to read it.
 */
-func testProxyReadFallback(t *testing.T, dispersalBackend common.EigenDABackend) {
+func testProxyReadFallback(t *testing.T, dispersalBackend common.EigenDABackend, fallback common.BackendType) {
 	t.Parallel()
 
 	if testutils.GetBackend() != testutils.MemstoreBackend {
@@ -245,7 +276,8 @@ func testProxyReadFallback(t *testing.T, dispersalBackend common.EigenDABackend)
 	}
 
 	testCfg := testutils.NewTestConfig(testutils.GetBackend(), dispersalBackend, nil)
-	testCfg.UseS3Fallback = true
+	testCfg.UseS3Fallback = fallback == common.S3BackendType
+	testCfg.UseGCSFallback = fallback == common.GCSBackendType
 	// ensure that blob memstore eviction times result in near immediate activation
 	testCfg.Expiration = time.Millisecond * 1
 
@@ -269,7 +301,7 @@ func testProxyReadFallback(t *testing.T, dispersalBackend common.EigenDABackend)
 	require.Equal(t, expectedBlob, actualBlob)
 
 	requireStandardClientSetGet(t, ts, testutils.RandBytes(1_000_000))
-	requireWriteReadSecondary(t, ts.Metrics.SecondaryRequestsTotal, common.S3BackendType)
+	requireWriteReadSecondary(t, ts.Metrics.SecondaryRequestsTotal, fallback)
 	requireDispersalRetrievalEigenDA(t, ts.Metrics.HTTPServerRequestsTotal, commitments.StandardCommitmentMode)
 }
 
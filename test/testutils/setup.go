@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	gcsstorage "cloud.google.com/go/storage"
 	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/signer"
 	"github.com/Layr-Labs/eigenda-proxy/config"
 	"github.com/Layr-Labs/eigenda-proxy/config/eigendaflags"
 	proxy_metrics "github.com/Layr-Labs/eigenda-proxy/metrics"
@@ -16,7 +18,9 @@ import (
 	"github.com/Layr-Labs/eigenda-proxy/store"
 	"github.com/Layr-Labs/eigenda-proxy/store/builder"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda/verify"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda-proxy/store/secondary/gcs"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/api/clients"
@@ -25,16 +29,21 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/docker/go-connections/nat"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/api/option"
 
+	"github.com/testcontainers/testcontainers-go"
 	miniotc "github.com/testcontainers/testcontainers-go/modules/minio"
 	redistc "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
 	minioAdmin       = "minioadmin"
+	fakeGCSPort      = nat.Port("4443/tcp")
 	backendEnvVar    = "BACKEND"
 	privateKeyEnvVar = "SIGNER_PRIVATE_KEY"
 	ethRPCEnvVar     = "ETHEREUM_RPC"
@@ -63,6 +72,8 @@ var (
 	minioEndpoint = ""
 	// set by startRedisContainer
 	redisEndpoint = ""
+	// set by startFakeGCSContainer
+	gcsEndpoint = ""
 )
 
 // TODO: we shouldn't start the containers in the init function like this.
@@ -78,6 +89,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	err = startFakeGCSContainer()
+	if err != nil {
+		panic(err)
+	}
 }
 
 // startMinIOContainer starts a MinIO container and sets the minioEndpoint global variable
@@ -125,6 +140,33 @@ func startRedisContainer() error {
 	return nil
 }
 
+// startFakeGCSContainer starts a fake-gcs-server container emulating the GCS JSON API, and sets the gcsEndpoint
+// global variable
+func startFakeGCSContainer() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	gcsContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "fsouza/fake-gcs-server:1.50.2",
+			Cmd:          []string{"-scheme", "http", "-port", fakeGCSPort.Port()},
+			ExposedPorts: []string{string(fakeGCSPort)},
+			WaitingFor:   wait.ForListeningPort(fakeGCSPort),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start fake GCS container: %w", err)
+	}
+
+	endpoint, err := gcsContainer.PortEndpoint(ctx, fakeGCSPort, "http")
+	if err != nil {
+		return fmt.Errorf("failed to get fake GCS endpoint: %w", err)
+	}
+	gcsEndpoint = endpoint + "/storage/v1/"
+	return nil
+}
+
 type Backend int
 
 const (
@@ -172,6 +214,8 @@ type TestConfig struct {
 	UseS3Caching       bool
 	UseRedisCaching    bool
 	UseS3Fallback      bool
+	UseGCSCaching      bool
+	UseGCSFallback     bool
 }
 
 // NewTestConfig returns a new TestConfig
@@ -199,12 +243,15 @@ func NewTestConfig(
 		UseS3Caching:       false,
 		UseRedisCaching:    false,
 		UseS3Fallback:      false,
+		UseGCSCaching:      false,
+		UseGCSFallback:     false,
 		WriteThreadCount:   0,
 		WriteOnCacheMiss:   false,
 	}
 }
 
-func createRedisConfig() redis.Config {
+// CreateRedisConfig returns the config of the Redis container
+func CreateRedisConfig() redis.Config {
 	return redis.Config{
 		Endpoint: redisEndpoint,
 		Password: "",
@@ -213,7 +260,8 @@ func createRedisConfig() redis.Config {
 	}
 }
 
-func createS3Config() s3.Config {
+// CreateS3Config creates a new bucket in the MinIO container, and returns its config
+func CreateS3Config() s3.Config {
 	// generate random string
 	bucketName := "eigenda-proxy-test-" + RandStr(10)
 	createS3Bucket(bucketName)
@@ -229,6 +277,19 @@ func createS3Config() s3.Config {
 	}
 }
 
+// CreateGCSConfig creates a new bucket in the fake GCS container, and returns its config
+func CreateGCSConfig() gcs.Config {
+	bucketName := "eigenda-proxy-test-" + RandStr(10)
+	createGCSBucket(bucketName)
+
+	return gcs.Config{
+		Bucket:         bucketName,
+		Path:           "",
+		Endpoint:       gcsEndpoint,
+		CredentialType: gcs.CredentialTypeNone,
+	}
+}
+
 // nolint: funlen
 func BuildTestSuiteConfig(testCfg TestConfig) config.AppConfig {
 	useMemory := testCfg.Backend == MemstoreBackend
@@ -330,7 +391,8 @@ func BuildTestSuiteConfig(testCfg TestConfig) config.AppConfig {
 				BlobExpiration:   testCfg.Expiration,
 				MaxBlobSizeBytes: maxBlobLengthBytes,
 			}),
-		MemstoreEnabled: useMemory,
+		MemstoreEnabled:        useMemory,
+		MemstoreExpiryNotifier: expiry.NewNotifier(),
 		ClientConfigV2: common.ClientConfigV2{
 			DisperserClientCfg: clientsv2.DisperserClientConfig{
 				Hostname:          disperserHostname,
@@ -354,6 +416,7 @@ func BuildTestSuiteConfig(testCfg TestConfig) config.AppConfig {
 			BLSOperatorStateRetrieverAddr:      blsOperatorStateRetrieverAddress,
 			EigenDAServiceManagerAddr:          svcManagerAddress,
 			RetrieversToEnable:                 testCfg.Retrievers,
+			Signer:                             signer.Config{Type: signer.TypeLocal},
 		},
 	}
 	if useMemory {
@@ -365,16 +428,22 @@ func BuildTestSuiteConfig(testCfg TestConfig) config.AppConfig {
 	}
 	switch {
 	case testCfg.UseKeccak256ModeS3:
-		builderConfig.S3Config = createS3Config()
+		builderConfig.S3Config = CreateS3Config()
 	case testCfg.UseS3Caching:
 		builderConfig.StoreConfig.CacheTargets = []string{"S3"}
-		builderConfig.S3Config = createS3Config()
+		builderConfig.S3Config = CreateS3Config()
 	case testCfg.UseS3Fallback:
 		builderConfig.StoreConfig.FallbackTargets = []string{"S3"}
-		builderConfig.S3Config = createS3Config()
+		builderConfig.S3Config = CreateS3Config()
 	case testCfg.UseRedisCaching:
 		builderConfig.StoreConfig.CacheTargets = []string{"redis"}
-		builderConfig.RedisConfig = createRedisConfig()
+		builderConfig.RedisConfig = CreateRedisConfig()
+	case testCfg.UseGCSCaching:
+		builderConfig.StoreConfig.CacheTargets = []string{"gcs"}
+		builderConfig.GCSConfig = CreateGCSConfig()
+	case testCfg.UseGCSFallback:
+		builderConfig.StoreConfig.FallbackTargets = []string{"gcs"}
+		builderConfig.GCSConfig = CreateGCSConfig()
 	}
 	secretConfig := common.SecretConfigV2{
 		SignerPaymentKey: pk,
@@ -419,3 +488,17 @@ func createS3Bucket(bucketName string) {
 		log.Info(fmt.Sprintf("Successfully created %s\n", bucketName))
 	}
 }
+
+func createGCSBucket(bucketName string) {
+	ctx := context.Background()
+	client, err := gcsstorage.NewClient(ctx, option.WithEndpoint(gcsEndpoint), option.WithoutAuthentication())
+	if err != nil {
+		panic(err)
+	}
+	defer client.Close()
+	// the project is ignored by the fake GCS server
+	if err = client.Bucket(bucketName).Create(ctx, "eigenda-proxy-test", nil); err != nil {
+		panic(err)
+	}
+	log.Info(fmt.Sprintf("Successfully created %s\n", bucketName))
+}
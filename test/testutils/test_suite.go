@@ -9,6 +9,7 @@ import (
 	proxy_metrics "github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/server"
 	"github.com/Layr-Labs/eigenda-proxy/store/builder"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/expiry"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
@@ -78,8 +79,12 @@ func CreateTestSuite(
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)
 	if appConfig.StoreBuilderConfig.MemstoreEnabled {
-		memconfig.NewHandlerHTTP(logger, appConfig.StoreBuilderConfig.MemstoreConfig).
-			RegisterMemstoreConfigHandlers(router)
+		proxyServer.RegisterExternalRoutes(router,
+			memconfig.NewHandlerHTTP(logger, appConfig.StoreBuilderConfig.MemstoreConfig).
+				RegisterMemstoreConfigHandlers)
+		proxyServer.RegisterExternalRoutes(router,
+			expiry.NewHandlerHTTP(logger, appConfig.StoreBuilderConfig.MemstoreExpiryNotifier).
+				RegisterExpiryEventHandlers)
 	}
 
 	if err := proxyServer.Start(router); err != nil {
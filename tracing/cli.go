@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	EnabledFlagName      = withFlagPrefix("enabled")
+	OTLPEndpointFlagName = withFlagPrefix("otlp-endpoint")
+	SampleRatioFlagName  = withFlagPrefix("sample-ratio")
+)
+
+const defaultOTLPEndpoint = "http://localhost:4318"
+
+func withFlagPrefix(s string) string {
+	return "tracing." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_TRACING_" + s}
+}
+
+// Config ... configures the OpenTelemetry tracer provider
+type Config struct {
+	// Enabled turns on span exporting. When disabled, spans are no-ops, but incoming W3C traceparent headers
+	// are still propagated.
+	Enabled bool
+	// OTLPEndpoint is the URL of the OTLP/HTTP collector that spans are exported to.
+	OTLPEndpoint string
+	// SampleRatio is the fraction of root traces that are sampled, in [0, 1].
+	// Spans with a sampled remote parent are always sampled.
+	SampleRatio float64
+}
+
+func (c Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.OTLPEndpoint == "" {
+		return fmt.Errorf("tracing enabled but no OTLP endpoint set")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be in [0, 1], got %f", c.SampleRatio)
+	}
+	return nil
+}
+
+// CLIFlags ... used for configuring OpenTelemetry tracing
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Export OpenTelemetry traces of every request to an OTLP/HTTP collector.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     OTLPEndpointFlagName,
+			Usage:    "URL of the OTLP/HTTP collector to export traces to. Use an https:// URL to enable TLS.",
+			Value:    defaultOTLPEndpoint,
+			EnvVars:  withEnvPrefix(envPrefix, "OTLP_ENDPOINT"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name: SampleRatioFlagName,
+			Usage: "Fraction of traces started by proxy that are sampled, in [0, 1]. " +
+				"Requests with a sampled W3C traceparent header are always sampled.",
+			Value:    1,
+			EnvVars:  withEnvPrefix(envPrefix, "SAMPLE_RATIO"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Enabled:      ctx.Bool(EnabledFlagName),
+		OTLPEndpoint: ctx.String(OTLPEndpointFlagName),
+		SampleRatio:  ctx.Float64(SampleRatioFlagName),
+	}
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda-proxy/common"
+	"github.com/Layr-Labs/eigenda-proxy/common/types/certs"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TracedV1Store ... wraps an EigenDA V1 store (or V1 memstore), creating a span for every call.
+type TracedV1Store struct {
+	common.EigenDAV1Store
+}
+
+var _ common.EigenDAV1Store = (*TracedV1Store)(nil)
+
+func NewTracedV1Store(store common.EigenDAV1Store) *TracedV1Store {
+	return &TracedV1Store{EigenDAV1Store: store}
+}
+
+func (t *TracedV1Store) Put(ctx context.Context, payload []byte) ([]byte, error) {
+	ctx, span := Start(ctx, t.BackendType().String()+".Put", attribute.Int("payload_size", len(payload)))
+	serializedCert, err := t.EigenDAV1Store.Put(ctx, payload)
+	End(span, err)
+	return serializedCert, err
+}
+
+func (t *TracedV1Store) Get(ctx context.Context, serializedCert []byte) ([]byte, error) {
+	ctx, span := Start(ctx, t.BackendType().String()+".Get")
+	payload, err := t.EigenDAV1Store.Get(ctx, serializedCert)
+	span.SetAttributes(attribute.Int("payload_size", len(payload)))
+	End(span, err)
+	return payload, err
+}
+
+func (t *TracedV1Store) Verify(
+	ctx context.Context, serializedCert []byte, payload []byte, opts common.CertVerificationOpts,
+) error {
+	ctx, span := Start(ctx, t.BackendType().String()+".Verify")
+	err := t.EigenDAV1Store.Verify(ctx, serializedCert, payload, opts)
+	End(span, err)
+	return err
+}
+
+// TracedV2Store ... wraps an EigenDA V2 store (or V2 memstore), creating a span for every call.
+type TracedV2Store struct {
+	common.EigenDAV2Store
+}
+
+var _ common.EigenDAV2Store = (*TracedV2Store)(nil)
+
+func NewTracedV2Store(store common.EigenDAV2Store) *TracedV2Store {
+	return &TracedV2Store{EigenDAV2Store: store}
+}
+
+func (t *TracedV2Store) Put(ctx context.Context, payload []byte) ([]byte, error) {
+	ctx, span := Start(ctx, t.BackendType().String()+".Put", attribute.Int("payload_size", len(payload)))
+	serializedCert, err := t.EigenDAV2Store.Put(ctx, payload)
+	End(span, err)
+	return serializedCert, err
+}
+
+func (t *TracedV2Store) Get(ctx context.Context, versionedCert certs.VersionedCert) ([]byte, error) {
+	ctx, span := Start(ctx, t.BackendType().String()+".Get", attribute.Int("cert_version", int(versionedCert.Version)))
+	payload, err := t.EigenDAV2Store.Get(ctx, versionedCert)
+	span.SetAttributes(attribute.Int("payload_size", len(payload)))
+	End(span, err)
+	return payload, err
+}
+
+func (t *TracedV2Store) Verify(
+	ctx context.Context, versionedCert certs.VersionedCert, opts common.CertVerificationOpts,
+) error {
+	ctx, span := Start(ctx, t.BackendType().String()+".Verify",
+		attribute.Int("cert_version", int(versionedCert.Version)),
+		// #nosec G115 - block numbers don't overflow int64
+		attribute.Int64("l1_inclusion_block_number", int64(opts.L1InclusionBlockNum)),
+	)
+	err := t.EigenDAV2Store.Verify(ctx, versionedCert, opts)
+	End(span, err)
+	return err
+}
+
+// TracedSecondaryStore ... wraps a secondary (cache/fallback) store, creating a span for every call.
+type TracedSecondaryStore struct {
+	common.SecondaryStore
+}
+
+var _ common.SecondaryStore = (*TracedSecondaryStore)(nil)
+
+func NewTracedSecondaryStore(store common.SecondaryStore) *TracedSecondaryStore {
+	return &TracedSecondaryStore{SecondaryStore: store}
+}
+
+func (t *TracedSecondaryStore) Put(ctx context.Context, key []byte, value []byte) error {
+	ctx, span := Start(ctx, t.BackendType().String()+".Put", attribute.Int("payload_size", len(value)))
+	err := t.SecondaryStore.Put(ctx, key, value)
+	End(span, err)
+	return err
+}
+
+func (t *TracedSecondaryStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	ctx, span := Start(ctx, t.BackendType().String()+".Get")
+	value, err := t.SecondaryStore.Get(ctx, key)
+	span.SetAttributes(attribute.Bool("hit", value != nil), attribute.Int("payload_size", len(value)))
+	End(span, err)
+	return value, err
+}
+
+func (t *TracedSecondaryStore) Verify(ctx context.Context, key []byte, value []byte) error {
+	ctx, span := Start(ctx, t.BackendType().String()+".Verify")
+	err := t.SecondaryStore.Verify(ctx, key, value)
+	End(span, err)
+	return err
+}